@@ -236,6 +236,20 @@ type SealVerifyInfo struct {
 	UnsealedCID cid.Cid `checked:"true"` // CommD
 }
 
+///
+/// Replica updates
+///
+
+// Information needed to verify a replica update proof, which shows that a sector's sealed replica
+// has been re-encoded with new data without resealing.
+type ReplicaUpdateInfo struct {
+	UpdateProofType      RegisteredSealProof
+	OldSealedSectorCID   cid.Cid
+	NewSealedSectorCID   cid.Cid
+	NewUnsealedSectorCID cid.Cid
+	Proof                []byte
+}
+
 ///
 /// PoSting
 ///
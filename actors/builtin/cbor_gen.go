@@ -157,3 +157,127 @@ func (t *ConfirmSectorProofsParams) UnmarshalCBOR(r io.Reader) error {
 
 	return nil
 }
+
+var lengthBufApplyRewardParams = []byte{130}
+
+func (t *ApplyRewardParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufApplyRewardParams); err != nil {
+		return err
+	}
+
+	// t.Reward (big.Int) (struct)
+	if err := t.Reward.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Penalty (big.Int) (struct)
+	if err := t.Penalty.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ApplyRewardParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ApplyRewardParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Reward (big.Int) (struct)
+
+	{
+
+		if err := t.Reward.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Reward: %w", err)
+		}
+
+	}
+	// t.Penalty (big.Int) (struct)
+
+	{
+
+		if err := t.Penalty.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Penalty: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufApplyRewardsReturn = []byte{130}
+
+func (t *ApplyRewardsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufApplyRewardsReturn); err != nil {
+		return err
+	}
+
+	// t.Locked (big.Int) (struct)
+	if err := t.Locked.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.DebtRepaid (big.Int) (struct)
+	if err := t.DebtRepaid.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ApplyRewardsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = ApplyRewardsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Locked (big.Int) (struct)
+
+	{
+
+		if err := t.Locked.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Locked: %w", err)
+		}
+
+	}
+	// t.DebtRepaid (big.Int) (struct)
+
+	{
+
+		if err := t.DebtRepaid.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.DebtRepaid: %w", err)
+		}
+
+	}
+	return nil
+}
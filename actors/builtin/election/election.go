@@ -0,0 +1,116 @@
+package election
+
+import (
+	gbig "math/big"
+
+	"github.com/minio/blake2b-simd"
+
+	abi "github.com/filecoin-project/specs-actors/actors/abi"
+	big "github.com/filecoin-project/specs-actors/actors/abi/big"
+	builtin "github.com/filecoin-project/specs-actors/actors/builtin"
+	power "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	math "github.com/filecoin-project/specs-actors/actors/util/math"
+)
+
+// Probabilities are held in Q.256 fixed point format, matching the 256-bit uniform sample.
+const precision = math.Precision256
+
+// Expected consensus elects a number of block producers for each epoch by Poisson sortition.
+// A miner's wins in an epoch are distributed as Poisson(λ) with rate λ = ExpectedLeadersPerEpoch * power / totalPower,
+// and are sampled by inverting the distribution's CDF at a uniform value derived from the miner's VRF ticket.
+// All arithmetic is deterministic integer fixed point, so that all nodes compute identical win counts.
+// Probabilities are carried in Q.256, with e^-λ evaluated natively in Q.256 by math.ExpNeg256.
+
+// The maximum number of wins by a single miner in an epoch.
+var MaxWinCount = 3 * builtin.ExpectedLeadersPerEpoch
+
+// Computes the number of blocks a miner is elected to produce in an epoch, given its VRF proof for the epoch's
+// election and its fraction of total network power.
+// The uniform sample is the blake2b-256 hash of the VRF proof, interpreted as a number in [0, 1).
+func ComputeWinCount(vrfProof []byte, minerPower, totalPower abi.StoragePower) int64 {
+	h := blake2b.Sum256(vrfProof)
+	sample := new(gbig.Int).SetBytes(h[:]) // 256 bits, as Q.256 in [0, 1)
+	return PoissonWinCount(big.Int{Int: sample}, minerPower, totalPower)
+}
+
+// Computes the number of blocks a miner is elected to produce in an epoch, given its claim and the network's
+// total quality-adjusted power, as recorded by the power actor at the epoch.
+func ComputeClaimWinCount(vrfProof []byte, claim *power.Claim, totalQAPower abi.StoragePower) int64 {
+	return ComputeWinCount(vrfProof, claim.QualityAdjPower, totalQAPower)
+}
+
+// Computes the number of wins for a uniform sample in [0, 1), in Q.256 format.
+// This is the number of times the sample is below the inverse CDF of Poisson(λ),
+// i.e. the least k for which sample >= 1 - CDF(k), capped at MaxWinCount.
+func PoissonWinCount(sample big.Int, minerPower, totalPower abi.StoragePower) int64 {
+	if minerPower.LessThanEqual(big.Zero()) || totalPower.LessThanEqual(big.Zero()) {
+		return 0
+	}
+	p, icdf := newPoisson(lambda(minerPower, totalPower))
+	var wins int64
+	for sample.Int.Cmp(icdf) < 0 && wins < MaxWinCount {
+		icdf = p.next()
+		wins++
+	}
+	return wins
+}
+
+// Computes the block reward a miner expects to earn per epoch, being its expected number of wins times the
+// reward per win paid by the reward actor.
+// This neglects the small probability of wins beyond MaxWinCount.
+func ExpectedRewardPerEpoch(epochReward abi.TokenAmount, minerPower, totalPower abi.StoragePower) abi.TokenAmount {
+	if totalPower.LessThanEqual(big.Zero()) {
+		return big.Zero()
+	}
+	// λ * epochReward / ExpectedLeadersPerEpoch
+	return big.Div(big.Mul(epochReward, minerPower), totalPower)
+}
+
+// Computes the Poisson rate λ = ExpectedLeadersPerEpoch * minerPower / totalPower.
+// Output is in Q.256 format.
+func lambda(minerPower, totalPower abi.StoragePower) *gbig.Int {
+	lam := new(gbig.Int).Mul(minerPower.Int, gbig.NewInt(builtin.ExpectedLeadersPerEpoch)) // Q.0
+	lam = lam.Lsh(lam, precision)                                                          // Q.0 => Q.256
+	return lam.Div(lam, totalPower.Int)                                                    // Q.256 / Q.0 => Q.256
+}
+
+// Incrementally computes the inverse (complementary) CDF of a Poisson distribution, icdf(k) = 1 - CDF(k).
+type poisson struct {
+	lam  *gbig.Int // Q.256
+	pmf  *gbig.Int // Q.256
+	icdf *gbig.Int // Q.256
+	tmp  *gbig.Int
+	k    uint64
+}
+
+// Starts a Poisson inverse CDF with rate λ in Q.256 format.
+// Returns the instance and icdf(0) = 1 - pmf(0) = 1 - e^-λ in Q.256 format.
+func newPoisson(lam *gbig.Int) (*poisson, *gbig.Int) {
+	pmf := math.ExpNeg256(lam) // Q.256
+
+	icdf := new(gbig.Int).Lsh(gbig.NewInt(1), precision) // Q.256
+	icdf = icdf.Sub(icdf, pmf)                           // Q.256
+
+	return &poisson{
+		lam:  lam,
+		pmf:  pmf,
+		icdf: icdf,
+		tmp:  new(gbig.Int),
+		k:    0,
+	}, icdf
+}
+
+// Computes icdf(k+1) and increments k.
+// Output is in Q.256 format.
+func (p *poisson) next() *gbig.Int {
+	// pmf(k) = λ^k * e^-λ / k! = pmf(k-1) * λ / k
+	p.k++
+	p.tmp.SetUint64(p.k)                // Q.0
+	p.pmf = p.pmf.Div(p.pmf, p.tmp)     // Q.256 / Q.0 => Q.256
+	p.tmp = p.tmp.Mul(p.pmf, p.lam)     // Q.256 * Q.256 => Q.512
+	p.pmf = p.pmf.Rsh(p.tmp, precision) // Q.512 >> 256 => Q.256
+
+	// icdf(k) = icdf(k-1) - pmf(k)
+	p.icdf = p.icdf.Sub(p.icdf, p.pmf) // Q.256
+	return p.icdf
+}
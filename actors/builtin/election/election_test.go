@@ -0,0 +1,145 @@
+package election_test
+
+import (
+	"bytes"
+	"fmt"
+	gbig "math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xorcare/golden"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin/election"
+	"github.com/filecoin-project/specs-actors/actors/builtin/power"
+)
+
+// Returns num/denom in Q.256 format.
+func q256(num, denom int64) big.Int {
+	return big.Div(big.Lsh(big.NewInt(num), 256), big.NewInt(denom))
+}
+
+func TestPoissonWinCount(t *testing.T) {
+	// Thresholds 1 - CDF(k) of each Poisson distribution were computed independently to 60 significant digits.
+	// Samples are chosen away from thresholds so that the win count is unambiguous.
+	for _, tc := range []struct {
+		minerPower, totalPower int64
+		sampleNum, sampleDenom int64
+		wins                   int64
+	}{
+		// λ = 1: thresholds 0.632, 0.264, 0.0803, 0.0190, 0.00366, 0.000594, 8.32e-5, 1.02e-5, 1.13e-6
+		{1, 5, 7, 10, 0},
+		{1, 5, 1, 2, 1},
+		{1, 5, 1, 10, 2},
+		{1, 5, 1, 20, 3},
+		{1, 5, 1, 100, 4},
+		{1, 5, 1, 1000, 5},
+		{1, 5, 1, 10_000, 6},
+		{1, 5, 1, 100_000, 8},
+		// λ = 0.05: thresholds 0.0488, 0.00121, 2.01e-5
+		{1, 100, 1, 2, 0},
+		{1, 100, 1, 100, 1},
+		{1, 100, 1, 10_000, 2},
+		// λ = 2.5: thresholds 0.918, 0.713, 0.456, 0.242, 0.109, 0.0420
+		{1, 2, 19, 20, 0},
+		{1, 2, 1, 2, 2},
+		{1, 2, 1, 20, 5},
+		// λ = 5: thresholds 0.993, 0.960, 0.875, 0.735, 0.560, 0.384
+		{5, 5, 995, 1000, 0},
+		{5, 5, 1, 2, 5},
+		{5, 5, 0, 1, election.MaxWinCount},
+		// No power
+		{0, 5, 0, 1, 0},
+		{0, 0, 0, 1, 0},
+	} {
+		t.Run(fmt.Sprintf("power %d/%d sample %d/%d", tc.minerPower, tc.totalPower, tc.sampleNum, tc.sampleDenom), func(t *testing.T) {
+			wins := election.PoissonWinCount(q256(tc.sampleNum, tc.sampleDenom), big.NewInt(tc.minerPower), big.NewInt(tc.totalPower))
+			assert.Equal(t, tc.wins, wins)
+		})
+	}
+}
+
+func TestPoissonThresholds(t *testing.T) {
+	// Thresholds floor((1 - CDF(k)) * 2^256) for k = 0..3 were computed independently with 700-bit arithmetic.
+	// A sample a few units in the last place above a threshold wins k times, and one just below it wins k+1 times.
+	const margin = 1 << 8
+	for _, tc := range []struct {
+		minerPower, totalPower int64
+		thresholds             []string
+	}{
+		{1, 5, []string{ // λ = 1
+			"a1d2a7274c4320e54521387d6fab06f22567fa554a9388cccfdb462f5af82512",
+			"43a54e4e988641ca8a4270fadf560de44acff4aa952711999fb68c5eb5f04a24",
+			"148ea1e23ea7d23d2cd30d39972b915d5d83f1d53a70d60007a42f76636c5cad",
+			"04dc6868cb5dad0e0dadebf929c81285b9154638c6dec2222a48bb28f295b830",
+		}},
+		{1, 2, []string{ // λ = 2.5
+			"eafc7a3f6b0bdb58a1b7cb5428fd7bfff866e684aba17fc8624837d25f21aeee",
+			"b673abddf6a97fb6360347a68f7731ffe56826d058b53f3d57fcc3604cf5e444",
+			"74c8a9e4252e8d2b2f61a30d8f8f557fcda9b72ef10dee8f8b1e71d1b63f26ee",
+			"3e0f7d93f69d6db7548599e364f8c86a648b04d31aad2b540b0fd8858dfc33d2",
+		}},
+		{5, 5, []string{ // λ = 5
+			"fe466c01ff2ac89e3ba3db6eb65a46a239e7bf27f07f5d8ebd1db8d14817b1cd",
+			"f5a6880bfb00b3b565d72498461da7cd5b6e7aefa2fc31586eb254e7b08e2ad2",
+			"e016ce24f0977f6f4f575b802d861ab92f3f5062e13442d0aaa5db1fb5b6595c",
+			"bc2742f93492d2fa7f2d0c58048984ece59b5ecd9e3c5fee63e6657d13a3fc44",
+		}},
+	} {
+		for k, hex := range tc.thresholds {
+			t.Run(fmt.Sprintf("power %d/%d threshold %d", tc.minerPower, tc.totalPower, k), func(t *testing.T) {
+				v, ok := new(gbig.Int).SetString(hex, 16)
+				require.True(t, ok)
+				threshold := big.Int{Int: v}
+				minerPower, totalPower := big.NewInt(tc.minerPower), big.NewInt(tc.totalPower)
+
+				above := big.Add(threshold, big.NewInt(margin))
+				assert.Equal(t, int64(k), election.PoissonWinCount(above, minerPower, totalPower))
+				below := big.Sub(threshold, big.NewInt(margin))
+				assert.Equal(t, int64(k+1), election.PoissonWinCount(below, minerPower, totalPower))
+			})
+		}
+	}
+}
+
+func TestComputeWinCount(t *testing.T) {
+	minerPower := abi.NewStoragePower(1 << 40)
+	totalPower := big.Mul(minerPower, big.NewInt(5))
+
+	t.Run("test vectors", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		b.WriteString("proof, wins\n")
+		for i := 0; i < 64; i++ {
+			proof := []byte(fmt.Sprintf("vrf-proof-%d", i))
+			fmt.Fprintf(b, "%s,%d\n", proof, election.ComputeWinCount(proof, minerPower, totalPower))
+		}
+		golden.Assert(t, b.Bytes())
+	})
+
+	t.Run("mean wins match power fraction", func(t *testing.T) {
+		// With λ = 1, the mean of 4000 samples is within 0.05 of 1 with high probability.
+		const n = 4000
+		total := int64(0)
+		for i := 0; i < n; i++ {
+			total += election.ComputeWinCount([]byte(fmt.Sprintf("mean-%d", i)), minerPower, totalPower)
+		}
+		assert.InDelta(t, 1.0, float64(total)/n, 0.05)
+	})
+
+	t.Run("uses claim quality-adjusted power", func(t *testing.T) {
+		claim := power.Claim{RawBytePower: big.Zero(), QualityAdjPower: minerPower}
+		for i := 0; i < 16; i++ {
+			proof := []byte(fmt.Sprintf("claim-%d", i))
+			assert.Equal(t, election.ComputeWinCount(proof, minerPower, totalPower), election.ComputeClaimWinCount(proof, &claim, totalPower))
+		}
+	})
+}
+
+func TestExpectedRewardPerEpoch(t *testing.T) {
+	reward := abi.NewTokenAmount(5000)
+	assert.Equal(t, abi.NewTokenAmount(1000), election.ExpectedRewardPerEpoch(reward, big.NewInt(1), big.NewInt(5)))
+	assert.Equal(t, abi.NewTokenAmount(50), election.ExpectedRewardPerEpoch(reward, big.NewInt(1), big.NewInt(100)))
+	assert.Equal(t, big.Zero(), election.ExpectedRewardPerEpoch(reward, big.Zero(), big.Zero()))
+}
@@ -0,0 +1,83 @@
+package market
+
+import (
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	cid "github.com/ipfs/go-cid"
+	xerrors "golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+)
+
+// An index from actor address to the set of IDs of deals to which the actor is party.
+// Backed by a SetMultimap keyed by address.
+type DealIndex struct {
+	sm *SetMultimap
+}
+
+// Interprets a store as a deal index with root `r`.
+func AsDealIndex(s adt.Store, r cid.Cid) (*DealIndex, error) {
+	sm, err := AsSetMultimap(s, r)
+	if err != nil {
+		return nil, err
+	}
+	return &DealIndex{sm}, nil
+}
+
+// Returns the root cid of the underlying HAMT.
+func (idx *DealIndex) Root() (cid.Cid, error) {
+	return idx.sm.Root()
+}
+
+// Adds a deal to the set for an address.
+func (idx *DealIndex) Put(a addr.Address, dealID abi.DealID) error {
+	if err := idx.sm.Put(adt.AddrKey(a), dealID); err != nil {
+		return xerrors.Errorf("failed to index deal %d for %v: %w", dealID, a, err)
+	}
+	return nil
+}
+
+// Removes a deal from the set for an address, removing the set if it becomes empty.
+// It is an error to remove a deal which is not in the set.
+func (idx *DealIndex) Remove(a addr.Address, dealID abi.DealID) error {
+	if err := idx.sm.Remove(adt.AddrKey(a), dealID); err != nil {
+		return xerrors.Errorf("failed to remove deal %d from index for %v: %w", dealID, a, err)
+	}
+	return nil
+}
+
+// Returns up to limit IDs of deals in the set for an address that are no less than start, in ascending order.
+// Also returns whether there are further such deals, which may be listed from one more than the last ID returned.
+// Only limit IDs are held in memory, however many deals are in the set.
+func (idx *DealIndex) Get(a addr.Address, start abi.DealID, limit uint64) ([]abi.DealID, bool, error) {
+	if limit == 0 {
+		return nil, false, xerrors.Errorf("page limit must be positive")
+	}
+
+	// The least limit IDs seen, in ascending order.
+	var dealIDs []abi.DealID
+	more := false
+	err := idx.sm.ForEach(adt.AddrKey(a), func(dealID abi.DealID) error {
+		if dealID < start {
+			return nil
+		}
+		i := sort.Search(len(dealIDs), func(i int) bool { return dealIDs[i] > dealID })
+		if uint64(len(dealIDs)) == limit {
+			more = true
+			if i == len(dealIDs) {
+				return nil
+			}
+			dealIDs = dealIDs[:len(dealIDs)-1]
+		}
+		dealIDs = append(dealIDs, 0)
+		copy(dealIDs[i+1:], dealIDs[i:])
+		dealIDs[i] = dealID
+		return nil
+	})
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to iterate deals for %v: %w", a, err)
+	}
+	return dealIDs, more, nil
+}
@@ -204,7 +204,7 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 	rt.State().Transaction(&st, func() interface{} {
 		msm, err := st.mutator(adt.AsStore(rt)).withPendingProposals(WritePermission).
 			withDealProposals(WritePermission).withDealsByEpoch(WritePermission).withEscrowTable(WritePermission).
-			withLockedTable(WritePermission).build()
+			withLockedTable(WritePermission).withDealIndexes(WritePermission).build()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load state")
 
 		// All storage dealProposals will be added in an atomic transaction; this operation will be unrolled if any of them fails.
@@ -243,9 +243,12 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 			err = msm.dealProposals.Set(id, &deal.Proposal)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set deal")
 
-			err = msm.dealsByEpoch.Put(deal.Proposal.StartEpoch, id)
+			err = msm.dealsByEpoch.Put(epochKey(deal.Proposal.StartEpoch), id)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set deal ops by epoch")
 
+			err = msm.indexDeal(id, &deal.Proposal)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to index deal")
+
 			newDealIds = append(newDealIds, id)
 		}
 
@@ -465,11 +468,11 @@ func (a Actor) CronTick(rt Runtime, params *adt.EmptyValue) *adt.EmptyValue {
 
 		msm, err := st.mutator(adt.AsStore(rt)).withDealStates(WritePermission).
 			withLockedTable(WritePermission).withEscrowTable(WritePermission).withDealsByEpoch(WritePermission).
-			withDealProposals(WritePermission).withPendingProposals(WritePermission).build()
+			withDealProposals(WritePermission).withPendingProposals(WritePermission).withDealIndexes(WritePermission).build()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load state")
 
 		for i := st.LastCron + 1; i <= rt.CurrEpoch(); i++ {
-			if err := msm.dealsByEpoch.ForEach(i, func(dealID abi.DealID) error {
+			if err := msm.dealsByEpoch.ForEach(epochKey(i), func(dealID abi.DealID) error {
 				deal, err := getDealProposal(msm.dealProposals, dealID)
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get dealId %d", dealID)
 
@@ -498,6 +501,8 @@ func (a Actor) CronTick(rt Runtime, params *adt.EmptyValue) *adt.EmptyValue {
 					if err := deleteDealProposalAndState(dealID, msm.dealStates, msm.dealProposals, true, false); err != nil {
 						builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete deal")
 					}
+					err = msm.unindexDeal(dealID, deal)
+					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to unindex deal")
 
 					// remove provider account if escrow balance is now zero.
 					err, code := msm.removeAccountIfNoBalance(deal.Provider)
@@ -520,6 +525,8 @@ func (a Actor) CronTick(rt Runtime, params *adt.EmptyValue) *adt.EmptyValue {
 					if err := deleteDealProposalAndState(dealID, msm.dealStates, msm.dealProposals, true, true); err != nil {
 						builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete deal")
 					}
+					err = msm.unindexDeal(dealID, deal)
+					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to unindex deal")
 				}
 				if !slashAmount.IsZero() {
 					amountSlashed = big.Add(amountSlashed, slashAmount)
@@ -551,7 +558,7 @@ func (a Actor) CronTick(rt Runtime, params *adt.EmptyValue) *adt.EmptyValue {
 			}); err != nil {
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to iterate deals for epoch")
 			}
-			builtin.RequireNoErr(rt, msm.dealsByEpoch.RemoveAll(i), exitcode.ErrIllegalState, "failed to delete deals from set")
+			builtin.RequireNoErr(rt, msm.dealsByEpoch.RemoveAll(epochKey(i)), exitcode.ErrIllegalState, "failed to delete deals from set")
 		}
 
 		// Iterate changes in sorted order to ensure that loads/stores
@@ -565,7 +572,7 @@ func (a Actor) CronTick(rt Runtime, params *adt.EmptyValue) *adt.EmptyValue {
 		sort.Slice(changedEpochs, func(i, j int) bool { return changedEpochs[i] < changedEpochs[j] })
 
 		for _, epoch := range changedEpochs {
-			if err := msm.dealsByEpoch.PutMany(epoch, updatesNeeded[epoch]); err != nil {
+			if err := msm.dealsByEpoch.PutMany(epochKey(epoch), updatesNeeded[epoch]); err != nil {
 				rt.Abortf(exitcode.ErrIllegalState, "failed to reinsert deal IDs into epoch set: %s", err)
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 
+	addr "github.com/filecoin-project/go-address"
 	"github.com/ipfs/go-cid"
 	xerrors "golang.org/x/xerrors"
 
@@ -53,6 +54,10 @@ type State struct {
 	DealOpsByEpoch cid.Cid // SetMultimap, HAMT[epoch]Set
 	LastCron       abi.ChainEpoch
 
+	// IDs of deals that have been published and not yet removed, indexed by client and by provider ID address.
+	DealsByClient   cid.Cid // DealIndex, HAMT[address]Set
+	DealsByProvider cid.Cid // DealIndex, HAMT[address]Set
+
 	// Total Client Collateral that is locked -> unlocked when deal is terminated
 	TotalClientLockedCollateral abi.TokenAmount
 	// Total Provider Collateral that is locked -> unlocked when deal is terminated
@@ -71,6 +76,8 @@ func ConstructState(emptyArrayCid, emptyMapCid, emptyMSetCid cid.Cid) *State {
 		NextID:           abi.DealID(0),
 		DealOpsByEpoch:   emptyMSetCid,
 		LastCron:         abi.ChainEpoch(-1),
+		DealsByClient:    emptyMSetCid,
+		DealsByProvider:  emptyMSetCid,
 
 		TotalClientLockedCollateral:   abi.NewTokenAmount(0),
 		TotalProviderLockedCollateral: abi.NewTokenAmount(0),
@@ -78,6 +85,28 @@ func ConstructState(emptyArrayCid, emptyMapCid, emptyMSetCid cid.Cid) *State {
 	}
 }
 
+// Returns up to limit IDs, no less than start and in ascending order, of deals published and not yet removed
+// in which an address is the client. Also returns whether there are further such deals.
+// The address must be an ID address.
+func (st *State) DealsForClient(s adt.Store, client addr.Address, start abi.DealID, limit uint64) ([]abi.DealID, bool, error) {
+	idx, err := AsDealIndex(s, st.DealsByClient)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load deals by client: %w", err)
+	}
+	return idx.Get(client, start, limit)
+}
+
+// Returns up to limit IDs, no less than start and in ascending order, of deals published and not yet removed
+// in which an address is the provider. Also returns whether there are further such deals.
+// The address must be an ID address.
+func (st *State) DealsForProvider(s adt.Store, provider addr.Address, start abi.DealID, limit uint64) ([]abi.DealID, bool, error) {
+	idx, err := AsDealIndex(s, st.DealsByProvider)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load deals by provider: %w", err)
+	}
+	return idx.Get(provider, start, limit)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // Deal state operations
 ////////////////////////////////////////////////////////////////////////////////
@@ -197,6 +226,28 @@ func (m *marketStateMutation) processDealExpired(rt Runtime, deal *DealProposal,
 	}
 }
 
+// Adds a newly published deal to the client and provider deal indexes.
+func (m *marketStateMutation) indexDeal(dealID abi.DealID, deal *DealProposal) error {
+	if err := m.dealsByClient.Put(deal.Client, dealID); err != nil {
+		return xerrors.Errorf("failed to index deal %d by client: %w", dealID, err)
+	}
+	if err := m.dealsByProvider.Put(deal.Provider, dealID); err != nil {
+		return xerrors.Errorf("failed to index deal %d by provider: %w", dealID, err)
+	}
+	return nil
+}
+
+// Removes a deal from the client and provider deal indexes.
+func (m *marketStateMutation) unindexDeal(dealID abi.DealID, deal *DealProposal) error {
+	if err := m.dealsByClient.Remove(deal.Client, dealID); err != nil {
+		return xerrors.Errorf("failed to unindex deal %d by client: %w", dealID, err)
+	}
+	if err := m.dealsByProvider.Remove(deal.Provider, dealID); err != nil {
+		return xerrors.Errorf("failed to unindex deal %d by provider: %w", dealID, err)
+	}
+	return nil
+}
+
 func (m *marketStateMutation) generateStorageDealID() abi.DealID {
 	ret := m.nextDealId
 	m.nextDealId = m.nextDealId + abi.DealID(1)
@@ -267,6 +318,10 @@ type marketStateMutation struct {
 	dpePermit    MarketStateMutationPermission
 	dealsByEpoch *SetMultimap
 
+	indexPermit     MarketStateMutationPermission
+	dealsByClient   *DealIndex
+	dealsByProvider *DealIndex
+
 	lockedPermit                  MarketStateMutationPermission
 	lockedTable                   *adt.BalanceTable
 	totalClientLockedCollateral   abi.TokenAmount
@@ -332,6 +387,19 @@ func (m *marketStateMutation) build() (*marketStateMutation, error) {
 		m.dealsByEpoch = dbe
 	}
 
+	if m.indexPermit != Invalid {
+		byClient, err := AsDealIndex(m.store, m.st.DealsByClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load deals by client: %w", err)
+		}
+		m.dealsByClient = byClient
+		byProvider, err := AsDealIndex(m.store, m.st.DealsByProvider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load deals by provider: %w", err)
+		}
+		m.dealsByProvider = byProvider
+	}
+
 	m.nextDealId = m.st.NextID
 
 	return m, nil
@@ -367,6 +435,11 @@ func (m *marketStateMutation) withDealsByEpoch(permit MarketStateMutationPermiss
 	return m
 }
 
+func (m *marketStateMutation) withDealIndexes(permit MarketStateMutationPermission) *marketStateMutation {
+	m.indexPermit = permit
+	return m
+}
+
 func (m *marketStateMutation) commitState() error {
 	var err error
 	if m.proposalPermit == WritePermission {
@@ -408,6 +481,15 @@ func (m *marketStateMutation) commitState() error {
 		}
 	}
 
+	if m.indexPermit == WritePermission {
+		if m.st.DealsByClient, err = m.dealsByClient.Root(); err != nil {
+			return fmt.Errorf("failed to flush deals by client: %w", err)
+		}
+		if m.st.DealsByProvider, err = m.dealsByProvider.Root(); err != nil {
+			return fmt.Errorf("failed to flush deals by provider: %w", err)
+		}
+	}
+
 	m.st.NextID = m.nextDealId
 
 	return nil
@@ -47,11 +47,79 @@ func TestRemoveAllError(t *testing.T) {
 
 	smm := market.MakeEmptySetMultimap(store)
 
-	if err := smm.RemoveAll(42); err != nil {
+	if err := smm.RemoveAll(adt.UIntKey(42)); err != nil {
 		t.Fatalf("expected no error, got: %s", err)
 	}
 }
 
+func TestDealIndex(t *testing.T) {
+	marketActor := tutil.NewIDAddr(t, 100)
+	party := tutil.NewIDAddr(t, 101)
+	other := tutil.NewIDAddr(t, 102)
+	builder := mock.NewBuilder(context.Background(), marketActor)
+
+	newIndex := func(t *testing.T, dealIDs ...abi.DealID) *market.DealIndex {
+		store := adt.AsStore(builder.Build(t))
+		root, err := market.MakeEmptySetMultimap(store).Root()
+		require.NoError(t, err)
+		idx, err := market.AsDealIndex(store, root)
+		require.NoError(t, err)
+		for _, id := range dealIDs {
+			require.NoError(t, idx.Put(party, id))
+		}
+		return idx
+	}
+
+	t.Run("lists deals a page at a time in ascending order", func(t *testing.T) {
+		idx := newIndex(t, 9, 3, 0, 7, 5, 1)
+
+		page, more, err := idx.Get(party, 0, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []abi.DealID{0, 1, 3, 5}, page)
+		assert.True(t, more)
+
+		page, more, err = idx.Get(party, 6, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []abi.DealID{7, 9}, page)
+		assert.False(t, more)
+
+		page, more, err = idx.Get(party, 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []abi.DealID{3, 5}, page)
+		assert.True(t, more)
+
+		page, more, err = idx.Get(other, 0, 4)
+		require.NoError(t, err)
+		assert.Empty(t, page)
+		assert.False(t, more)
+
+		_, _, err = idx.Get(party, 0, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("removes deals and empty sets", func(t *testing.T) {
+		idx := newIndex(t, 1, 2)
+		emptyRoot, err := newIndex(t).Root()
+		require.NoError(t, err)
+
+		require.NoError(t, idx.Remove(party, 1))
+		page, _, err := idx.Get(party, 0, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []abi.DealID{2}, page)
+
+		// Removing the last deal removes the set.
+		require.NoError(t, idx.Remove(party, 2))
+		root, err := idx.Root()
+		require.NoError(t, err)
+		assert.Equal(t, emptyRoot, root)
+
+		// Removing a deal not in the index fails.
+		assert.Error(t, idx.Remove(party, 2))
+		require.NoError(t, idx.Put(party, 3))
+		assert.Error(t, idx.Remove(party, 4))
+	})
+}
+
 func TestMarketActor(t *testing.T) {
 	owner := tutil.NewIDAddr(t, 101)
 	provider := tutil.NewIDAddr(t, 102)
@@ -406,6 +474,25 @@ func TestPublishStorageDeals(t *testing.T) {
 		actor.activateDeals(rt, endEpoch+1, provider, newEpoch, deal2ID)
 	})
 
+	t.Run("indexes published deals by client and provider", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		client2 := tutil.NewIDAddr(t, 900)
+		provider2 := tutil.NewIDAddr(t, 109)
+		mAddr2 := &minerAddrs{owner, worker, provider2}
+
+		deal1 := actor.generateDealAndAddFunds(rt, client, mAddr, abi.ChainEpoch(42), abi.ChainEpoch(100))
+		deal2 := actor.generateDealAndAddFunds(rt, client2, mAddr, abi.ChainEpoch(42), abi.ChainEpoch(100))
+		ids := actor.publishDeals(rt, mAddr, deal1, deal2)
+		deal3 := actor.generateDealAndAddFunds(rt, client, mAddr2, abi.ChainEpoch(20), abi.ChainEpoch(50))
+		ids = append(ids, actor.publishDeals(rt, mAddr2, deal3)...)
+
+		assert.Equal(t, []abi.DealID{ids[0], ids[2]}, actor.dealsForClient(rt, client))
+		assert.Equal(t, []abi.DealID{ids[1]}, actor.dealsForClient(rt, client2))
+		assert.Equal(t, []abi.DealID{ids[0], ids[1]}, actor.dealsForProvider(rt, provider))
+		assert.Equal(t, []abi.DealID{ids[2]}, actor.dealsForProvider(rt, provider2))
+		assert.Empty(t, actor.dealsForClient(rt, provider))
+	})
+
 	t.Run("publish multiple deals for different clients and ensure balances are correct", func(t *testing.T) {
 		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
 		client1 := tutil.NewIDAddr(t, 900)
@@ -1404,6 +1491,21 @@ func TestCronTickTimedoutDeals(t *testing.T) {
 		actor.assertDealDeleted(rt, dealId, d)
 	})
 
+	t.Run("timed out deal is removed from deal indexes leaving others", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		timedOutId := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch)
+		d := actor.getDealProposal(rt, timedOutId)
+		laterId := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch+1000, endEpoch+1000)
+
+		rt.SetEpoch(startEpoch)
+		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d.ProviderCollateral, nil, exitcode.Ok)
+		actor.cronTick(rt)
+
+		actor.assertDealDeleted(rt, timedOutId, d)
+		assert.Equal(t, []abi.DealID{laterId}, actor.dealsForClient(rt, client))
+		assert.Equal(t, []abi.DealID{laterId}, actor.dealsForProvider(rt, provider))
+	})
+
 	t.Run("publishing timed out deal again should work after cron tick as it should no longer be pending", func(t *testing.T) {
 		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
 		dealId := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch)
@@ -2544,6 +2646,49 @@ func (h *marketActorTestHarness) assertDealDeleted(rt *mock.Runtime, dealId abi.
 	found, err = pending.Get(adt.CidKey(pcid), nil)
 	require.NoError(h.t, err)
 	require.False(h.t, found)
+
+	require.NotContains(h.t, h.dealsForClient(rt, p.Client), dealId)
+	require.NotContains(h.t, h.dealsForProvider(rt, p.Provider), dealId)
+}
+
+func (h *marketActorTestHarness) dealsForClient(rt *mock.Runtime, client address.Address) []abi.DealID {
+	var st market.State
+	rt.GetState(&st)
+	// List a deal at a time, exercising pagination.
+	var dealIDs []abi.DealID
+	start, more := abi.DealID(0), true
+	for more {
+		var page []abi.DealID
+		var err error
+		page, more, err = st.DealsForClient(adt.AsStore(rt), client, start, 1)
+		require.NoError(h.t, err)
+		require.True(h.t, len(page) <= 1)
+		if len(page) > 0 {
+			dealIDs = append(dealIDs, page...)
+			start = page[len(page)-1] + 1
+		}
+	}
+	return dealIDs
+}
+
+func (h *marketActorTestHarness) dealsForProvider(rt *mock.Runtime, provider address.Address) []abi.DealID {
+	var st market.State
+	rt.GetState(&st)
+	// List a deal at a time, exercising pagination.
+	var dealIDs []abi.DealID
+	start, more := abi.DealID(0), true
+	for more {
+		var page []abi.DealID
+		var err error
+		page, more, err = st.DealsForProvider(adt.AsStore(rt), provider, start, 1)
+		require.NoError(h.t, err)
+		require.True(h.t, len(page) <= 1)
+		if len(page) > 0 {
+			dealIDs = append(dealIDs, page...)
+			start = page[len(page)-1] + 1
+		}
+	}
+	return dealIDs
 }
 
 func (h *marketActorTestHarness) assertDealsTerminated(rt *mock.Runtime, epoch abi.ChainEpoch, dealIds ...abi.DealID) {
@@ -38,10 +38,15 @@ func (mm *SetMultimap) Root() (cid.Cid, error) {
 	return mm.mp.Root()
 }
 
-func (mm *SetMultimap) Put(epoch abi.ChainEpoch, v abi.DealID) error {
+// Adds a value to the set for a key.
+func (mm *SetMultimap) Put(key adt.Keyer, v abi.DealID) error {
+	return mm.PutMany(key, []abi.DealID{v})
+}
+
+// Adds values to the set for a key.
+func (mm *SetMultimap) PutMany(key adt.Keyer, vs []abi.DealID) error {
 	// Load the hamt under key, or initialize a new empty one if not found.
-	k := adt.UIntKey(uint64(epoch))
-	set, found, err := mm.get(k)
+	set, found, err := mm.get(key)
 	if err != nil {
 		return err
 	}
@@ -50,57 +55,49 @@ func (mm *SetMultimap) Put(epoch abi.ChainEpoch, v abi.DealID) error {
 	}
 
 	// Add to the set.
-	if err = set.Put(dealKey(v)); err != nil {
-		return errors.Wrapf(err, "failed to add key to set %v", epoch)
-	}
-
-	src, err := set.Root()
-	if err != nil {
-		return xerrors.Errorf("failed to flush set root: %w", err)
-	}
-	// Store the new set root under key.
-	newSetRoot := cbg.CborCid(src)
-	err = mm.mp.Put(k, &newSetRoot)
-	if err != nil {
-		return errors.Wrapf(err, "failed to store set")
+	for _, v := range vs {
+		if err = set.Put(dealKey(v)); err != nil {
+			return errors.Wrapf(err, "failed to add value %d to set %v", v, key)
+		}
 	}
-	return nil
+	return mm.putSet(key, set)
 }
 
-func (mm *SetMultimap) PutMany(epoch abi.ChainEpoch, vs []abi.DealID) error {
-	// Load the hamt under key, or initialize a new empty one if not found.
-	k := adt.UIntKey(uint64(epoch))
-	set, found, err := mm.get(k)
+// Removes a value from the set for a key, removing the set if it becomes empty.
+// It is an error to remove a value which is not in the set.
+func (mm *SetMultimap) Remove(key adt.Keyer, v abi.DealID) error {
+	set, found, err := mm.get(key)
 	if err != nil {
 		return err
 	}
 	if !found {
-		set = adt.MakeEmptySet(mm.store)
+		return xerrors.Errorf("no set for key %v", key)
 	}
 
-	// Add to the set.
-	for _, v := range vs {
-		if err = set.Put(dealKey(v)); err != nil {
-			return errors.Wrapf(err, "failed to add key to set %v", epoch)
-		}
+	if err = set.Delete(dealKey(v)); err != nil {
+		return xerrors.Errorf("failed to remove value %d from set %v: %w", v, key, err)
 	}
 
-	src, err := set.Root()
-	if err != nil {
-		return xerrors.Errorf("failed to flush set root: %w", err)
+	empty := true
+	err = set.ForEach(func(string) error {
+		empty = false
+		return errStopIteration
+	})
+	if err != nil && err != errStopIteration {
+		return xerrors.Errorf("failed to iterate set %v: %w", key, err)
 	}
-	// Store the new set root under key.
-	newSetRoot := cbg.CborCid(src)
-	err = mm.mp.Put(k, &newSetRoot)
-	if err != nil {
-		return errors.Wrapf(err, "failed to store set")
+	if empty {
+		if err = mm.mp.Delete(key); err != nil {
+			return xerrors.Errorf("failed to delete set %v: %w", key, err)
+		}
+		return nil
 	}
-	return nil
+	return mm.putSet(key, set)
 }
 
 // Removes all values for a key.
-func (mm *SetMultimap) RemoveAll(key abi.ChainEpoch) error {
-	err := mm.mp.Delete(adt.UIntKey(uint64(key)))
+func (mm *SetMultimap) RemoveAll(key adt.Keyer) error {
+	err := mm.mp.Delete(key)
 	if err != nil && !xerrors.Is(err, hamt.ErrNotFound) {
 		return xerrors.Errorf("failed to delete set key %v: %w", key, err)
 	}
@@ -108,8 +105,8 @@ func (mm *SetMultimap) RemoveAll(key abi.ChainEpoch) error {
 }
 
 // Iterates all entries for a key, iteration halts if the function returns an error.
-func (mm *SetMultimap) ForEach(epoch abi.ChainEpoch, fn func(id abi.DealID) error) error {
-	set, found, err := mm.get(adt.UIntKey(uint64(epoch)))
+func (mm *SetMultimap) ForEach(key adt.Keyer, fn func(id abi.DealID) error) error {
+	set, found, err := mm.get(key)
 	if err != nil {
 		return err
 	}
@@ -125,6 +122,19 @@ func (mm *SetMultimap) ForEach(epoch abi.ChainEpoch, fn func(id abi.DealID) erro
 	return nil
 }
 
+func (mm *SetMultimap) putSet(key adt.Keyer, set *adt.Set) error {
+	src, err := set.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush set root: %w", err)
+	}
+	// Store the new set root under key.
+	newSetRoot := cbg.CborCid(src)
+	if err = mm.mp.Put(key, &newSetRoot); err != nil {
+		return errors.Wrapf(err, "failed to store set")
+	}
+	return nil
+}
+
 func (mm *SetMultimap) get(key adt.Keyer) (*adt.Set, bool, error) {
 	var setRoot cbg.CborCid
 	found, err := mm.mp.Get(key, &setRoot)
@@ -141,6 +151,13 @@ func (mm *SetMultimap) get(key adt.Keyer) (*adt.Set, bool, error) {
 	return set, found, nil
 }
 
+// Sentinel error used to halt set iteration, never returned to callers.
+var errStopIteration = errors.New("stop iteration")
+
+func epochKey(e abi.ChainEpoch) adt.Keyer {
+	return adt.UIntKey(uint64(e))
+}
+
 func dealKey(e abi.DealID) adt.Keyer {
 	return adt.UIntKey(uint64(e))
 }
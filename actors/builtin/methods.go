@@ -33,7 +33,8 @@ var MethodsReward = struct {
 	AwardBlockReward abi.MethodNum
 	ThisEpochReward  abi.MethodNum
 	UpdateNetworkKPI abi.MethodNum
-}{MethodConstructor, 2, 3, 4}
+	MintingStats     abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5}
 
 var MethodsMultisig = struct {
 	Constructor                 abi.MethodNum
@@ -75,7 +76,9 @@ var MethodsPower = struct {
 	OnConsensusFault         abi.MethodNum
 	SubmitPoRepForBulkVerify abi.MethodNum
 	CurrentTotalPower        abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+	DeleteMiner              abi.MethodNum
+	MinerPower               abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
 
 var MethodsMiner = struct {
 	Constructor              abi.MethodNum
@@ -97,7 +100,16 @@ var MethodsMiner = struct {
 	ConfirmSectorProofsValid abi.MethodNum
 	ChangeMultiaddrs         abi.MethodNum
 	CompactPartitions        abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+	DisputeWindowedPoSt      abi.MethodNum
+	ProveReplicaUpdates      abi.MethodNum
+	MaskSectorNumbers        abi.MethodNum
+	MovePartitions           abi.MethodNum
+	CompactSectorNumbers     abi.MethodNum
+	ShutdownMiner            abi.MethodNum
+	ChangeBeneficiary        abi.MethodNum
+	GetBeneficiary           abi.MethodNum
+	ApplyRewards             abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28}
 
 var MethodsVerifiedRegistry = struct {
 	Constructor       abi.MethodNum
@@ -15,7 +15,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{140}
+var lengthBufState = []byte{144}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -61,6 +61,18 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return xerrors.Errorf("failed to write cid field t.PreCommittedSectors: %w", err)
 	}
 
+	// t.PreCommittedSectorsExpiry (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.PreCommittedSectorsExpiry); err != nil {
+		return xerrors.Errorf("failed to write cid field t.PreCommittedSectorsExpiry: %w", err)
+	}
+
+	// t.AllocatedSectors (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.AllocatedSectors); err != nil {
+		return xerrors.Errorf("failed to write cid field t.AllocatedSectors: %w", err)
+	}
+
 	// t.Sectors (cid.Cid) (struct)
 
 	if err := cbg.WriteCidBuf(scratch, w, t.Sectors); err != nil {
@@ -95,10 +107,26 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.ShutdownEpoch (abi.ChainEpoch) (int64)
+	if t.ShutdownEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ShutdownEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ShutdownEpoch-1)); err != nil {
+			return err
+		}
+	}
+
 	// t.FaultyPower (miner.PowerPair) (struct)
 	if err := t.FaultyPower.MarshalCBOR(w); err != nil {
 		return err
 	}
+
+	// t.FeeDebt (big.Int) (struct)
+	if err := t.FeeDebt.MarshalCBOR(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -116,7 +144,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 12 {
+	if extra != 16 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -182,6 +210,30 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.PreCommittedSectors = c
 
+	}
+	// t.PreCommittedSectorsExpiry (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.PreCommittedSectorsExpiry: %w", err)
+		}
+
+		t.PreCommittedSectorsExpiry = c
+
+	}
+	// t.AllocatedSectors (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.AllocatedSectors: %w", err)
+		}
+
+		t.AllocatedSectors = c
+
 	}
 	// t.Sectors (cid.Cid) (struct)
 
@@ -267,6 +319,31 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		}
 
 	}
+	// t.ShutdownEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ShutdownEpoch = abi.ChainEpoch(extraI)
+	}
 	// t.FaultyPower (miner.PowerPair) (struct)
 
 	{
@@ -275,11 +352,20 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.FaultyPower: %w", err)
 		}
 
+	}
+	// t.FeeDebt (big.Int) (struct)
+
+	{
+
+		if err := t.FeeDebt.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.FeeDebt: %w", err)
+		}
+
 	}
 	return nil
 }
 
-var lengthBufMinerInfo = []byte{136}
+var lengthBufMinerInfo = []byte{140}
 
 func (t *MinerInfo) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -307,6 +393,21 @@ func (t *MinerInfo) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.Beneficiary (address.Address) (struct)
+	if err := t.Beneficiary.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.BeneficiaryTerm (miner.BeneficiaryTerm) (struct)
+	if err := t.BeneficiaryTerm.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.PendingBeneficiaryTerm (miner.PendingBeneficiaryChange) (struct)
+	if err := t.PendingBeneficiaryTerm.MarshalCBOR(w); err != nil {
+		return err
+	}
+
 	// t.PeerId ([]uint8) (slice)
 	if len(t.PeerId) > cbg.ByteArrayMaxLen {
 		return xerrors.Errorf("Byte array in field t.PeerId was too long")
@@ -365,6 +466,16 @@ func (t *MinerInfo) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.ConsensusFaultElapsed (abi.ChainEpoch) (int64)
+	if t.ConsensusFaultElapsed >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ConsensusFaultElapsed)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ConsensusFaultElapsed-1)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -382,7 +493,7 @@ func (t *MinerInfo) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 8 {
+	if extra != 12 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -424,6 +535,45 @@ func (t *MinerInfo) UnmarshalCBOR(r io.Reader) error {
 			}
 		}
 
+	}
+	// t.Beneficiary (address.Address) (struct)
+
+	{
+
+		if err := t.Beneficiary.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Beneficiary: %w", err)
+		}
+
+	}
+	// t.BeneficiaryTerm (miner.BeneficiaryTerm) (struct)
+
+	{
+
+		if err := t.BeneficiaryTerm.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.BeneficiaryTerm: %w", err)
+		}
+
+	}
+	// t.PendingBeneficiaryTerm (miner.PendingBeneficiaryChange) (struct)
+
+	{
+
+		pb, err := br.PeekByte()
+		if err != nil {
+			return err
+		}
+		if pb == cbg.CborNull[0] {
+			var nbuf [1]byte
+			if _, err := br.Read(nbuf[:]); err != nil {
+				return err
+			}
+		} else {
+			t.PendingBeneficiaryTerm = new(PendingBeneficiaryChange)
+			if err := t.PendingBeneficiaryTerm.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.PendingBeneficiaryTerm pointer: %w", err)
+			}
+		}
+
 	}
 	// t.PeerId ([]uint8) (slice)
 
@@ -538,6 +688,31 @@ func (t *MinerInfo) UnmarshalCBOR(r io.Reader) error {
 		t.WindowPoStPartitionSectors = uint64(extra)
 
 	}
+	// t.ConsensusFaultElapsed (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ConsensusFaultElapsed = abi.ChainEpoch(extraI)
+	}
 	return nil
 }
 
@@ -621,7 +796,7 @@ func (t *Deadlines) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufDeadline = []byte{134}
+var lengthBufDeadline = []byte{136}
 
 func (t *Deadline) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -668,6 +843,18 @@ func (t *Deadline) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.OptimisticPoStSubmissions (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.OptimisticPoStSubmissions); err != nil {
+		return xerrors.Errorf("failed to write cid field t.OptimisticPoStSubmissions: %w", err)
+	}
+
+	// t.OptimisticPoStSubmissionsSnapshot (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.OptimisticPoStSubmissionsSnapshot); err != nil {
+		return xerrors.Errorf("failed to write cid field t.OptimisticPoStSubmissionsSnapshot: %w", err)
+	}
+
 	return nil
 }
 
@@ -685,7 +872,7 @@ func (t *Deadline) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 6 {
+	if extra != 8 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -782,6 +969,30 @@ func (t *Deadline) UnmarshalCBOR(r io.Reader) error {
 		}
 		t.TotalSectors = uint64(extra)
 
+	}
+	// t.OptimisticPoStSubmissions (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.OptimisticPoStSubmissions: %w", err)
+		}
+
+		t.OptimisticPoStSubmissions = c
+
+	}
+	// t.OptimisticPoStSubmissionsSnapshot (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.OptimisticPoStSubmissionsSnapshot: %w", err)
+		}
+
+		t.OptimisticPoStSubmissionsSnapshot = c
+
 	}
 	return nil
 }
@@ -1634,7 +1845,7 @@ func (t *SectorPreCommitInfo) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufSectorOnChainInfo = []byte{137}
+var lengthBufSectorOnChainInfo = []byte{139}
 
 func (t *SectorOnChainInfo) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -1653,6 +1864,12 @@ func (t *SectorOnChainInfo) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.SealedSectorNumber (abi.SectorNumber) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SealedSectorNumber)); err != nil {
+		return err
+	}
+
 	// t.SealProof (abi.RegisteredSealProof) (int64)
 	if t.SealProof >= 0 {
 		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SealProof)); err != nil {
@@ -1695,6 +1912,17 @@ func (t *SectorOnChainInfo) MarshalCBOR(w io.Writer) error {
 		}
 	}
 
+	// t.ReplicaUpdateEpoch (abi.ChainEpoch) (int64)
+	if t.ReplicaUpdateEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ReplicaUpdateEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ReplicaUpdateEpoch-1)); err != nil {
+			return err
+		}
+	}
+
 	// t.Expiration (abi.ChainEpoch) (int64)
 	if t.Expiration >= 0 {
 		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Expiration)); err != nil {
@@ -1737,7 +1965,7 @@ func (t *SectorOnChainInfo) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 9 {
+	if extra != 11 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -1754,6 +1982,20 @@ func (t *SectorOnChainInfo) UnmarshalCBOR(r io.Reader) error {
 		}
 		t.SectorNumber = abi.SectorNumber(extra)
 
+	}
+	// t.SealedSectorNumber (abi.SectorNumber) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.SealedSectorNumber = abi.SectorNumber(extra)
+
 	}
 	// t.SealProof (abi.RegisteredSealProof) (int64)
 	{
@@ -1850,6 +2092,31 @@ func (t *SectorOnChainInfo) UnmarshalCBOR(r io.Reader) error {
 
 		t.Activation = abi.ChainEpoch(extraI)
 	}
+	// t.ReplicaUpdateEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ReplicaUpdateEpoch = abi.ChainEpoch(extraI)
+	}
 	// t.Expiration (abi.ChainEpoch) (int64)
 	{
 		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
@@ -1991,57 +2258,44 @@ func (t *WorkerKeyChange) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufSubmitWindowedPoStParams = []byte{131}
+var lengthBufBeneficiaryTerm = []byte{131}
 
-func (t *SubmitWindowedPoStParams) MarshalCBOR(w io.Writer) error {
+func (t *BeneficiaryTerm) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufSubmitWindowedPoStParams); err != nil {
+	if _, err := w.Write(lengthBufBeneficiaryTerm); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.Deadline (uint64) (uint64)
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
+	// t.Quota (big.Int) (struct)
+	if err := t.Quota.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.Partitions ([]miner.PoStPartition) (slice)
-	if len(t.Partitions) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Partitions was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Partitions))); err != nil {
+	// t.UsedQuota (big.Int) (struct)
+	if err := t.UsedQuota.MarshalCBOR(w); err != nil {
 		return err
 	}
-	for _, v := range t.Partitions {
-		if err := v.MarshalCBOR(w); err != nil {
+
+	// t.Expiration (abi.ChainEpoch) (int64)
+	if t.Expiration >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Expiration)); err != nil {
 			return err
 		}
-	}
-
-	// t.Proofs ([]abi.PoStProof) (slice)
-	if len(t.Proofs) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Proofs was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Proofs))); err != nil {
-		return err
-	}
-	for _, v := range t.Proofs {
-		if err := v.MarshalCBOR(w); err != nil {
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Expiration-1)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (t *SubmitWindowedPoStParams) UnmarshalCBOR(r io.Reader) error {
-	*t = SubmitWindowedPoStParams{}
+func (t *BeneficiaryTerm) UnmarshalCBOR(r io.Reader) error {
+	*t = BeneficiaryTerm{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2058,50 +2312,1300 @@ func (t *SubmitWindowedPoStParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Deadline (uint64) (uint64)
+	// t.Quota (big.Int) (struct)
 
 	{
 
-		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-		if err != nil {
-			return err
+		if err := t.Quota.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Quota: %w", err)
 		}
-		if maj != cbg.MajUnsignedInt {
-			return fmt.Errorf("wrong type for uint64 field")
-		}
-		t.Deadline = uint64(extra)
 
 	}
-	// t.Partitions ([]miner.PoStPartition) (slice)
+	// t.UsedQuota (big.Int) (struct)
 
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+	{
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Partitions: array too large (%d)", extra)
+		if err := t.UsedQuota.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.UsedQuota: %w", err)
+		}
+
+	}
+	// t.Expiration (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Expiration = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufPendingBeneficiaryChange = []byte{133}
+
+func (t *PendingBeneficiaryChange) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufPendingBeneficiaryChange); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.NewBeneficiary (address.Address) (struct)
+	if err := t.NewBeneficiary.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.NewQuota (big.Int) (struct)
+	if err := t.NewQuota.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.NewExpiration (abi.ChainEpoch) (int64)
+	if t.NewExpiration >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.NewExpiration)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.NewExpiration-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.ApprovedByBeneficiary (bool) (bool)
+	if err := cbg.WriteBool(w, t.ApprovedByBeneficiary); err != nil {
+		return err
+	}
+
+	// t.ApprovedByNominee (bool) (bool)
+	if err := cbg.WriteBool(w, t.ApprovedByNominee); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *PendingBeneficiaryChange) UnmarshalCBOR(r io.Reader) error {
+	*t = PendingBeneficiaryChange{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 5 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewBeneficiary (address.Address) (struct)
+
+	{
+
+		if err := t.NewBeneficiary.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewBeneficiary: %w", err)
+		}
+
+	}
+	// t.NewQuota (big.Int) (struct)
+
+	{
+
+		if err := t.NewQuota.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewQuota: %w", err)
+		}
+
+	}
+	// t.NewExpiration (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.NewExpiration = abi.ChainEpoch(extraI)
+	}
+	// t.ApprovedByBeneficiary (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.ApprovedByBeneficiary = false
+	case 21:
+		t.ApprovedByBeneficiary = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	// t.ApprovedByNominee (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.ApprovedByNominee = false
+	case 21:
+		t.ApprovedByNominee = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	return nil
+}
+
+var lengthBufWindowedPoSt = []byte{131}
+
+func (t *WindowedPoSt) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufWindowedPoSt); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Partitions (bitfield.BitField) (struct)
+	if err := t.Partitions.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.SectorInfos ([]abi.SectorInfo) (slice)
+	if len(t.SectorInfos) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.SectorInfos was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.SectorInfos))); err != nil {
+		return err
+	}
+	for _, v := range t.SectorInfos {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Proofs ([]abi.PoStProof) (slice)
+	if len(t.Proofs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Proofs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Proofs))); err != nil {
+		return err
+	}
+	for _, v := range t.Proofs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *WindowedPoSt) UnmarshalCBOR(r io.Reader) error {
+	*t = WindowedPoSt{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Partitions (bitfield.BitField) (struct)
+
+	{
+
+		pb, err := br.PeekByte()
+		if err != nil {
+			return err
+		}
+		if pb == cbg.CborNull[0] {
+			var nbuf [1]byte
+			if _, err := br.Read(nbuf[:]); err != nil {
+				return err
+			}
+		} else {
+			t.Partitions = new(bitfield.BitField)
+			if err := t.Partitions.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Partitions pointer: %w", err)
+			}
+		}
+
+	}
+	// t.SectorInfos ([]abi.SectorInfo) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.SectorInfos: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.SectorInfos = make([]abi.SectorInfo, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v abi.SectorInfo
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.SectorInfos[i] = v
+	}
+
+	// t.Proofs ([]abi.PoStProof) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Proofs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Proofs = make([]abi.PoStProof, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v abi.PoStProof
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Proofs[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufSubmitWindowedPoStParams = []byte{131}
+
+func (t *SubmitWindowedPoStParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSubmitWindowedPoStParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Deadline (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
+		return err
+	}
+
+	// t.Partitions ([]miner.PoStPartition) (slice)
+	if len(t.Partitions) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Partitions was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Partitions))); err != nil {
+		return err
+	}
+	for _, v := range t.Partitions {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Proofs ([]abi.PoStProof) (slice)
+	if len(t.Proofs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Proofs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Proofs))); err != nil {
+		return err
+	}
+	for _, v := range t.Proofs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *SubmitWindowedPoStParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SubmitWindowedPoStParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Deadline (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Deadline = uint64(extra)
+
+	}
+	// t.Partitions ([]miner.PoStPartition) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Partitions: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Partitions = make([]PoStPartition, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v PoStPartition
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Partitions[i] = v
+	}
+
+	// t.Proofs ([]abi.PoStProof) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Proofs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Proofs = make([]abi.PoStProof, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v abi.PoStProof
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Proofs[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufTerminateSectorsParams = []byte{129}
+
+func (t *TerminateSectorsParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufTerminateSectorsParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Terminations ([]miner.TerminationDeclaration) (slice)
+	if len(t.Terminations) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Terminations was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Terminations))); err != nil {
+		return err
+	}
+	for _, v := range t.Terminations {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TerminateSectorsParams) UnmarshalCBOR(r io.Reader) error {
+	*t = TerminateSectorsParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Terminations ([]miner.TerminationDeclaration) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Terminations: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Terminations = make([]TerminationDeclaration, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v TerminationDeclaration
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Terminations[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufTerminateSectorsReturn = []byte{131}
+
+func (t *TerminateSectorsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufTerminateSectorsReturn); err != nil {
+		return err
+	}
+
+	// t.Done (bool) (bool)
+	if err := cbg.WriteBool(w, t.Done); err != nil {
+		return err
+	}
+
+	// t.Report (miner.TerminationReport) (struct)
+	if err := t.Report.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.PowerRemoved (miner.PowerPair) (struct)
+	if err := t.PowerRemoved.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TerminateSectorsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = TerminateSectorsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Done (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.Done = false
+	case 21:
+		t.Done = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	// t.Report (miner.TerminationReport) (struct)
+
+	{
+
+		if err := t.Report.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Report: %w", err)
+		}
+
+	}
+	// t.PowerRemoved (miner.PowerPair) (struct)
+
+	{
+
+		if err := t.PowerRemoved.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.PowerRemoved: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufTerminationReport = []byte{132}
+
+func (t *TerminationReport) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufTerminationReport); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Sectors ([]miner.SectorTerminationReport) (slice)
+	if len(t.Sectors) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Sectors was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Sectors))); err != nil {
+		return err
+	}
+	for _, v := range t.Sectors {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.PenaltyCharged (big.Int) (struct)
+	if err := t.PenaltyCharged.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.PledgeReleased (big.Int) (struct)
+	if err := t.PledgeReleased.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Power (miner.PowerPair) (struct)
+	if err := t.Power.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TerminationReport) UnmarshalCBOR(r io.Reader) error {
+	*t = TerminationReport{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Sectors ([]miner.SectorTerminationReport) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Sectors: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Sectors = make([]SectorTerminationReport, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v SectorTerminationReport
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Sectors[i] = v
+	}
+
+	// t.PenaltyCharged (big.Int) (struct)
+
+	{
+
+		if err := t.PenaltyCharged.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.PenaltyCharged: %w", err)
+		}
+
+	}
+	// t.PledgeReleased (big.Int) (struct)
+
+	{
+
+		if err := t.PledgeReleased.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.PledgeReleased: %w", err)
+		}
+
+	}
+	// t.Power (miner.PowerPair) (struct)
+
+	{
+
+		if err := t.Power.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Power: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufSectorTerminationReport = []byte{134}
+
+func (t *SectorTerminationReport) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSectorTerminationReport); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.SectorNumber (abi.SectorNumber) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SectorNumber)); err != nil {
+		return err
+	}
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Penalty (big.Int) (struct)
+	if err := t.Penalty.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Pledge (big.Int) (struct)
+	if err := t.Pledge.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Power (miner.PowerPair) (struct)
+	if err := t.Power.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Deals ([]abi.DealID) (slice)
+	if len(t.Deals) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Deals was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Deals))); err != nil {
+		return err
+	}
+	for _, v := range t.Deals {
+		if err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *SectorTerminationReport) UnmarshalCBOR(r io.Reader) error {
+	*t = SectorTerminationReport{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 6 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.SectorNumber (abi.SectorNumber) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.SectorNumber = abi.SectorNumber(extra)
+
+	}
+	// t.Epoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	// t.Penalty (big.Int) (struct)
+
+	{
+
+		if err := t.Penalty.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Penalty: %w", err)
+		}
+
+	}
+	// t.Pledge (big.Int) (struct)
+
+	{
+
+		if err := t.Pledge.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Pledge: %w", err)
+		}
+
+	}
+	// t.Power (miner.PowerPair) (struct)
+
+	{
+
+		if err := t.Power.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Power: %w", err)
+		}
+
+	}
+	// t.Deals ([]abi.DealID) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Deals: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Deals = make([]abi.DealID, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		maj, val, err := cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return xerrors.Errorf("failed to read uint64 for t.Deals slice: %w", err)
+		}
+
+		if maj != cbg.MajUnsignedInt {
+			return xerrors.Errorf("value read for array t.Deals was not a uint, instead got %d", maj)
+		}
+
+		t.Deals[i] = abi.DealID(val)
+	}
+
+	return nil
+}
+
+var lengthBufChangePeerIDParams = []byte{129}
+
+func (t *ChangePeerIDParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangePeerIDParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.NewID ([]uint8) (slice)
+	if len(t.NewID) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.NewID was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.NewID))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.NewID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ChangePeerIDParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangePeerIDParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewID ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.NewID: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+	t.NewID = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.NewID); err != nil {
+		return err
+	}
+	return nil
+}
+
+var lengthBufChangeMultiaddrsParams = []byte{129}
+
+func (t *ChangeMultiaddrsParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangeMultiaddrsParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.NewMultiaddrs ([][]uint8) (slice)
+	if len(t.NewMultiaddrs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.NewMultiaddrs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.NewMultiaddrs))); err != nil {
+		return err
+	}
+	for _, v := range t.NewMultiaddrs {
+		if len(v) > cbg.ByteArrayMaxLen {
+			return xerrors.Errorf("Byte array in field v was too long")
+		}
+
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(v))); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ChangeMultiaddrsParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeMultiaddrsParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewMultiaddrs ([][]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.NewMultiaddrs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.NewMultiaddrs = make([][]uint8, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+		{
+			var maj byte
+			var extra uint64
+			var err error
+
+			maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+			if err != nil {
+				return err
+			}
+
+			if extra > cbg.ByteArrayMaxLen {
+				return fmt.Errorf("t.NewMultiaddrs[i]: byte array too large (%d)", extra)
+			}
+			if maj != cbg.MajByteString {
+				return fmt.Errorf("expected byte array")
+			}
+			t.NewMultiaddrs[i] = make([]byte, extra)
+			if _, err := io.ReadFull(br, t.NewMultiaddrs[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+var lengthBufProveCommitSectorParams = []byte{130}
+
+func (t *ProveCommitSectorParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufProveCommitSectorParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.SectorNumber (abi.SectorNumber) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SectorNumber)); err != nil {
+		return err
+	}
+
+	// t.Proof ([]uint8) (slice)
+	if len(t.Proof) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.Proof was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Proof))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.Proof); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ProveCommitSectorParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ProveCommitSectorParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.SectorNumber (abi.SectorNumber) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.SectorNumber = abi.SectorNumber(extra)
+
+	}
+	// t.Proof ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.Proof: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+	t.Proof = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.Proof); err != nil {
+		return err
+	}
+	return nil
+}
+
+var lengthBufChangeWorkerAddressParams = []byte{129}
+
+func (t *ChangeWorkerAddressParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangeWorkerAddressParams); err != nil {
+		return err
+	}
+
+	// t.NewWorker (address.Address) (struct)
+	if err := t.NewWorker.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ChangeWorkerAddressParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeWorkerAddressParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
+	// t.NewWorker (address.Address) (struct)
+
+	{
+
+		if err := t.NewWorker.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewWorker: %w", err)
+		}
+
 	}
+	return nil
+}
 
-	if extra > 0 {
-		t.Partitions = make([]PoStPartition, extra)
+var lengthBufExtendSectorExpirationParams = []byte{129}
+
+func (t *ExtendSectorExpirationParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufExtendSectorExpirationParams); err != nil {
+		return err
 	}
 
-	for i := 0; i < int(extra); i++ {
+	scratch := make([]byte, 9)
 
-		var v PoStPartition
-		if err := v.UnmarshalCBOR(br); err != nil {
+	// t.Extensions ([]miner.ExpirationExtension) (slice)
+	if len(t.Extensions) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Extensions was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Extensions))); err != nil {
+		return err
+	}
+	for _, v := range t.Extensions {
+		if err := v.MarshalCBOR(w); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		t.Partitions[i] = v
+func (t *ExtendSectorExpirationParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ExtendSectorExpirationParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	// t.Proofs ([]abi.PoStProof) (slice)
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Extensions ([]miner.ExpirationExtension) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2109,7 +3613,7 @@ func (t *SubmitWindowedPoStParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Proofs: array too large (%d)", extra)
+		return fmt.Errorf("t.Extensions: array too large (%d)", extra)
 	}
 
 	if maj != cbg.MajArray {
@@ -2117,44 +3621,44 @@ func (t *SubmitWindowedPoStParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > 0 {
-		t.Proofs = make([]abi.PoStProof, extra)
+		t.Extensions = make([]ExpirationExtension, extra)
 	}
 
 	for i := 0; i < int(extra); i++ {
 
-		var v abi.PoStProof
+		var v ExpirationExtension
 		if err := v.UnmarshalCBOR(br); err != nil {
 			return err
 		}
 
-		t.Proofs[i] = v
+		t.Extensions[i] = v
 	}
 
 	return nil
 }
 
-var lengthBufTerminateSectorsParams = []byte{129}
+var lengthBufDeclareFaultsParams = []byte{129}
 
-func (t *TerminateSectorsParams) MarshalCBOR(w io.Writer) error {
+func (t *DeclareFaultsParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufTerminateSectorsParams); err != nil {
+	if _, err := w.Write(lengthBufDeclareFaultsParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.Terminations ([]miner.TerminationDeclaration) (slice)
-	if len(t.Terminations) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Terminations was too long")
+	// t.Faults ([]miner.FaultDeclaration) (slice)
+	if len(t.Faults) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Faults was too long")
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Terminations))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Faults))); err != nil {
 		return err
 	}
-	for _, v := range t.Terminations {
+	for _, v := range t.Faults {
 		if err := v.MarshalCBOR(w); err != nil {
 			return err
 		}
@@ -2162,8 +3666,8 @@ func (t *TerminateSectorsParams) MarshalCBOR(w io.Writer) error {
 	return nil
 }
 
-func (t *TerminateSectorsParams) UnmarshalCBOR(r io.Reader) error {
-	*t = TerminateSectorsParams{}
+func (t *DeclareFaultsParams) UnmarshalCBOR(r io.Reader) error {
+	*t = DeclareFaultsParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2180,7 +3684,7 @@ func (t *TerminateSectorsParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Terminations ([]miner.TerminationDeclaration) (slice)
+	// t.Faults ([]miner.FaultDeclaration) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2188,7 +3692,7 @@ func (t *TerminateSectorsParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Terminations: array too large (%d)", extra)
+		return fmt.Errorf("t.Faults: array too large (%d)", extra)
 	}
 
 	if maj != cbg.MajArray {
@@ -2196,42 +3700,53 @@ func (t *TerminateSectorsParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > 0 {
-		t.Terminations = make([]TerminationDeclaration, extra)
+		t.Faults = make([]FaultDeclaration, extra)
 	}
 
 	for i := 0; i < int(extra); i++ {
 
-		var v TerminationDeclaration
+		var v FaultDeclaration
 		if err := v.UnmarshalCBOR(br); err != nil {
 			return err
 		}
 
-		t.Terminations[i] = v
+		t.Faults[i] = v
 	}
 
 	return nil
 }
 
-var lengthBufTerminateSectorsReturn = []byte{129}
+var lengthBufDeclareFaultsRecoveredParams = []byte{129}
 
-func (t *TerminateSectorsReturn) MarshalCBOR(w io.Writer) error {
+func (t *DeclareFaultsRecoveredParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufTerminateSectorsReturn); err != nil {
+	if _, err := w.Write(lengthBufDeclareFaultsRecoveredParams); err != nil {
 		return err
 	}
 
-	// t.Done (bool) (bool)
-	if err := cbg.WriteBool(w, t.Done); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.Recoveries ([]miner.RecoveryDeclaration) (slice)
+	if len(t.Recoveries) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Recoveries was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Recoveries))); err != nil {
 		return err
 	}
+	for _, v := range t.Recoveries {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (t *TerminateSectorsReturn) UnmarshalCBOR(r io.Reader) error {
-	*t = TerminateSectorsReturn{}
+func (t *DeclareFaultsRecoveredParams) UnmarshalCBOR(r io.Reader) error {
+	*t = DeclareFaultsRecoveredParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2248,56 +3763,94 @@ func (t *TerminateSectorsReturn) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Done (bool) (bool)
+	// t.Recoveries ([]miner.RecoveryDeclaration) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
 		return err
 	}
-	if maj != cbg.MajOther {
-		return fmt.Errorf("booleans must be major type 7")
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Recoveries: array too large (%d)", extra)
 	}
-	switch extra {
-	case 20:
-		t.Done = false
-	case 21:
-		t.Done = true
-	default:
-		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Recoveries = make([]RecoveryDeclaration, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v RecoveryDeclaration
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Recoveries[i] = v
 	}
+
 	return nil
 }
 
-var lengthBufChangePeerIDParams = []byte{129}
+var lengthBufReportConsensusFaultParams = []byte{131}
 
-func (t *ChangePeerIDParams) MarshalCBOR(w io.Writer) error {
+func (t *ReportConsensusFaultParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufChangePeerIDParams); err != nil {
+	if _, err := w.Write(lengthBufReportConsensusFaultParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.NewID ([]uint8) (slice)
-	if len(t.NewID) > cbg.ByteArrayMaxLen {
-		return xerrors.Errorf("Byte array in field t.NewID was too long")
+	// t.BlockHeader1 ([]uint8) (slice)
+	if len(t.BlockHeader1) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.BlockHeader1 was too long")
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.NewID))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.BlockHeader1))); err != nil {
 		return err
 	}
 
-	if _, err := w.Write(t.NewID); err != nil {
+	if _, err := w.Write(t.BlockHeader1); err != nil {
+		return err
+	}
+
+	// t.BlockHeader2 ([]uint8) (slice)
+	if len(t.BlockHeader2) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.BlockHeader2 was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.BlockHeader2))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.BlockHeader2); err != nil {
+		return err
+	}
+
+	// t.BlockHeaderExtra ([]uint8) (slice)
+	if len(t.BlockHeaderExtra) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.BlockHeaderExtra was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.BlockHeaderExtra))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.BlockHeaderExtra); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *ChangePeerIDParams) UnmarshalCBOR(r io.Reader) error {
-	*t = ChangePeerIDParams{}
+func (t *ReportConsensusFaultParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ReportConsensusFaultParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2310,11 +3863,11 @@ func (t *ChangePeerIDParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.NewID ([]uint8) (slice)
+	// t.BlockHeader1 ([]uint8) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2322,57 +3875,77 @@ func (t *ChangePeerIDParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > cbg.ByteArrayMaxLen {
-		return fmt.Errorf("t.NewID: byte array too large (%d)", extra)
+		return fmt.Errorf("t.BlockHeader1: byte array too large (%d)", extra)
 	}
 	if maj != cbg.MajByteString {
 		return fmt.Errorf("expected byte array")
 	}
-	t.NewID = make([]byte, extra)
-	if _, err := io.ReadFull(br, t.NewID); err != nil {
+	t.BlockHeader1 = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.BlockHeader1); err != nil {
 		return err
 	}
-	return nil
-}
+	// t.BlockHeader2 ([]uint8) (slice)
 
-var lengthBufChangeMultiaddrsParams = []byte{129}
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
 
-func (t *ChangeMultiaddrsParams) MarshalCBOR(w io.Writer) error {
-	if t == nil {
-		_, err := w.Write(cbg.CborNull)
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.BlockHeader2: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+	t.BlockHeader2 = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.BlockHeader2); err != nil {
 		return err
 	}
-	if _, err := w.Write(lengthBufChangeMultiaddrsParams); err != nil {
+	// t.BlockHeaderExtra ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
 		return err
 	}
 
-	scratch := make([]byte, 9)
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.BlockHeaderExtra: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+	t.BlockHeaderExtra = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.BlockHeaderExtra); err != nil {
+		return err
+	}
+	return nil
+}
 
-	// t.NewMultiaddrs ([][]uint8) (slice)
-	if len(t.NewMultiaddrs) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.NewMultiaddrs was too long")
+var lengthBufGetControlAddressesReturn = []byte{130}
+
+func (t *GetControlAddressesReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetControlAddressesReturn); err != nil {
+		return err
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.NewMultiaddrs))); err != nil {
+	// t.Owner (address.Address) (struct)
+	if err := t.Owner.MarshalCBOR(w); err != nil {
 		return err
 	}
-	for _, v := range t.NewMultiaddrs {
-		if len(v) > cbg.ByteArrayMaxLen {
-			return xerrors.Errorf("Byte array in field v was too long")
-		}
-
-		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(v))); err != nil {
-			return err
-		}
 
-		if _, err := w.Write(v); err != nil {
-			return err
-		}
+	// t.Worker (address.Address) (struct)
+	if err := t.Worker.MarshalCBOR(w); err != nil {
+		return err
 	}
 	return nil
 }
 
-func (t *ChangeMultiaddrsParams) UnmarshalCBOR(r io.Reader) error {
-	*t = ChangeMultiaddrsParams{}
+func (t *GetControlAddressesReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetControlAddressesReturn{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2385,64 +3958,39 @@ func (t *ChangeMultiaddrsParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 2 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.NewMultiaddrs ([][]uint8) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+	// t.Owner (address.Address) (struct)
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.NewMultiaddrs: array too large (%d)", extra)
-	}
+	{
 
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
-	}
+		if err := t.Owner.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Owner: %w", err)
+		}
 
-	if extra > 0 {
-		t.NewMultiaddrs = make([][]uint8, extra)
 	}
+	// t.Worker (address.Address) (struct)
 
-	for i := 0; i < int(extra); i++ {
-		{
-			var maj byte
-			var extra uint64
-			var err error
-
-			maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-			if err != nil {
-				return err
-			}
+	{
 
-			if extra > cbg.ByteArrayMaxLen {
-				return fmt.Errorf("t.NewMultiaddrs[i]: byte array too large (%d)", extra)
-			}
-			if maj != cbg.MajByteString {
-				return fmt.Errorf("expected byte array")
-			}
-			t.NewMultiaddrs[i] = make([]byte, extra)
-			if _, err := io.ReadFull(br, t.NewMultiaddrs[i]); err != nil {
-				return err
-			}
+		if err := t.Worker.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Worker: %w", err)
 		}
-	}
 
+	}
 	return nil
 }
 
-var lengthBufProveCommitSectorParams = []byte{130}
+var lengthBufCheckSectorProvenParams = []byte{129}
 
-func (t *ProveCommitSectorParams) MarshalCBOR(w io.Writer) error {
+func (t *CheckSectorProvenParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufProveCommitSectorParams); err != nil {
+	if _, err := w.Write(lengthBufCheckSectorProvenParams); err != nil {
 		return err
 	}
 
@@ -2454,23 +4002,11 @@ func (t *ProveCommitSectorParams) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
-	// t.Proof ([]uint8) (slice)
-	if len(t.Proof) > cbg.ByteArrayMaxLen {
-		return xerrors.Errorf("Byte array in field t.Proof was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Proof))); err != nil {
-		return err
-	}
-
-	if _, err := w.Write(t.Proof); err != nil {
-		return err
-	}
 	return nil
 }
 
-func (t *ProveCommitSectorParams) UnmarshalCBOR(r io.Reader) error {
-	*t = ProveCommitSectorParams{}
+func (t *CheckSectorProvenParams) UnmarshalCBOR(r io.Reader) error {
+	*t = CheckSectorProvenParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2483,7 +4019,7 @@ func (t *ProveCommitSectorParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 1 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -2501,46 +4037,29 @@ func (t *ProveCommitSectorParams) UnmarshalCBOR(r io.Reader) error {
 		t.SectorNumber = abi.SectorNumber(extra)
 
 	}
-	// t.Proof ([]uint8) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
-
-	if extra > cbg.ByteArrayMaxLen {
-		return fmt.Errorf("t.Proof: byte array too large (%d)", extra)
-	}
-	if maj != cbg.MajByteString {
-		return fmt.Errorf("expected byte array")
-	}
-	t.Proof = make([]byte, extra)
-	if _, err := io.ReadFull(br, t.Proof); err != nil {
-		return err
-	}
 	return nil
 }
 
-var lengthBufChangeWorkerAddressParams = []byte{129}
+var lengthBufWithdrawBalanceParams = []byte{129}
 
-func (t *ChangeWorkerAddressParams) MarshalCBOR(w io.Writer) error {
+func (t *WithdrawBalanceParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufChangeWorkerAddressParams); err != nil {
+	if _, err := w.Write(lengthBufWithdrawBalanceParams); err != nil {
 		return err
 	}
 
-	// t.NewWorker (address.Address) (struct)
-	if err := t.NewWorker.MarshalCBOR(w); err != nil {
+	// t.AmountRequested (big.Int) (struct)
+	if err := t.AmountRequested.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *ChangeWorkerAddressParams) UnmarshalCBOR(r io.Reader) error {
-	*t = ChangeWorkerAddressParams{}
+func (t *WithdrawBalanceParams) UnmarshalCBOR(r io.Reader) error {
+	*t = WithdrawBalanceParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2557,49 +4076,55 @@ func (t *ChangeWorkerAddressParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.NewWorker (address.Address) (struct)
+	// t.AmountRequested (big.Int) (struct)
 
 	{
 
-		if err := t.NewWorker.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.NewWorker: %w", err)
+		if err := t.AmountRequested.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AmountRequested: %w", err)
 		}
 
 	}
 	return nil
 }
 
-var lengthBufExtendSectorExpirationParams = []byte{129}
+var lengthBufCompactPartitionsParams = []byte{130}
 
-func (t *ExtendSectorExpirationParams) MarshalCBOR(w io.Writer) error {
+func (t *CompactPartitionsParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufExtendSectorExpirationParams); err != nil {
+	if _, err := w.Write(lengthBufCompactPartitionsParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.Extensions ([]miner.ExpirationExtension) (slice)
-	if len(t.Extensions) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Extensions was too long")
+	// t.Deadline (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
+		return err
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Extensions))); err != nil {
+	// t.Partitions ([]uint64) (slice)
+	if len(t.Partitions) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Partitions was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Partitions))); err != nil {
 		return err
 	}
-	for _, v := range t.Extensions {
-		if err := v.MarshalCBOR(w); err != nil {
+	for _, v := range t.Partitions {
+		if err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (t *ExtendSectorExpirationParams) UnmarshalCBOR(r io.Reader) error {
-	*t = ExtendSectorExpirationParams{}
+func (t *CompactPartitionsParams) UnmarshalCBOR(r io.Reader) error {
+	*t = CompactPartitionsParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2612,11 +4137,25 @@ func (t *ExtendSectorExpirationParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 2 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Extensions ([]miner.ExpirationExtension) (slice)
+	// t.Deadline (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Deadline = uint64(extra)
+
+	}
+	// t.Partitions ([]uint64) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2624,7 +4163,7 @@ func (t *ExtendSectorExpirationParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Extensions: array too large (%d)", extra)
+		return fmt.Errorf("t.Partitions: array too large (%d)", extra)
 	}
 
 	if maj != cbg.MajArray {
@@ -2632,53 +4171,56 @@ func (t *ExtendSectorExpirationParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > 0 {
-		t.Extensions = make([]ExpirationExtension, extra)
+		t.Partitions = make([]uint64, extra)
 	}
 
 	for i := 0; i < int(extra); i++ {
 
-		var v ExpirationExtension
-		if err := v.UnmarshalCBOR(br); err != nil {
-			return err
+		maj, val, err := cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return xerrors.Errorf("failed to read uint64 for t.Partitions slice: %w", err)
 		}
 
-		t.Extensions[i] = v
+		if maj != cbg.MajUnsignedInt {
+			return xerrors.Errorf("value read for array t.Partitions was not a uint, instead got %d", maj)
+		}
+
+		t.Partitions[i] = uint64(val)
 	}
 
 	return nil
 }
 
-var lengthBufDeclareFaultsParams = []byte{129}
+var lengthBufDisputeWindowedPoStParams = []byte{130}
 
-func (t *DeclareFaultsParams) MarshalCBOR(w io.Writer) error {
+func (t *DisputeWindowedPoStParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufDeclareFaultsParams); err != nil {
+	if _, err := w.Write(lengthBufDisputeWindowedPoStParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.Faults ([]miner.FaultDeclaration) (slice)
-	if len(t.Faults) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Faults was too long")
-	}
+	// t.Deadline (uint64) (uint64)
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Faults))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
 		return err
 	}
-	for _, v := range t.Faults {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
+
+	// t.PoStIndex (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.PoStIndex)); err != nil {
+		return err
 	}
+
 	return nil
 }
 
-func (t *DeclareFaultsParams) UnmarshalCBOR(r io.Reader) error {
-	*t = DeclareFaultsParams{}
+func (t *DisputeWindowedPoStParams) UnmarshalCBOR(r io.Reader) error {
+	*t = DisputeWindowedPoStParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2691,64 +4233,63 @@ func (t *DeclareFaultsParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 2 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Faults ([]miner.FaultDeclaration) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+	// t.Deadline (uint64) (uint64)
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Faults: array too large (%d)", extra)
-	}
+	{
 
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
-	}
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Deadline = uint64(extra)
 
-	if extra > 0 {
-		t.Faults = make([]FaultDeclaration, extra)
 	}
+	// t.PoStIndex (uint64) (uint64)
 
-	for i := 0; i < int(extra); i++ {
+	{
 
-		var v FaultDeclaration
-		if err := v.UnmarshalCBOR(br); err != nil {
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
 			return err
 		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.PoStIndex = uint64(extra)
 
-		t.Faults[i] = v
 	}
-
 	return nil
 }
 
-var lengthBufDeclareFaultsRecoveredParams = []byte{129}
+var lengthBufProveReplicaUpdatesParams = []byte{129}
 
-func (t *DeclareFaultsRecoveredParams) MarshalCBOR(w io.Writer) error {
+func (t *ProveReplicaUpdatesParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufDeclareFaultsRecoveredParams); err != nil {
+	if _, err := w.Write(lengthBufProveReplicaUpdatesParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.Recoveries ([]miner.RecoveryDeclaration) (slice)
-	if len(t.Recoveries) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Recoveries was too long")
+	// t.Updates ([]miner.ReplicaUpdate) (slice)
+	if len(t.Updates) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Updates was too long")
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Recoveries))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Updates))); err != nil {
 		return err
 	}
-	for _, v := range t.Recoveries {
+	for _, v := range t.Updates {
 		if err := v.MarshalCBOR(w); err != nil {
 			return err
 		}
@@ -2756,8 +4297,8 @@ func (t *DeclareFaultsRecoveredParams) MarshalCBOR(w io.Writer) error {
 	return nil
 }
 
-func (t *DeclareFaultsRecoveredParams) UnmarshalCBOR(r io.Reader) error {
-	*t = DeclareFaultsRecoveredParams{}
+func (t *ProveReplicaUpdatesParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ProveReplicaUpdatesParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2774,7 +4315,7 @@ func (t *DeclareFaultsRecoveredParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Recoveries ([]miner.RecoveryDeclaration) (slice)
+	// t.Updates ([]miner.ReplicaUpdate) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
@@ -2782,7 +4323,7 @@ func (t *DeclareFaultsRecoveredParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Recoveries: array too large (%d)", extra)
+		return fmt.Errorf("t.Updates: array too large (%d)", extra)
 	}
 
 	if maj != cbg.MajArray {
@@ -2790,78 +4331,42 @@ func (t *DeclareFaultsRecoveredParams) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > 0 {
-		t.Recoveries = make([]RecoveryDeclaration, extra)
+		t.Updates = make([]ReplicaUpdate, extra)
 	}
 
 	for i := 0; i < int(extra); i++ {
 
-		var v RecoveryDeclaration
+		var v ReplicaUpdate
 		if err := v.UnmarshalCBOR(br); err != nil {
 			return err
 		}
 
-		t.Recoveries[i] = v
+		t.Updates[i] = v
 	}
 
 	return nil
 }
 
-var lengthBufReportConsensusFaultParams = []byte{131}
+var lengthBufMaskSectorNumbersParams = []byte{129}
 
-func (t *ReportConsensusFaultParams) MarshalCBOR(w io.Writer) error {
+func (t *MaskSectorNumbersParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufReportConsensusFaultParams); err != nil {
-		return err
-	}
-
-	scratch := make([]byte, 9)
-
-	// t.BlockHeader1 ([]uint8) (slice)
-	if len(t.BlockHeader1) > cbg.ByteArrayMaxLen {
-		return xerrors.Errorf("Byte array in field t.BlockHeader1 was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.BlockHeader1))); err != nil {
-		return err
-	}
-
-	if _, err := w.Write(t.BlockHeader1); err != nil {
-		return err
-	}
-
-	// t.BlockHeader2 ([]uint8) (slice)
-	if len(t.BlockHeader2) > cbg.ByteArrayMaxLen {
-		return xerrors.Errorf("Byte array in field t.BlockHeader2 was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.BlockHeader2))); err != nil {
-		return err
-	}
-
-	if _, err := w.Write(t.BlockHeader2); err != nil {
-		return err
-	}
-
-	// t.BlockHeaderExtra ([]uint8) (slice)
-	if len(t.BlockHeaderExtra) > cbg.ByteArrayMaxLen {
-		return xerrors.Errorf("Byte array in field t.BlockHeaderExtra was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.BlockHeaderExtra))); err != nil {
+	if _, err := w.Write(lengthBufMaskSectorNumbersParams); err != nil {
 		return err
 	}
 
-	if _, err := w.Write(t.BlockHeaderExtra); err != nil {
+	// t.Mask (bitfield.BitField) (struct)
+	if err := t.Mask.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *ReportConsensusFaultParams) UnmarshalCBOR(r io.Reader) error {
-	*t = ReportConsensusFaultParams{}
+func (t *MaskSectorNumbersParams) UnmarshalCBOR(r io.Reader) error {
+	*t = MaskSectorNumbersParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2874,89 +4379,68 @@ func (t *ReportConsensusFaultParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 3 {
+	if extra != 1 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.BlockHeader1 ([]uint8) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
-
-	if extra > cbg.ByteArrayMaxLen {
-		return fmt.Errorf("t.BlockHeader1: byte array too large (%d)", extra)
-	}
-	if maj != cbg.MajByteString {
-		return fmt.Errorf("expected byte array")
-	}
-	t.BlockHeader1 = make([]byte, extra)
-	if _, err := io.ReadFull(br, t.BlockHeader1); err != nil {
-		return err
-	}
-	// t.BlockHeader2 ([]uint8) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+	// t.Mask (bitfield.BitField) (struct)
 
-	if extra > cbg.ByteArrayMaxLen {
-		return fmt.Errorf("t.BlockHeader2: byte array too large (%d)", extra)
-	}
-	if maj != cbg.MajByteString {
-		return fmt.Errorf("expected byte array")
-	}
-	t.BlockHeader2 = make([]byte, extra)
-	if _, err := io.ReadFull(br, t.BlockHeader2); err != nil {
-		return err
-	}
-	// t.BlockHeaderExtra ([]uint8) (slice)
+	{
 
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+		pb, err := br.PeekByte()
+		if err != nil {
+			return err
+		}
+		if pb == cbg.CborNull[0] {
+			var nbuf [1]byte
+			if _, err := br.Read(nbuf[:]); err != nil {
+				return err
+			}
+		} else {
+			t.Mask = new(bitfield.BitField)
+			if err := t.Mask.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Mask pointer: %w", err)
+			}
+		}
 
-	if extra > cbg.ByteArrayMaxLen {
-		return fmt.Errorf("t.BlockHeaderExtra: byte array too large (%d)", extra)
-	}
-	if maj != cbg.MajByteString {
-		return fmt.Errorf("expected byte array")
-	}
-	t.BlockHeaderExtra = make([]byte, extra)
-	if _, err := io.ReadFull(br, t.BlockHeaderExtra); err != nil {
-		return err
 	}
 	return nil
 }
 
-var lengthBufGetControlAddressesReturn = []byte{130}
+var lengthBufMovePartitionsParams = []byte{131}
 
-func (t *GetControlAddressesReturn) MarshalCBOR(w io.Writer) error {
+func (t *MovePartitionsParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufGetControlAddressesReturn); err != nil {
+	if _, err := w.Write(lengthBufMovePartitionsParams); err != nil {
 		return err
 	}
 
-	// t.Owner (address.Address) (struct)
-	if err := t.Owner.MarshalCBOR(w); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.OrigDeadline (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.OrigDeadline)); err != nil {
 		return err
 	}
 
-	// t.Worker (address.Address) (struct)
-	if err := t.Worker.MarshalCBOR(w); err != nil {
+	// t.DestDeadline (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.DestDeadline)); err != nil {
+		return err
+	}
+
+	// t.Partitions (bitfield.BitField) (struct)
+	if err := t.Partitions.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *GetControlAddressesReturn) UnmarshalCBOR(r io.Reader) error {
-	*t = GetControlAddressesReturn{}
+func (t *MovePartitionsParams) UnmarshalCBOR(r io.Reader) error {
+	*t = MovePartitionsParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -2969,55 +4453,98 @@ func (t *GetControlAddressesReturn) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Owner (address.Address) (struct)
+	// t.OrigDeadline (uint64) (uint64)
 
 	{
 
-		if err := t.Owner.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.Owner: %w", err)
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
 		}
+		t.OrigDeadline = uint64(extra)
 
 	}
-	// t.Worker (address.Address) (struct)
+	// t.DestDeadline (uint64) (uint64)
 
 	{
 
-		if err := t.Worker.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.Worker: %w", err)
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.DestDeadline = uint64(extra)
+
+	}
+	// t.Partitions (bitfield.BitField) (struct)
+
+	{
+
+		pb, err := br.PeekByte()
+		if err != nil {
+			return err
+		}
+		if pb == cbg.CborNull[0] {
+			var nbuf [1]byte
+			if _, err := br.Read(nbuf[:]); err != nil {
+				return err
+			}
+		} else {
+			t.Partitions = new(bitfield.BitField)
+			if err := t.Partitions.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Partitions pointer: %w", err)
+			}
 		}
 
 	}
 	return nil
 }
 
-var lengthBufCheckSectorProvenParams = []byte{129}
+var lengthBufCompactSectorNumbersParams = []byte{131}
 
-func (t *CheckSectorProvenParams) MarshalCBOR(w io.Writer) error {
+func (t *CompactSectorNumbersParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufCheckSectorProvenParams); err != nil {
+	if _, err := w.Write(lengthBufCompactSectorNumbersParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.SectorNumber (abi.SectorNumber) (uint64)
+	// t.Deadline (uint64) (uint64)
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SectorNumber)); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
+		return err
+	}
+
+	// t.Partition (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Partition)); err != nil {
+		return err
+	}
+
+	// t.FirstSectorNumber (abi.SectorNumber) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.FirstSectorNumber)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (t *CheckSectorProvenParams) UnmarshalCBOR(r io.Reader) error {
-	*t = CheckSectorProvenParams{}
+func (t *CompactSectorNumbersParams) UnmarshalCBOR(r io.Reader) error {
+	*t = CompactSectorNumbersParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -3030,11 +4557,11 @@ func (t *CheckSectorProvenParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.SectorNumber (abi.SectorNumber) (uint64)
+	// t.Deadline (uint64) (uint64)
 
 	{
 
@@ -3045,32 +4572,78 @@ func (t *CheckSectorProvenParams) UnmarshalCBOR(r io.Reader) error {
 		if maj != cbg.MajUnsignedInt {
 			return fmt.Errorf("wrong type for uint64 field")
 		}
-		t.SectorNumber = abi.SectorNumber(extra)
+		t.Deadline = uint64(extra)
+
+	}
+	// t.Partition (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Partition = uint64(extra)
+
+	}
+	// t.FirstSectorNumber (abi.SectorNumber) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.FirstSectorNumber = abi.SectorNumber(extra)
 
 	}
 	return nil
 }
 
-var lengthBufWithdrawBalanceParams = []byte{129}
+var lengthBufChangeBeneficiaryParams = []byte{131}
 
-func (t *WithdrawBalanceParams) MarshalCBOR(w io.Writer) error {
+func (t *ChangeBeneficiaryParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufWithdrawBalanceParams); err != nil {
+	if _, err := w.Write(lengthBufChangeBeneficiaryParams); err != nil {
 		return err
 	}
 
-	// t.AmountRequested (big.Int) (struct)
-	if err := t.AmountRequested.MarshalCBOR(w); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.NewBeneficiary (address.Address) (struct)
+	if err := t.NewBeneficiary.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.NewQuota (big.Int) (struct)
+	if err := t.NewQuota.MarshalCBOR(w); err != nil {
 		return err
 	}
+
+	// t.NewExpiration (abi.ChainEpoch) (int64)
+	if t.NewExpiration >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.NewExpiration)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.NewExpiration-1)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (t *WithdrawBalanceParams) UnmarshalCBOR(r io.Reader) error {
-	*t = WithdrawBalanceParams{}
+func (t *ChangeBeneficiaryParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeBeneficiaryParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -3083,59 +4656,86 @@ func (t *WithdrawBalanceParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.AmountRequested (big.Int) (struct)
+	// t.NewBeneficiary (address.Address) (struct)
 
 	{
 
-		if err := t.AmountRequested.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AmountRequested: %w", err)
+		if err := t.NewBeneficiary.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewBeneficiary: %w", err)
+		}
+
+	}
+	// t.NewQuota (big.Int) (struct)
+
+	{
+
+		if err := t.NewQuota.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewQuota: %w", err)
+		}
+
+	}
+	// t.NewExpiration (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
 		}
 
+		t.NewExpiration = abi.ChainEpoch(extraI)
 	}
 	return nil
 }
 
-var lengthBufCompactPartitionsParams = []byte{130}
+var lengthBufGetBeneficiaryReturn = []byte{131}
 
-func (t *CompactPartitionsParams) MarshalCBOR(w io.Writer) error {
+func (t *GetBeneficiaryReturn) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufCompactPartitionsParams); err != nil {
+	if _, err := w.Write(lengthBufGetBeneficiaryReturn); err != nil {
 		return err
 	}
 
-	scratch := make([]byte, 9)
-
-	// t.Deadline (uint64) (uint64)
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
+	// t.Beneficiary (address.Address) (struct)
+	if err := t.Beneficiary.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.Partitions ([]uint64) (slice)
-	if len(t.Partitions) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Partitions was too long")
+	// t.Term (miner.BeneficiaryTerm) (struct)
+	if err := t.Term.MarshalCBOR(w); err != nil {
+		return err
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Partitions))); err != nil {
+	// t.Proposed (miner.PendingBeneficiaryChange) (struct)
+	if err := t.Proposed.MarshalCBOR(w); err != nil {
 		return err
 	}
-	for _, v := range t.Partitions {
-		if err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
-			return err
-		}
-	}
 	return nil
 }
 
-func (t *CompactPartitionsParams) UnmarshalCBOR(r io.Reader) error {
-	*t = CompactPartitionsParams{}
+func (t *GetBeneficiaryReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetBeneficiaryReturn{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -3148,57 +4748,49 @@ func (t *CompactPartitionsParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Deadline (uint64) (uint64)
+	// t.Beneficiary (address.Address) (struct)
 
 	{
 
-		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-		if err != nil {
-			return err
-		}
-		if maj != cbg.MajUnsignedInt {
-			return fmt.Errorf("wrong type for uint64 field")
+		if err := t.Beneficiary.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Beneficiary: %w", err)
 		}
-		t.Deadline = uint64(extra)
-
-	}
-	// t.Partitions ([]uint64) (slice)
 
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
 	}
+	// t.Term (miner.BeneficiaryTerm) (struct)
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Partitions: array too large (%d)", extra)
-	}
+	{
 
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
-	}
+		if err := t.Term.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Term: %w", err)
+		}
 
-	if extra > 0 {
-		t.Partitions = make([]uint64, extra)
 	}
+	// t.Proposed (miner.PendingBeneficiaryChange) (struct)
 
-	for i := 0; i < int(extra); i++ {
+	{
 
-		maj, val, err := cbg.CborReadHeaderBuf(br, scratch)
+		pb, err := br.PeekByte()
 		if err != nil {
-			return xerrors.Errorf("failed to read uint64 for t.Partitions slice: %w", err)
+			return err
 		}
-
-		if maj != cbg.MajUnsignedInt {
-			return xerrors.Errorf("value read for array t.Partitions was not a uint, instead got %d", maj)
+		if pb == cbg.CborNull[0] {
+			var nbuf [1]byte
+			if _, err := br.Read(nbuf[:]); err != nil {
+				return err
+			}
+		} else {
+			t.Proposed = new(PendingBeneficiaryChange)
+			if err := t.Proposed.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Proposed pointer: %w", err)
+			}
 		}
 
-		t.Partitions[i] = uint64(val)
 	}
-
 	return nil
 }
 
@@ -3825,3 +5417,212 @@ func (t *PoStPartition) UnmarshalCBOR(r io.Reader) error {
 	}
 	return nil
 }
+
+var lengthBufReplicaUpdate = []byte{135}
+
+func (t *ReplicaUpdate) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufReplicaUpdate); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.SectorNumber (abi.SectorNumber) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SectorNumber)); err != nil {
+		return err
+	}
+
+	// t.Deadline (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
+		return err
+	}
+
+	// t.Partition (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Partition)); err != nil {
+		return err
+	}
+
+	// t.NewSealedCID (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.NewSealedCID); err != nil {
+		return xerrors.Errorf("failed to write cid field t.NewSealedCID: %w", err)
+	}
+
+	// t.NewUnsealedCID (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.NewUnsealedCID); err != nil {
+		return xerrors.Errorf("failed to write cid field t.NewUnsealedCID: %w", err)
+	}
+
+	// t.Deals ([]abi.DealID) (slice)
+	if len(t.Deals) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Deals was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Deals))); err != nil {
+		return err
+	}
+	for _, v := range t.Deals {
+		if err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
+			return err
+		}
+	}
+
+	// t.ReplicaProof ([]uint8) (slice)
+	if len(t.ReplicaProof) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.ReplicaProof was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.ReplicaProof))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.ReplicaProof); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ReplicaUpdate) UnmarshalCBOR(r io.Reader) error {
+	*t = ReplicaUpdate{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 7 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.SectorNumber (abi.SectorNumber) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.SectorNumber = abi.SectorNumber(extra)
+
+	}
+	// t.Deadline (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Deadline = uint64(extra)
+
+	}
+	// t.Partition (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Partition = uint64(extra)
+
+	}
+	// t.NewSealedCID (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.NewSealedCID: %w", err)
+		}
+
+		t.NewSealedCID = c
+
+	}
+	// t.NewUnsealedCID (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.NewUnsealedCID: %w", err)
+		}
+
+		t.NewUnsealedCID = c
+
+	}
+	// t.Deals ([]abi.DealID) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Deals: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Deals = make([]abi.DealID, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		maj, val, err := cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return xerrors.Errorf("failed to read uint64 for t.Deals slice: %w", err)
+		}
+
+		if maj != cbg.MajUnsignedInt {
+			return xerrors.Errorf("value read for array t.Deals was not a uint, instead got %d", maj)
+		}
+
+		t.Deals[i] = abi.DealID(val)
+	}
+
+	// t.ReplicaProof ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.ReplicaProof: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+	t.ReplicaProof = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.ReplicaProof); err != nil {
+		return err
+	}
+	return nil
+}
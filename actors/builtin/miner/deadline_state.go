@@ -2,6 +2,7 @@ package miner
 
 import (
 	"errors"
+	"sort"
 
 	"github.com/filecoin-project/go-bitfield"
 	"github.com/ipfs/go-cid"
@@ -48,6 +49,27 @@ type Deadline struct {
 
 	// The total number of sectors in this deadline (incl dead).
 	TotalSectors uint64
+
+	// Proofs accepted without verification since the proving period started.
+	// These are moved to OptimisticPoStSubmissionsSnapshot when the deadline closes.
+	OptimisticPoStSubmissions cid.Cid // AMT[]WindowedPoSt
+
+	// Proofs accepted without verification in the deadline's most recent challenge window,
+	// which may be disputed until WPoStDisputeWindow epochs after the deadline closed.
+	OptimisticPoStSubmissionsSnapshot cid.Cid // AMT[]WindowedPoSt
+}
+
+// A Window PoSt accepted without verification, recorded with the sectors it claimed to prove
+// so that it may later be disputed.
+type WindowedPoSt struct {
+	// Partitions proven by this submission.
+	Partitions *abi.BitField
+	// The sectors challenged by the proof, with a stand-in sector for those that were faulty or terminated,
+	// as at the time of submission. These are retained so that the proof can be verified even after
+	// sectors have since been terminated, removed or renumbered.
+	SectorInfos []abi.SectorInfo
+	// Array of proofs, one per distinct registered proof type present in the sectors being proven.
+	Proofs []abi.PoStProof
 }
 
 //
@@ -111,6 +133,9 @@ func ConstructDeadline(emptyArrayCid cid.Cid) *Deadline {
 		PostSubmissions:   abi.NewBitField(),
 		EarlyTerminations: abi.NewBitField(),
 		LiveSectors:       0,
+
+		OptimisticPoStSubmissions:         emptyArrayCid,
+		OptimisticPoStSubmissionsSnapshot: emptyArrayCid,
 	}
 }
 
@@ -417,12 +442,274 @@ func (dl *Deadline) PopEarlyTerminations(store adt.Store, maxPartitions, maxSect
 	return result, !noEarlyTerminations, nil
 }
 
+// Terminates sectors in some of a deadline's partitions, marking those partitions as having pending early
+// terminations. Sectors are keyed by partition index.
+// Returns the power removed from the partitions, and the part of that power which was faulty.
+func (dl *Deadline) TerminateSectors(store adt.Store, epoch abi.ChainEpoch, partitionSectors map[uint64][]*SectorOnChainInfo,
+	ssize abi.SectorSize, quant QuantSpec) (removed, removedFaulty PowerPair, err error) {
+	removed, removedFaulty = NewPowerPairZero(), NewPowerPairZero()
+	partitions, err := dl.PartitionsArray(store)
+	if err != nil {
+		return removed, removedFaulty, xerrors.Errorf("failed to load partitions: %w", err)
+	}
+
+	partIdxs := make([]uint64, 0, len(partitionSectors))
+	for partIdx := range partitionSectors {
+		partIdxs = append(partIdxs, partIdx)
+	}
+	sort.Slice(partIdxs, func(i, j int) bool { return partIdxs[i] < partIdxs[j] })
+
+	for _, partIdx := range partIdxs {
+		sectors := partitionSectors[partIdx]
+		var partition Partition
+		if found, err := partitions.Get(partIdx, &partition); err != nil {
+			return removed, removedFaulty, xerrors.Errorf("failed to load partition %d: %w", partIdx, err)
+		} else if !found {
+			return removed, removedFaulty, xerrors.Errorf("no partition %d", partIdx)
+		}
+
+		faultyBefore := partition.FaultyPower
+		partitionRemoved, err := partition.TerminateSectors(store, epoch, sectors, ssize, quant)
+		if err != nil {
+			return removed, removedFaulty, xerrors.Errorf("failed to terminate sectors in partition %d: %w", partIdx, err)
+		}
+		if err = partitions.Set(partIdx, &partition); err != nil {
+			return removed, removedFaulty, xerrors.Errorf("failed to store partition %d: %w", partIdx, err)
+		}
+
+		// Record that the partition now has pending early terminations.
+		dl.EarlyTerminations.Set(partIdx)
+		dl.LiveSectors -= uint64(len(sectors))
+		removed = removed.Add(partitionRemoved)
+		removedFaulty = removedFaulty.Add(faultyBefore.Sub(partition.FaultyPower))
+	}
+
+	if dl.Partitions, err = partitions.Root(); err != nil {
+		return removed, removedFaulty, xerrors.Errorf("failed to store partitions: %w", err)
+	}
+	return removed, removedFaulty, nil
+}
+
+// Removes partitions from a deadline, re-indexing the partitions that remain so that partition numbers stay
+// sequential. Returns the removed partitions, in order of their original index.
+// The partitions' expiration and early termination indexes are rebuilt for their new positions.
+// It's the caller's responsibility to make sure that this deadline has no PoSt submissions or disputable proofs
+// that refer to partitions by their old index.
+func (dl *Deadline) RemovePartitions(store adt.Store, toRemove *abi.BitField, quant QuantSpec) ([]*Partition, error) {
+	if noSubmissions, err := dl.PostSubmissions.IsEmpty(); err != nil {
+		return nil, xerrors.Errorf("failed to count PoSt submissions: %w", err)
+	} else if !noSubmissions {
+		return nil, xerrors.Errorf("cannot remove partitions from deadline with PoSt submissions")
+	}
+
+	partitions, err := dl.PartitionsArray(store)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load partitions: %w", err)
+	}
+
+	if err = toRemove.ForEach(func(partIdx uint64) error {
+		if partIdx >= partitions.Length() {
+			return xerrors.Errorf("partition index %d out of range [0, %d)", partIdx, partitions.Length())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var kept, removed []*Partition
+	var partition Partition
+	if err = partitions.ForEach(&partition, func(i int64) error {
+		toMove := partition
+		if remove, err := toRemove.IsSet(uint64(i)); err != nil {
+			return err
+		} else if remove {
+			removed = append(removed, &toMove)
+		} else {
+			kept = append(kept, &toMove)
+		}
+		return nil
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to walk partitions: %w", err)
+	}
+
+	// Rebuild the deadline from the partitions that remain.
+	emptyArray, err := adt.MakeEmptyArray(store).Root()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct empty array: %w", err)
+	}
+	dl.Partitions = emptyArray
+	dl.ExpirationsEpochs = emptyArray
+	dl.EarlyTerminations = abi.NewBitField()
+	dl.LiveSectors = 0
+	dl.TotalSectors = 0
+	if err = dl.AppendPartitions(store, kept, quant); err != nil {
+		return nil, xerrors.Errorf("failed to re-index remaining partitions: %w", err)
+	}
+	return removed, nil
+}
+
+// Appends whole partitions to a deadline, after any existing partitions, indexing their expirations and any
+// pending early terminations. The partitions are not merged with any existing partially-full partition.
+// It's the caller's responsibility to make sure that this deadline isn't currently "open".
+func (dl *Deadline) AppendPartitions(store adt.Store, newPartitions []*Partition, quant QuantSpec) error {
+	partitions, err := dl.PartitionsArray(store)
+	if err != nil {
+		return xerrors.Errorf("failed to load partitions: %w", err)
+	}
+
+	expirationUpdates := make(map[abi.ChainEpoch][]uint64)
+	for _, partition := range newPartitions {
+		partIdx := partitions.Length()
+		if err = partitions.AppendContinuous(partition); err != nil {
+			return xerrors.Errorf("failed to append partition %d: %w", partIdx, err)
+		}
+
+		// Every epoch at which the partition has sectors expiring, on-time or as faults, must be indexed.
+		expirations, err := LoadExpirationQueue(store, partition.ExpirationsEpochs, quant)
+		if err != nil {
+			return xerrors.Errorf("failed to load expiration queue for partition %d: %w", partIdx, err)
+		}
+		if err = expirations.ForEach(nil, func(epoch int64) error {
+			expirationUpdates[abi.ChainEpoch(epoch)] = append(expirationUpdates[abi.ChainEpoch(epoch)], partIdx)
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("failed to walk expirations for partition %d: %w", partIdx, err)
+		}
+
+		earlyTerminated, err := adt.AsArray(store, partition.EarlyTerminated)
+		if err != nil {
+			return xerrors.Errorf("failed to load early terminations for partition %d: %w", partIdx, err)
+		}
+		if earlyTerminated.Length() > 0 {
+			dl.EarlyTerminations.Set(partIdx)
+		}
+
+		sectorCount, err := partition.Sectors.Count()
+		if err != nil {
+			return xerrors.Errorf("failed to count sectors in partition %d: %w", partIdx, err)
+		}
+		liveSectors, err := partition.LiveSectors()
+		if err != nil {
+			return xerrors.Errorf("failed to compute live sectors in partition %d: %w", partIdx, err)
+		}
+		liveCount, err := liveSectors.Count()
+		if err != nil {
+			return xerrors.Errorf("failed to count live sectors in partition %d: %w", partIdx, err)
+		}
+		dl.TotalSectors += sectorCount
+		dl.LiveSectors += liveCount
+	}
+
+	if dl.Partitions, err = partitions.Root(); err != nil {
+		return xerrors.Errorf("failed to save partitions: %w", err)
+	}
+
+	deadlineExpirations, err := LoadBitfieldQueue(store, dl.ExpirationsEpochs, quant)
+	if err != nil {
+		return xerrors.Errorf("failed to load expiration epochs: %w", err)
+	}
+	if err = deadlineExpirations.AddManyToQueueValues(expirationUpdates); err != nil {
+		return xerrors.Errorf("failed to add expirations for appended partitions: %w", err)
+	}
+	if dl.ExpirationsEpochs, err = deadlineExpirations.Root(); err != nil {
+		return xerrors.Errorf("failed to save expiration epochs: %w", err)
+	}
+	return nil
+}
+
+// Assigns the live sectors of a partition new, consecutive numbers starting at firstSectorNo, and removes the
+// partition's terminated sectors. Returns the mapping from old to new sector numbers, and the numbers of the
+// terminated sectors removed.
+// It's the caller's responsibility to make sure that this deadline has no disputable proofs that refer to the
+// partition's sectors by their old numbers.
+func (dl *Deadline) RenumberSectors(store adt.Store, partIdx uint64, firstSectorNo abi.SectorNumber, quant QuantSpec) (map[uint64]uint64, *abi.BitField, error) {
+	partitions, err := dl.PartitionsArray(store)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to load partitions: %w", err)
+	}
+	var partition Partition
+	if found, err := partitions.Get(partIdx, &partition); err != nil {
+		return nil, nil, xerrors.Errorf("failed to load partition %d: %w", partIdx, err)
+	} else if !found {
+		return nil, nil, xerrors.Errorf("no partition %d", partIdx)
+	}
+
+	renumbered, removed, err := partition.RenumberSectors(store, firstSectorNo, quant)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to renumber sectors in partition %d: %w", partIdx, err)
+	}
+	if err = partitions.Set(partIdx, &partition); err != nil {
+		return nil, nil, xerrors.Errorf("failed to store partition %d: %w", partIdx, err)
+	}
+	if dl.Partitions, err = partitions.Root(); err != nil {
+		return nil, nil, xerrors.Errorf("failed to save partitions: %w", err)
+	}
+	removedCount, err := removed.Count()
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to count removed sectors: %w", err)
+	}
+	dl.TotalSectors -= removedCount
+	return renumbered, removed, nil
+}
+
 func (dl *Deadline) AddPoStSubmissions(idxs []uint64) {
 	for _, pIdx := range idxs {
 		dl.PostSubmissions.Set(pIdx)
 	}
 }
 
+// Records a proof accepted without verification so that it may be disputed after the deadline closes.
+func (dl *Deadline) RecordOptimisticPoSt(store adt.Store, post *WindowedPoSt) error {
+	submissions, err := adt.AsArray(store, dl.OptimisticPoStSubmissions)
+	if err != nil {
+		return xerrors.Errorf("failed to load optimistic proofs: %w", err)
+	}
+	err = submissions.AppendContinuous(post)
+	if err != nil {
+		return xerrors.Errorf("failed to store optimistic proof: %w", err)
+	}
+	if dl.OptimisticPoStSubmissions, err = submissions.Root(); err != nil {
+		return xerrors.Errorf("failed to save optimistic proofs: %w", err)
+	}
+	return nil
+}
+
+// Snapshots the optimistic proofs at the close of the deadline, and resets them for the next proving period.
+// Any previous snapshot is replaced.
+func (dl *Deadline) TakePoStSnapshot(store adt.Store) error {
+	emptyArray, err := adt.MakeEmptyArray(store).Root()
+	if err != nil {
+		return xerrors.Errorf("failed to construct empty array: %w", err)
+	}
+	dl.OptimisticPoStSubmissionsSnapshot = dl.OptimisticPoStSubmissions
+	dl.OptimisticPoStSubmissions = emptyArray
+	return nil
+}
+
+// Removes and returns an optimistic proof from the snapshot taken when the deadline last closed.
+// Returns false if there is no proof at that index, e.g. because it has already been disputed.
+func (dl *Deadline) TakeOptimisticPoStSnapshot(store adt.Store, idx uint64) (*WindowedPoSt, bool, error) {
+	proofs, err := adt.AsArray(store, dl.OptimisticPoStSubmissionsSnapshot)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load optimistic proofs snapshot: %w", err)
+	}
+	var post WindowedPoSt
+	found, err := proofs.Get(idx, &post)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load optimistic proof %d: %w", idx, err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	if err = proofs.Delete(idx); err != nil {
+		return nil, false, xerrors.Errorf("failed to delete optimistic proof %d: %w", idx, err)
+	}
+	if dl.OptimisticPoStSubmissionsSnapshot, err = proofs.Root(); err != nil {
+		return nil, false, xerrors.Errorf("failed to save optimistic proofs snapshot: %w", err)
+	}
+	return &post, true, nil
+}
+
 // Returns nil if nothing was popped.
 func (dl *Deadline) popExpiredPartitions(store adt.Store, until abi.ChainEpoch, quant QuantSpec) (*abi.BitField, bool, error) {
 	expirations, err := LoadBitfieldQueue(store, dl.ExpirationsEpochs, quant)
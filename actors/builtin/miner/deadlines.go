@@ -144,6 +144,107 @@ func FindSector(store adt.Store, deadlines *Deadlines, sectorNum abi.SectorNumbe
 	return 0, 0, xerrors.Errorf("sector %d not due at any deadline", sectorNum)
 }
 
+// A partition with sectors to be proven in a scheduled deadline window.
+type ScheduledPartition struct {
+	Index       uint64        // Index of the partition within its deadline
+	Sectors     *abi.BitField // Sectors to be proven: active sectors and declared recoveries
+	SectorCount uint64
+}
+
+// A deadline window in a projected proving schedule.
+type ScheduledDeadline struct {
+	Info        DeadlineInfo
+	Partitions  []ScheduledPartition // Partitions with at least one sector to prove
+	SectorCount uint64               // Total sectors to prove across the partitions
+}
+
+// Projects the next count deadline windows that have not elapsed as of the current epoch, in the order they open,
+// starting from the proving period start and current deadline recorded in state.
+// A window that is currently open is included.
+// The sectors to prove in each window reflect the partitions as they are now, excluding known faults and
+// including pending recoveries; windows in later proving periods assume no change.
+func ProvingSchedule(store adt.Store, periodStart abi.ChainEpoch, currentDeadline uint64, deadlines *Deadlines,
+	currEpoch abi.ChainEpoch, count int) ([]ScheduledDeadline, error) {
+	if currentDeadline >= WPoStPeriodDeadlines {
+		return nil, xerrors.Errorf("invalid current deadline %d", currentDeadline)
+	}
+	if count < 0 {
+		return nil, xerrors.Errorf("negative deadline count %d", count)
+	}
+
+	// Each deadline's partitions are loaded at most once, however many times it recurs in the schedule.
+	partitionsByDeadline := make(map[uint64][]ScheduledPartition)
+	schedule := make([]ScheduledDeadline, 0, count)
+	for dlInfo := NewDeadlineInfo(periodStart, currentDeadline, currEpoch); len(schedule) < count; dlInfo = nextDeadline(dlInfo) {
+		// The state may lag the current epoch if cron has not yet run for elapsed deadlines.
+		if dlInfo.HasElapsed() {
+			continue
+		}
+
+		partitions, ok := partitionsByDeadline[dlInfo.Index]
+		if !ok {
+			var err error
+			if partitions, err = scheduledPartitions(store, deadlines, dlInfo.Index); err != nil {
+				return nil, err
+			}
+			partitionsByDeadline[dlInfo.Index] = partitions
+		}
+
+		entry := ScheduledDeadline{Info: *dlInfo, Partitions: partitions}
+		for _, partition := range partitions {
+			entry.SectorCount += partition.SectorCount
+		}
+		schedule = append(schedule, entry)
+	}
+	return schedule, nil
+}
+
+// Returns the deadline window that opens when some deadline closes, advancing to the next proving period after
+// the last deadline.
+func nextDeadline(dlInfo *DeadlineInfo) *DeadlineInfo {
+	if dlInfo.Index+1 >= WPoStPeriodDeadlines {
+		return NewDeadlineInfo(dlInfo.NextPeriodStart(), 0, dlInfo.CurrentEpoch)
+	}
+	return NewDeadlineInfo(dlInfo.PeriodStart, dlInfo.Index+1, dlInfo.CurrentEpoch)
+}
+
+// Loads the partitions of a deadline that have sectors to be proven.
+func scheduledPartitions(store adt.Store, deadlines *Deadlines, dlIdx uint64) ([]ScheduledPartition, error) {
+	dl, err := deadlines.LoadDeadline(store, dlIdx)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := dl.PartitionsArray(store)
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduled []ScheduledPartition
+	var partition Partition
+	err = partitions.ForEach(&partition, func(i int64) error {
+		proving, err := partition.ProvingSectors()
+		if err != nil {
+			return err
+		}
+		count, err := proving.Count()
+		if err != nil {
+			return xerrors.Errorf("failed to count sectors in partition %d: %w", i, err)
+		}
+		if count > 0 {
+			scheduled = append(scheduled, ScheduledPartition{
+				Index:       uint64(i),
+				Sectors:     proving,
+				SectorCount: count,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load partitions of deadline %d: %w", dlIdx, err)
+	}
+	return scheduled, nil
+}
+
 // Returns true if the deadline at the given index is currently mutable.
 func deadlineIsMutable(provingPeriodStart abi.ChainEpoch, dlIdx uint64, currentEpoch abi.ChainEpoch) bool {
 	// Get the next non-elapsed deadline (i.e., the next time we care about
@@ -153,3 +254,12 @@ func deadlineIsMutable(provingPeriodStart abi.ChainEpoch, dlIdx uint64, currentE
 	// that deadline opens.
 	return currentEpoch < dlInfo.Open-WPoStChallengeWindow
 }
+
+// Returns true if proofs accepted without verification in the most recent instance of the deadline at the given
+// index may still be disputed.
+func deadlineInDisputeWindow(provingPeriodStart abi.ChainEpoch, dlIdx uint64, currentEpoch abi.ChainEpoch) bool {
+	dlInfo := NewDeadlineInfo(provingPeriodStart, dlIdx, currentEpoch).NextNotElapsed()
+	// The most recent instance closed one proving period before the next one closes.
+	lastClose := dlInfo.Close - WPoStProvingPeriod
+	return currentEpoch < lastClose+WPoStDisputeWindow
+}
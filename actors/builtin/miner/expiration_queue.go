@@ -451,6 +451,20 @@ func (q ExpirationQueue) RemoveSectors(sectors []*SectorOnChainInfo, faults *abi
 	return removed, recoveringPower, nil
 }
 
+// Replaces the numbers of all sectors in the queue, according to a mapping from old to new numbers.
+// Every sector in the queue must be mapped. Pledge and power are unchanged.
+func (q ExpirationQueue) RenumberSectors(renumbered map[uint64]uint64) error {
+	return q.traverseMutate(func(epoch abi.ChainEpoch, es *ExpirationSet) (changed, keepGoing bool, err error) {
+		if es.OnTimeSectors, err = renumberBitField(es.OnTimeSectors, renumbered); err != nil {
+			return false, false, xerrors.Errorf("failed to renumber on-time sectors expiring at %d: %w", epoch, err)
+		}
+		if es.EarlySectors, err = renumberBitField(es.EarlySectors, renumbered); err != nil {
+			return false, false, xerrors.Errorf("failed to renumber early sectors expiring at %d: %w", epoch, err)
+		}
+		return true, true, nil
+	})
+}
+
 // Removes and aggregates entries from the queue up to and including some epoch.
 func (q ExpirationQueue) PopUntil(until abi.ChainEpoch) (*ExpirationSet, error) {
 	var onTimeSectors []*abi.BitField
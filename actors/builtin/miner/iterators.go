@@ -0,0 +1,145 @@
+package miner
+
+import (
+	"errors"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+)
+
+// The iterators here visit items in increasing order from a cursor, calling a function for each.
+// The function returns false to halt iteration early, after which the item is considered visited.
+// Each iterator returns the cursor from which a subsequent call resumes, and whether iteration completed,
+// so that large collections can be served a page at a time without loading them in full.
+
+// Sentinel error used to halt AMT iteration, never returned to callers.
+var errHaltIteration = errors.New("halt iteration")
+
+// Iterates sectors with numbers in [from, until), in increasing order of sector number.
+// Returns the cursor following the last sector visited, and whether all sectors in the range were visited.
+// The pointer provided to the callback is not safe for re-use. Copy the pointed-to value in full to hold a reference.
+func (st *State) IterateSectors(store adt.Store, from, until abi.SectorNumber,
+	f func(*SectorOnChainInfo) (bool, error)) (next abi.SectorNumber, done bool, err error) {
+	sectors, err := adt.AsArray(store, st.Sectors)
+	if err != nil {
+		return from, false, xerrors.Errorf("failed to load sectors: %w", err)
+	}
+
+	next = from
+	halted := false
+	var sector SectorOnChainInfo
+	err = sectors.ForEachFrom(&sector, uint64(from), func(i int64) error {
+		if abi.SectorNumber(i) >= until {
+			return errHaltIteration
+		}
+		next = abi.SectorNumber(i) + 1
+		if cont, err := f(&sector); err != nil {
+			return err
+		} else if !cont {
+			halted = true
+			return errHaltIteration
+		}
+		return nil
+	})
+	if err != nil && err != errHaltIteration {
+		return from, false, xerrors.Errorf("failed to iterate sectors from %d: %w", from, err)
+	}
+	if halted {
+		return next, false, nil
+	}
+	return until, true, nil
+}
+
+// Iterates the sectors with numbers in some set that are at least from, in increasing order of sector number.
+// Returns an error if a sector in the set is missing from the sector array.
+// Returns the cursor following the last sector visited, and whether all sectors in the set were visited.
+func (st *State) IterateSectorsIn(store adt.Store, sectorNos *abi.BitField, from abi.SectorNumber,
+	f func(*SectorOnChainInfo) (bool, error)) (next abi.SectorNumber, done bool, err error) {
+	sectors, err := adt.AsArray(store, st.Sectors)
+	if err != nil {
+		return from, false, xerrors.Errorf("failed to load sectors: %w", err)
+	}
+
+	runs, err := sectorNos.RunIterator()
+	if err != nil {
+		return from, false, xerrors.Errorf("failed to iterate sector numbers: %w", err)
+	}
+	next = from
+	for pos := uint64(0); runs.HasNext(); {
+		run, err := runs.NextRun()
+		if err != nil {
+			return from, false, xerrors.Errorf("failed to iterate sector numbers: %w", err)
+		}
+		start, end := pos, pos+run.Len
+		pos = end
+		if !run.Val || end <= uint64(next) {
+			continue
+		}
+		if start < uint64(next) {
+			start = uint64(next)
+		}
+		for i := start; i < end; i++ {
+			var sector SectorOnChainInfo
+			found, err := sectors.Get(i, &sector)
+			if err != nil {
+				return from, false, xerrors.Errorf("failed to load sector %d: %w", i, err)
+			} else if !found {
+				return from, false, xerrors.Errorf("can't find sector %d", i)
+			}
+			next = abi.SectorNumber(i) + 1
+			if cont, err := f(&sector); err != nil {
+				return from, false, err
+			} else if !cont {
+				return next, false, nil
+			}
+		}
+	}
+	return next, true, nil
+}
+
+// Iterates the partitions of a deadline with index at least from, in increasing order.
+// Returns the cursor following the last partition visited, and whether all partitions were visited.
+// The pointer provided to the callback is not safe for re-use. Copy the pointed-to value in full to hold a reference.
+func (d *Deadline) IteratePartitions(store adt.Store, from uint64,
+	f func(partIdx uint64, partition *Partition) (bool, error)) (next uint64, done bool, err error) {
+	partitions, err := d.PartitionsArray(store)
+	if err != nil {
+		return from, false, err
+	}
+
+	next = from
+	var partition Partition
+	err = partitions.ForEachFrom(&partition, from, func(i int64) error {
+		next = uint64(i) + 1
+		if cont, err := f(uint64(i), &partition); err != nil {
+			return err
+		} else if !cont {
+			return errHaltIteration
+		}
+		return nil
+	})
+	if err == errHaltIteration {
+		return next, false, nil
+	} else if err != nil {
+		return from, false, xerrors.Errorf("failed to iterate partitions from %d: %w", from, err)
+	}
+	return next, true, nil
+}
+
+// Loads up to limit sectors with numbers in [from, until).
+// Returns the cursor from which to load the next page, and whether the range is exhausted.
+// A page that fills the limit may be followed by an empty final page.
+func (st *State) LoadSectorsPage(store adt.Store, from, until abi.SectorNumber, limit uint64) ([]*SectorOnChainInfo, abi.SectorNumber, bool, error) {
+	if limit == 0 {
+		return nil, from, false, xerrors.Errorf("page limit must be positive")
+	}
+	var page []*SectorOnChainInfo
+	next, done, err := st.IterateSectors(store, from, until, func(sector *SectorOnChainInfo) (bool, error) {
+		copied := *sector
+		page = append(page, &copied)
+		return uint64(len(page)) < limit, nil
+	})
+	return page, next, done, err
+}
@@ -0,0 +1,140 @@
+package miner_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	tutils "github.com/filecoin-project/specs-actors/support/testing"
+)
+
+func TestSectorIterators(t *testing.T) {
+	setup := func(t *testing.T, sectorNos ...abi.SectorNumber) *stateHarness {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+		for _, sectorNo := range sectorNos {
+			harness.putSector(newSectorOnChainInfo(sectorNo, tutils.MakeCID(fmt.Sprintf("%d", sectorNo), &miner.SealedCIDPrefix), big.Zero(), abi.ChainEpoch(1)))
+		}
+		return harness
+	}
+
+	// Collects the sector numbers visited, halting after max.
+	collect := func(visited *[]abi.SectorNumber, max int) func(*miner.SectorOnChainInfo) (bool, error) {
+		return func(sector *miner.SectorOnChainInfo) (bool, error) {
+			*visited = append(*visited, sector.SectorNumber)
+			return len(*visited) < max, nil
+		}
+	}
+
+	t.Run("iterates sectors in range", func(t *testing.T) {
+		harness := setup(t, 1, 3, 5, 7, 1000)
+
+		var visited []abi.SectorNumber
+		next, done, err := harness.s.IterateSectors(harness.store, 2, 7, collect(&visited, 10))
+		require.NoError(t, err)
+		assert.Equal(t, []abi.SectorNumber{3, 5}, visited)
+		assert.Equal(t, abi.SectorNumber(7), next)
+		assert.True(t, done)
+	})
+
+	t.Run("halts and resumes sector iteration", func(t *testing.T) {
+		harness := setup(t, 1, 3, 5, 7, 1000)
+
+		var visited []abi.SectorNumber
+		next, done, err := harness.s.IterateSectors(harness.store, 0, abi.MaxSectorNumber, collect(&visited, 2))
+		require.NoError(t, err)
+		assert.Equal(t, []abi.SectorNumber{1, 3}, visited)
+		assert.Equal(t, abi.SectorNumber(4), next)
+		assert.False(t, done)
+
+		visited = nil
+		next, done, err = harness.s.IterateSectors(harness.store, next, abi.MaxSectorNumber, collect(&visited, 10))
+		require.NoError(t, err)
+		assert.Equal(t, []abi.SectorNumber{5, 7, 1000}, visited)
+		assert.Equal(t, abi.SectorNumber(abi.MaxSectorNumber), next)
+		assert.True(t, done)
+	})
+
+	t.Run("loads sectors a page at a time", func(t *testing.T) {
+		harness := setup(t, 1, 3, 5, 7, 1000)
+
+		var pages [][]abi.SectorNumber
+		cursor, done := abi.SectorNumber(0), false
+		for !done {
+			var page []*miner.SectorOnChainInfo
+			var err error
+			page, cursor, done, err = harness.s.LoadSectorsPage(harness.store, cursor, abi.MaxSectorNumber, 2)
+			require.NoError(t, err)
+			var nos []abi.SectorNumber
+			for _, sector := range page {
+				nos = append(nos, sector.SectorNumber)
+			}
+			pages = append(pages, nos)
+		}
+		assert.Equal(t, [][]abi.SectorNumber{{1, 3}, {5, 7}, {1000}}, pages)
+
+		_, _, _, err := harness.s.LoadSectorsPage(harness.store, 0, abi.MaxSectorNumber, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("iterates sectors in bitfield", func(t *testing.T) {
+		harness := setup(t, 1, 3, 5, 7, 1000)
+
+		var visited []abi.SectorNumber
+		next, done, err := harness.s.IterateSectorsIn(harness.store, bf(1, 5, 7, 1000), 2, collect(&visited, 2))
+		require.NoError(t, err)
+		assert.Equal(t, []abi.SectorNumber{5, 7}, visited)
+		assert.Equal(t, abi.SectorNumber(8), next)
+		assert.False(t, done)
+
+		visited = nil
+		_, done, err = harness.s.IterateSectorsIn(harness.store, bf(1, 5, 7, 1000), next, collect(&visited, 10))
+		require.NoError(t, err)
+		assert.Equal(t, []abi.SectorNumber{1000}, visited)
+		assert.True(t, done)
+
+		_, _, err = harness.s.IterateSectorsIn(harness.store, bf(2), 0, collect(&visited, 10))
+		assert.Error(t, err)
+	})
+
+	t.Run("halts and resumes partition iteration", func(t *testing.T) {
+		harness := setup(t)
+		emptyArray, err := adt.MakeEmptyArray(harness.store).Root()
+		require.NoError(t, err)
+
+		// Five sectors in partitions of two.
+		var sectors []*miner.SectorOnChainInfo
+		for i := 0; i < 5; i++ {
+			sector := newSectorOnChainInfo(abi.SectorNumber(i), tutils.MakeCID(fmt.Sprintf("%d", i), &miner.SealedCIDPrefix), big.Zero(), abi.ChainEpoch(1))
+			sector.Expiration = 100
+			sectors = append(sectors, sector)
+		}
+		dl := miner.ConstructDeadline(emptyArray)
+		_, err = dl.AddSectors(harness.store, 2, sectors, abi.SectorSize(32<<30), miner.NoQuantization)
+		require.NoError(t, err)
+
+		var visited []uint64
+		visit := func(max int) func(uint64, *miner.Partition) (bool, error) {
+			return func(partIdx uint64, partition *miner.Partition) (bool, error) {
+				visited = append(visited, partIdx)
+				return len(visited) < max, nil
+			}
+		}
+		next, done, err := dl.IteratePartitions(harness.store, 0, visit(2))
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{0, 1}, visited)
+		assert.Equal(t, uint64(2), next)
+		assert.False(t, done)
+
+		next, done, err = dl.IteratePartitions(harness.store, next, visit(10))
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{0, 1, 2}, visited)
+		assert.Equal(t, uint64(3), next)
+		assert.True(t, done)
+	})
+}
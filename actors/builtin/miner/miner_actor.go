@@ -9,6 +9,7 @@ import (
 	"github.com/filecoin-project/go-bitfield"
 	cid "github.com/ipfs/go-cid"
 	cbg "github.com/whyrusleeping/cbor-gen"
+	xerrors "golang.org/x/xerrors"
 
 	abi "github.com/filecoin-project/specs-actors/actors/abi"
 	big "github.com/filecoin-project/specs-actors/actors/abi/big"
@@ -29,7 +30,7 @@ type CronEventType int64
 
 const (
 	CronEventWorkerKeyChange CronEventType = iota
-	CronEventPreCommitExpiry
+	CronEventPreCommitExpiry               // No longer enrolled; pre-commit expirations are queued in state.
 	CronEventProvingDeadline
 	CronEventProcessEarlyTerminations
 )
@@ -68,6 +69,15 @@ func (a Actor) Exports() []interface{} {
 		17:                        a.ConfirmSectorProofsValid,
 		18:                        a.ChangeMultiaddrs,
 		19:                        a.CompactPartitions,
+		20:                        a.DisputeWindowedPoSt,
+		21:                        a.ProveReplicaUpdates,
+		22:                        a.MaskSectorNumbers,
+		23:                        a.MovePartitions,
+		24:                        a.CompactSectorNumbers,
+		25:                        a.ShutdownMiner,
+		26:                        a.ChangeBeneficiary,
+		27:                        a.GetBeneficiary,
+		28:                        a.ApplyRewards,
 	}
 }
 
@@ -102,6 +112,8 @@ func (a Actor) Constructor(rt Runtime, params *ConstructorParams) *adt.EmptyValu
 		rt.Abortf(exitcode.ErrIllegalState, "failed to construct initial state: %v", err)
 	}
 
+	emptyBitfieldCid := rt.Store().Put(abi.NewBitField())
+
 	emptyDeadline := ConstructDeadline(emptyArray)
 	emptyDeadlineCid := rt.Store().Put(emptyDeadline)
 
@@ -118,7 +130,7 @@ func (a Actor) Constructor(rt Runtime, params *ConstructorParams) *adt.EmptyValu
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to construct initial miner info")
 	infoCid := rt.Store().Put(info)
 
-	state, err := ConstructState(infoCid, periodStart, emptyArray, emptyMap, emptyDeadlinesCid)
+	state, err := ConstructState(infoCid, periodStart, emptyBitfieldCid, emptyArray, emptyMap, emptyDeadlinesCid)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to construct state")
 	rt.State().Create(state)
 
@@ -221,6 +233,107 @@ func (a Actor) ChangeMultiaddrs(rt Runtime, params *ChangeMultiaddrsParams) *adt
 	return nil
 }
 
+type ChangeBeneficiaryParams struct {
+	NewBeneficiary addr.Address
+	NewQuota       abi.TokenAmount
+	NewExpiration  abi.ChainEpoch
+}
+
+// Proposes or approves a change of the account to which withdrawn funds are paid.
+// The owner proposes a new beneficiary, quota and expiration, replacing any pending proposal. The change takes
+// effect once the nominee has accepted it by calling this method with the same parameters, and, if the current
+// beneficiary is not the owner and its term has not expired, once the current beneficiary has also approved it.
+// The owner may propose itself as beneficiary, with zero quota and expiration, to revert to the default.
+func (a Actor) ChangeBeneficiary(rt Runtime, params *ChangeBeneficiaryParams) *adt.EmptyValue {
+	nominee := resolveOwnerAddress(rt, params.NewBeneficiary)
+	currEpoch := rt.CurrEpoch()
+
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		info := getMinerInfo(rt, &st)
+		rt.ValidateImmediateCallerIs(info.Owner, info.Beneficiary, nominee)
+		caller := rt.Message().Caller()
+
+		if caller == info.Owner {
+			if nominee == info.Owner {
+				if !params.NewQuota.IsZero() || params.NewExpiration != 0 {
+					rt.Abortf(exitcode.ErrIllegalArgument, "owner as beneficiary must have zero quota and expiration, was %v, %d",
+						params.NewQuota, params.NewExpiration)
+				}
+			} else {
+				if params.NewQuota.LessThanEqual(big.Zero()) {
+					rt.Abortf(exitcode.ErrIllegalArgument, "beneficiary quota %v must be positive", params.NewQuota)
+				}
+				if params.NewExpiration <= currEpoch {
+					rt.Abortf(exitcode.ErrIllegalArgument, "beneficiary expiration %d must be after now (%d)", params.NewExpiration, currEpoch)
+				}
+			}
+
+			// This may replace another pending change.
+			info.PendingBeneficiaryTerm = &PendingBeneficiaryChange{
+				NewBeneficiary:        nominee,
+				NewQuota:              params.NewQuota,
+				NewExpiration:         params.NewExpiration,
+				ApprovedByBeneficiary: false,
+				ApprovedByNominee:     nominee == info.Owner,
+			}
+		} else {
+			pending := info.PendingBeneficiaryTerm
+			if pending == nil {
+				rt.Abortf(exitcode.ErrForbidden, "no pending beneficiary change to approve")
+			}
+			if pending.NewBeneficiary != nominee || !pending.NewQuota.Equals(params.NewQuota) || pending.NewExpiration != params.NewExpiration {
+				rt.Abortf(exitcode.ErrIllegalArgument, "parameters do not match pending beneficiary change to %v, quota %v, expiration %d",
+					pending.NewBeneficiary, pending.NewQuota, pending.NewExpiration)
+			}
+			// The caller may be both the current beneficiary and the nominee.
+			if caller == pending.NewBeneficiary {
+				pending.ApprovedByNominee = true
+			}
+			if caller == info.Beneficiary {
+				pending.ApprovedByBeneficiary = true
+			}
+		}
+
+		pending := info.PendingBeneficiaryTerm
+		currentTermEnded := info.Beneficiary == info.Owner || currEpoch >= info.BeneficiaryTerm.Expiration
+		if pending.ApprovedByNominee && (pending.ApprovedByBeneficiary || currentTermEnded) {
+			info.Beneficiary = pending.NewBeneficiary
+			info.BeneficiaryTerm = BeneficiaryTerm{
+				Quota:      pending.NewQuota,
+				UsedQuota:  big.Zero(),
+				Expiration: pending.NewExpiration,
+			}
+			info.PendingBeneficiaryTerm = nil
+			rt.Log(vmr.INFO, "changed beneficiary to %v with quota %v until %d", info.Beneficiary,
+				info.BeneficiaryTerm.Quota, info.BeneficiaryTerm.Expiration)
+		}
+
+		err := st.SaveInfo(adt.AsStore(rt), info)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "could not save miner info")
+		return nil
+	})
+	return nil
+}
+
+type GetBeneficiaryReturn struct {
+	Beneficiary addr.Address
+	Term        BeneficiaryTerm
+	Proposed    *PendingBeneficiaryChange
+}
+
+func (a Actor) GetBeneficiary(rt Runtime, _ *adt.EmptyValue) *GetBeneficiaryReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.State().Readonly(&st)
+	info := getMinerInfo(rt, &st)
+	return &GetBeneficiaryReturn{
+		Beneficiary: info.Beneficiary,
+		Term:        info.BeneficiaryTerm,
+		Proposed:    info.PendingBeneficiaryTerm,
+	}
+}
+
 //////////////////
 // WindowedPoSt //
 //////////////////
@@ -255,7 +368,7 @@ func (a Actor) SubmitWindowedPoSt(rt Runtime, params *SubmitWindowedPoStParams)
 	// TODO: limit the length of proofs array https://github.com/filecoin-project/specs-actors/issues/416
 
 	// Get the total power/reward. We need these to compute penalties.
-	epochReward := requestCurrentEpochBlockReward(rt)
+	rewardStats := requestCurrentEpochBlockReward(rt)
 	pwrTotal := requestCurrentTotalPower(rt)
 
 	newFaultPowerTotal := NewPowerPairZero()
@@ -354,33 +467,53 @@ func (a Actor) SubmitWindowedPoSt(rt Runtime, params *SubmitWindowedPoStParams)
 		allIgnoredNos, err := bitfield.MultiMerge(allIgnored...)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to merge ignored sectors bitfields")
 
-		// Load sector infos for proof, substituting a known-good sector for known-faulty sectors.
-		// Note: this is slightly sub-optimal, loading info for the recovering sectors again after they were already
-		// loaded above.
-		sectorInfos, err := st.LoadSectorInfosForProof(store, allSectorNos, allIgnoredNos)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proven sector info")
+		if recoveredPowerTotal.IsZero() {
+			// With no power being restored, accept the proof optimistically without verifying it.
+			// The proof may be disputed for WPoStDisputeWindow epochs after the deadline closes.
+			// Skip recording if all sectors are faults, since there is nothing to dispute.
+			// We still need to allow this call to succeed so the miner can declare a whole partition as skipped.
+			provenSectorNos, err := bitfield.SubtractBitField(allSectorNos, allIgnoredNos)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to subtract ignored sectors")
+			noneProven, err := provenSectorNos.IsEmpty()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check if proven sectors is empty")
+			if !noneProven {
+				sectorInfos, err := st.LoadSectorInfosForProof(store, allSectorNos, allIgnoredNos)
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proven sector info")
+
+				err = deadline.RecordOptimisticPoSt(store, &WindowedPoSt{
+					Partitions:  bitfield.NewFromSet(partitionIdxs),
+					SectorInfos: sectorProofInfos(sectorInfos),
+					Proofs:      params.Proofs,
+				})
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record proof for dispute")
+			}
+		} else {
+			// Recovered power is restored immediately, so the proof must be verified before it is accepted.
+			// Load sector infos for proof, substituting a known-good sector for known-faulty sectors.
+			// Note: this is slightly sub-optimal, loading info for the recovering sectors again after they were already
+			// loaded above.
+			sectorInfos, err := st.LoadSectorInfosForProof(store, allSectorNos, allIgnoredNos)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proven sector info")
 
-		// Skip verification if all sectors are faults.
-		// We still need to allow this call to succeed so the miner can declare a whole partition as skipped.
-		if len(sectorInfos) > 0 {
 			// Verify the proof.
 			// A failed verification doesn't immediately cause a penalty; the miner can try again.
-			verifyWindowedPost(rt, currDeadline.Challenge, sectorInfos, params.Proofs)
+			err = verifyWindowedPost(rt, currDeadline.Challenge, sectorProofInfos(sectorInfos), params.Proofs)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "window post failed")
 		}
 
 		// Penalize new skipped faults and retracted recoveries as undeclared faults.
 		// These pay a higher fee than faults declared before the deadline challenge window opened.
 		undeclaredPenaltyPower := newFaultPowerTotal.Add(retractedRecoveryPowerTotal)
-		undeclaredPenaltyTarget := PledgePenaltyForUndeclaredFault(epochReward, pwrTotal.QualityAdjPower, undeclaredPenaltyPower.QA)
+		undeclaredPenaltyTarget := PledgePenaltyForUndeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, undeclaredPenaltyPower.QA)
 		// Subtract the "ongoing" fault fee from the amount charged now, since it will be charged at
 		// the end-of-deadline cron.
-		undeclaredPenaltyTarget = big.Sub(undeclaredPenaltyTarget, PledgePenaltyForDeclaredFault(epochReward, pwrTotal.QualityAdjPower, undeclaredPenaltyPower.QA))
+		undeclaredPenaltyTarget = big.Sub(undeclaredPenaltyTarget, PledgePenaltyForDeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, undeclaredPenaltyPower.QA))
 
 		// Penalize recoveries as declared faults (a lower fee than the undeclared, above).
 		// It sounds odd, but because faults are penalized in arrears, at the _end_ of the faulty period, we must
 		// penalize recovered sectors here because they won't be penalized by the end-of-deadline cron for the
 		// immediately-prior faulty period.
-		declaredPenaltyTarget := PledgePenaltyForDeclaredFault(epochReward, pwrTotal.QualityAdjPower, recoveredPowerTotal.QA)
+		declaredPenaltyTarget := PledgePenaltyForDeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, recoveredPowerTotal.QA)
 
 		// Note: We could delay this charge until end of deadline, but that would require more accounting state.
 		totalPenaltyTarget := big.Add(undeclaredPenaltyTarget, declaredPenaltyTarget)
@@ -413,6 +546,132 @@ func (a Actor) SubmitWindowedPoSt(rt Runtime, params *SubmitWindowedPoStParams)
 	return nil
 }
 
+type DisputeWindowedPoStParams struct {
+	Deadline  uint64
+	PoStIndex uint64 // Index of the proof in the deadline's optimistic proofs snapshot.
+}
+
+// Disputes a Window PoSt that was accepted without verification in the most recent instance of a deadline.
+// A proof may be disputed by any party until WPoStDisputeWindow epochs after the deadline's challenge window closes.
+// If the proof fails to verify, the partitions it claimed to prove are marked faulty, the miner is penalized,
+// and the disputer is rewarded out of the penalty.
+func (a Actor) DisputeWindowedPoSt(rt Runtime, params *DisputeWindowedPoStParams) *adt.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	reporter := rt.Message().Caller()
+
+	if params.Deadline >= WPoStPeriodDeadlines {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid deadline %d of %d", params.Deadline, WPoStPeriodDeadlines)
+	}
+
+	currEpoch := rt.CurrEpoch()
+	store := adt.AsStore(rt)
+
+	// Get the total power/reward. We need these to compute penalties.
+	rewardStats := requestCurrentEpochBlockReward(rt)
+	pwrTotal := requestCurrentTotalPower(rt)
+
+	powerDelta := NewPowerPairZero()
+	penaltyTotal := abi.NewTokenAmount(0)
+
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		// Find the most recent instance of the deadline to have been processed at its close.
+		// That is either in the current proving period or, if not yet reached, in the previous one.
+		targetPeriodStart := st.ProvingPeriodStart
+		if params.Deadline >= st.CurrentDeadline {
+			targetPeriodStart -= WPoStProvingPeriod
+		}
+		targetDeadline := NewDeadlineInfo(targetPeriodStart, params.Deadline, currEpoch)
+		if currEpoch >= targetDeadline.Close+WPoStDisputeWindow {
+			rt.Abortf(exitcode.ErrForbidden, "dispute window for deadline %d closed at %d",
+				params.Deadline, targetDeadline.Close+WPoStDisputeWindow)
+		}
+
+		deadlines, err := st.LoadDeadlines(store)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadlines")
+
+		deadline, err := deadlines.LoadDeadline(store, params.Deadline)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadline %d", params.Deadline)
+
+		// Remove the proof from the snapshot so it can't be disputed again.
+		post, found, err := deadline.TakeOptimisticPoStSnapshot(store, params.PoStIndex)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proof for dispute")
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "no proof %d to dispute at deadline %d", params.PoStIndex, params.Deadline)
+		}
+
+		// Verify the proof against the sectors recorded at submission, which remain valid even if some
+		// have since been terminated or removed.
+		if err := verifyWindowedPost(rt, targetDeadline.Challenge, post.SectorInfos, post.Proofs); err == nil {
+			rt.Abortf(exitcode.ErrIllegalArgument, "failed to dispute valid post")
+		}
+
+		// The proof is invalid, so mark all power in the proven partitions faulty, as for a missed PoSt.
+		partitions, err := deadline.PartitionsArray(store)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load partitions for deadline %d", params.Deadline)
+
+		faultExpiration := targetDeadline.Close + FaultMaxAge
+		quant := st.QuantEndOfDeadline()
+		penalizePowerTotal := big.Zero()
+
+		err = post.Partitions.ForEach(func(partIdx uint64) error {
+			key := PartitionKey{params.Deadline, partIdx}
+			var partition Partition
+			found, err := partitions.Get(partIdx, &partition)
+			if err != nil {
+				return xerrors.Errorf("failed to load partition %v: %w", key, err)
+			} else if !found {
+				return xerrors.Errorf("no partition %v", key)
+			}
+
+			newFaultPower, failedRecoveryPower, err := partition.RecordMissedPost(store, faultExpiration, quant)
+			if err != nil {
+				return xerrors.Errorf("failed to record disputed PoSt for %v: %w", key, err)
+			}
+
+			st.FaultyPower = st.FaultyPower.Add(newFaultPower)
+			powerDelta = powerDelta.Sub(newFaultPower)
+			penalizePowerTotal = big.Sum(penalizePowerTotal, newFaultPower.QA, failedRecoveryPower.QA)
+
+			return partitions.Set(partIdx, &partition)
+		})
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to fault disputed partitions")
+
+		// Penalize the newly faulty power as undeclared faults, less the "ongoing" fault fee that will be charged
+		// at the end-of-deadline cron, plus a fixed penalty for the invalid proof.
+		penaltyTarget := PledgePenaltyForUndeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, penalizePowerTotal)
+		penaltyTarget = big.Sub(penaltyTarget, PledgePenaltyForDeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, penalizePowerTotal))
+		penaltyTarget = big.Add(penaltyTarget, BasePenaltyForDisputedWindowPoSt)
+		penaltyTotal, err = st.UnlockUnvestedFunds(store, currEpoch, penaltyTarget)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to unlock penalty")
+
+		// Save everything back.
+		deadline.Partitions, err = partitions.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store partitions")
+
+		err = deadlines.UpdateDeadline(store, params.Deadline, deadline)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update deadline %d", params.Deadline)
+
+		err = st.SaveDeadlines(store, deadlines)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save deadlines")
+
+		return nil
+	})
+
+	// Remove power for the new faults.
+	requestUpdatePower(rt, powerDelta)
+
+	// Reward the disputer out of the penalty, and burn the remainder.
+	disputerReward := big.Min(BaseRewardForDisputedWindowPoSt, penaltyTotal)
+	if disputerReward.GreaterThan(big.Zero()) {
+		_, code := rt.Send(reporter, builtin.MethodSend, nil, disputerReward)
+		builtin.RequireSuccess(rt, code, "failed to reward disputer")
+	}
+	burnFunds(rt, big.Sub(penaltyTotal, disputerReward))
+	notifyPledgeChanged(rt, penaltyTotal.Neg())
+	return nil
+}
+
 ///////////////////////
 // Sector Commitment //
 ///////////////////////
@@ -456,9 +715,13 @@ func (a Actor) PreCommitSector(rt Runtime, params *SectorPreCommitInfo) *adt.Emp
 	if params.ReplaceSectorNumber >= abi.MaxSectorNumber {
 		rt.Abortf(exitcode.ErrIllegalArgument, "invalid sector number %d", params.ReplaceSectorNumber)
 	}
+	msd, ok := MaxSealDuration[params.SealProof]
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "no max seal duration set for proof type: %d", params.SealProof)
+	}
 
 	// gather information from other actors
-	baselinePower, epochReward := requestCurrentEpochBaselinePowerAndReward(rt)
+	rewardStats := requestCurrentEpochBlockReward(rt)
 	pwrTotal := requestCurrentTotalPower(rt)
 	dealWeight := requestDealWeight(rt, params.DealIDs, rt.CurrEpoch(), params.Expiration)
 	circulatingSupply := rt.TotalFilCircSupply()
@@ -468,6 +731,9 @@ func (a Actor) PreCommitSector(rt Runtime, params *SectorPreCommitInfo) *adt.Emp
 	newlyVestedAmount := rt.State().Transaction(&st, func() interface{} {
 		info := getMinerInfo(rt, &st)
 		rt.ValidateImmediateCallerIs(info.Worker)
+		if st.ShuttingDown() {
+			rt.Abortf(exitcode.ErrForbidden, "cannot pre-commit sectors while shutting down")
+		}
 		if params.SealProof != info.SealProofType {
 			rt.Abortf(exitcode.ErrIllegalArgument, "sector seal proof %v must match miner seal proof type %d", params.SealProof, info.SealProofType)
 		}
@@ -489,6 +755,13 @@ func (a Actor) PreCommitSector(rt Runtime, params *SectorPreCommitInfo) *adt.Emp
 			rt.Abortf(exitcode.ErrIllegalArgument, "sector %v already committed", params.SectorNumber)
 		}
 
+		// Sector numbers are never reused, even after the sector has expired or been terminated.
+		allocated, err := st.AllocateSectorNumber(store, params.SectorNumber)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to allocate sector number %v", params.SectorNumber)
+		if !allocated {
+			rt.Abortf(exitcode.ErrIllegalArgument, "sector number %v already allocated", params.SectorNumber)
+		}
+
 		validateExpiration(rt, rt.CurrEpoch(), params.Expiration, params.SealProof)
 
 		depositMinimum := big.Zero()
@@ -505,7 +778,7 @@ func (a Actor) PreCommitSector(rt Runtime, params *SectorPreCommitInfo) *adt.Emp
 
 		sectorWeight := QAPowerForWeight(info.SectorSize, duration, dealWeight.DealWeight, dealWeight.VerifiedDealWeight)
 		depositReq := big.Max(
-			precommitDeposit(sectorWeight, pwrTotal.QualityAdjPower, baselinePower, pwrTotal.PledgeCollateral, epochReward, circulatingSupply),
+			PreCommitDepositForPower(sectorWeight, pwrTotal.QualityAdjPowerSmoothed, rewardStats.ThisEpochBaselinePower, pwrTotal.PledgeCollateral, rewardStats.ThisEpochRewardSmoothed, circulatingSupply),
 			depositMinimum,
 		)
 		if availableBalance.LessThan(depositReq) {
@@ -525,31 +798,18 @@ func (a Actor) PreCommitSector(rt Runtime, params *SectorPreCommitInfo) *adt.Emp
 			rt.Abortf(exitcode.ErrIllegalState, "failed to write pre-committed sector %v: %v", params.SectorNumber, err)
 		}
 
+		// Add precommit expiry to the queue, processed at deadline cron.
+		// The +1 here is critical for the batch verification of proofs. Without it, if a proof arrived exactly on the
+		// due epoch, ProveCommitSector would accept it, then the expiry would remove it, and then
+		// ConfirmSectorProofsValid would fail to find it.
+		expiryBound := rt.CurrEpoch() + msd + 1
+		err = st.AddPreCommitExpiry(store, expiryBound, params.SectorNumber)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add pre-commit expiry to queue")
+
 		return newlyVestedFund
 	}).(abi.TokenAmount)
 
 	notifyPledgeChanged(rt, newlyVestedAmount.Neg())
-
-	bf := abi.NewBitField()
-	bf.Set(uint64(params.SectorNumber))
-
-	// Request deferred Cron check for PreCommit expiry check.
-	cronPayload := CronEventPayload{
-		EventType: CronEventPreCommitExpiry,
-		Sectors:   bf,
-	}
-
-	msd, ok := MaxSealDuration[params.SealProof]
-	if !ok {
-		rt.Abortf(exitcode.ErrIllegalArgument, "no max seal duration set for proof type: %d", params.SealProof)
-	}
-
-	// The +1 here is critical for the batch verification of proofs. Without it, if a proof arrived exactly on the
-	// due epoch, ProveCommitSector would accept it, then the expiry event would remove it, and then
-	// ConfirmSectorProofsValid would fail to find it.
-	expiryBound := rt.CurrEpoch() + msd + 1
-	enrollCronEvent(rt, expiryBound, &cronPayload)
-
 	return nil
 }
 
@@ -696,6 +956,7 @@ func (a Actor) ConfirmSectorProofsValid(rt Runtime, params *builtin.ConfirmSecto
 			totalPledge = big.Add(totalPledge, initialPledge)
 			newSectorInfo := SectorOnChainInfo{
 				SectorNumber:       precommit.Info.SectorNumber,
+				SealedSectorNumber: precommit.Info.SectorNumber,
 				SealProof:          precommit.Info.SealProof,
 				SealedCID:          precommit.Info.SealedCID,
 				DealIDs:            precommit.Info.DealIDs,
@@ -748,6 +1009,220 @@ func (a Actor) ConfirmSectorProofsValid(rt Runtime, params *builtin.ConfirmSecto
 	return nil
 }
 
+type ReplicaUpdate struct {
+	SectorNumber   abi.SectorNumber
+	Deadline       uint64
+	Partition      uint64
+	NewSealedCID   cid.Cid `checked:"true"` // CommR
+	NewUnsealedCID cid.Cid `checked:"true"` // CommD, checked against the deals
+	Deals          []abi.DealID
+	ReplicaProof   []byte
+}
+
+type ProveReplicaUpdatesParams struct {
+	Updates []ReplicaUpdate
+}
+
+// Upgrades committed-capacity sectors to hold deals without resealing them.
+// Each sector keeps its number and partition, but its sealed CID, deals and activation epoch are replaced,
+// and its power and pledge are recomputed for the new deals.
+// The sectors must be healthy and their deadlines must be mutable and not open to Window PoSt disputes.
+func (a Actor) ProveReplicaUpdates(rt Runtime, params *ProveReplicaUpdatesParams) *adt.EmptyValue {
+	if uint64(len(params.Updates)) > ProveReplicaUpdatesMaxSize {
+		rt.Abortf(exitcode.ErrIllegalArgument, "too many updates %d, max %d", len(params.Updates), ProveReplicaUpdatesMaxSize)
+	}
+
+	currEpoch := rt.CurrEpoch()
+	store := adt.AsStore(rt)
+
+	var st State
+	rt.State().Readonly(&st)
+	info := getMinerInfo(rt, &st)
+	rt.ValidateImmediateCallerIs(info.Worker)
+
+	// Validate the updates against the current sectors before consulting other actors.
+	oldSectors := make([]*SectorOnChainInfo, len(params.Updates))
+	seen := map[abi.SectorNumber]struct{}{}
+	for i, update := range params.Updates {
+		if _, ok := seen[update.SectorNumber]; ok {
+			rt.Abortf(exitcode.ErrIllegalArgument, "duplicate update for sector %d", update.SectorNumber)
+		}
+		seen[update.SectorNumber] = struct{}{}
+
+		if update.Deadline >= WPoStPeriodDeadlines {
+			rt.Abortf(exitcode.ErrIllegalArgument, "invalid deadline %d", update.Deadline)
+		}
+		if len(update.Deals) == 0 {
+			rt.Abortf(exitcode.ErrIllegalArgument, "cannot update sector %d without deals", update.SectorNumber)
+		}
+		if uint64(len(update.Deals)) > dealPerSectorLimit(info.SectorSize) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "too many deals for sector %d > %d", len(update.Deals), dealPerSectorLimit(info.SectorSize))
+		}
+		if !update.NewSealedCID.Defined() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "sealed CID undefined")
+		}
+		if update.NewSealedCID.Prefix() != SealedCIDPrefix {
+			rt.Abortf(exitcode.ErrIllegalArgument, "sealed CID had wrong prefix")
+		}
+		if !deadlineIsMutable(st.ProvingPeriodStart, update.Deadline, currEpoch) {
+			rt.Abortf(exitcode.ErrForbidden, "cannot update sector %d in immutable deadline %d", update.SectorNumber, update.Deadline)
+		}
+		// The proofs for the deadline must no longer be disputable, since a dispute would check them
+		// against the new sealed CID.
+		if deadlineInDisputeWindow(st.ProvingPeriodStart, update.Deadline, currEpoch) {
+			rt.Abortf(exitcode.ErrForbidden, "cannot update sector %d while deadline %d is open to disputes", update.SectorNumber, update.Deadline)
+		}
+
+		sector, found, err := st.GetSector(store, update.SectorNumber)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load sector %d", update.SectorNumber)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "no such sector %d to update", update.SectorNumber)
+		}
+		if len(sector.DealIDs) > 0 {
+			rt.Abortf(exitcode.ErrIllegalArgument, "cannot update sector %d which already has deals", update.SectorNumber)
+		}
+
+		status, err := st.SectorStatus(store, update.Deadline, update.Partition, update.SectorNumber)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check sector health %d", update.SectorNumber)
+		switch status {
+		case SectorNotFound:
+			rt.Abortf(exitcode.ErrIllegalArgument, "sector %d not found at %d:%d (deadline:partition)",
+				update.SectorNumber, update.Deadline, update.Partition)
+		case SectorFaulty:
+			rt.Abortf(exitcode.ErrIllegalArgument, "cannot update faulty sector %d", update.SectorNumber)
+		case SectorTerminated:
+			rt.Abortf(exitcode.ErrIllegalArgument, "cannot update terminated sector %d", update.SectorNumber)
+		case SectorHealthy:
+			// pass
+		default:
+			panic(fmt.Sprintf("unexpected sector status %d", status))
+		}
+
+		oldSectors[i] = sector
+	}
+
+	// gather information from other actors
+	rewardStats := requestCurrentEpochBlockReward(rt)
+	pwrTotal := requestCurrentTotalPower(rt)
+	circulatingSupply := rt.TotalFilCircSupply()
+
+	newSectors := make([]*SectorOnChainInfo, len(params.Updates))
+	for i, update := range params.Updates {
+		oldSector := oldSectors[i]
+
+		dealWeight := requestDealWeight(rt, update.Deals, currEpoch, oldSector.Expiration)
+
+		unsealedCID := requestUnsealedSectorCID(rt, oldSector.SealProof, update.Deals)
+		if !unsealedCID.Equals(update.NewUnsealedCID) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "unsealed CID %v for sector %d does not match deals, expected %v",
+				update.NewUnsealedCID, update.SectorNumber, unsealedCID)
+		}
+
+		err := rt.Syscalls().VerifyReplicaUpdate(abi.ReplicaUpdateInfo{
+			UpdateProofType:      oldSector.SealProof,
+			OldSealedSectorCID:   oldSector.SealedCID,
+			NewSealedSectorCID:   update.NewSealedCID,
+			NewUnsealedSectorCID: update.NewUnsealedCID,
+			Proof:                update.ReplicaProof,
+		})
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "invalid replica update proof for sector %d", update.SectorNumber)
+
+		_, code := rt.Send(
+			builtin.StorageMarketActorAddr,
+			builtin.MethodsMarket.ActivateDeals,
+			&market.ActivateDealsParams{
+				DealIDs:      update.Deals,
+				SectorExpiry: oldSector.Expiration,
+			},
+			abi.NewTokenAmount(0),
+		)
+		builtin.RequireSuccess(rt, code, "failed to activate deals for sector %d", update.SectorNumber)
+
+		// The sector's power is recomputed from the new deals over its remaining lifetime.
+		// Its activation, and so its age for the purposes of termination fees and lifetime limits, is unchanged.
+		newSector := *oldSector
+		newSector.SealedCID = update.NewSealedCID
+		newSector.DealIDs = update.Deals
+		newSector.ReplicaUpdateEpoch = currEpoch
+		newSector.DealWeight = dealWeight.DealWeight
+		newSector.VerifiedDealWeight = dealWeight.VerifiedDealWeight
+
+		// The pledge requirement may increase with the sector's power, but never decreases.
+		sectorWeight := QAPowerForSector(info.SectorSize, &newSector)
+		newSector.InitialPledge = big.Max(
+			InitialPledgeForPower(sectorWeight, pwrTotal.QualityAdjPowerSmoothed, rewardStats.ThisEpochBaselinePower, pwrTotal.PledgeCollateral, rewardStats.ThisEpochRewardSmoothed, circulatingSupply),
+			oldSector.InitialPledge,
+		)
+		newSectors[i] = &newSector
+	}
+
+	powerDelta := NewPowerPairZero()
+	pledgeDelta := big.Zero()
+	newlyVestedAmount := rt.State().Transaction(&st, func() interface{} {
+		deadlines, err := st.LoadDeadlines(store)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadlines")
+		quant := st.QuantEndOfDeadline()
+
+		for i, update := range params.Updates {
+			key := PartitionKey{update.Deadline, update.Partition}
+			deadline, err := deadlines.LoadDeadline(store, update.Deadline)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadline %d", update.Deadline)
+
+			partitions, err := deadline.PartitionsArray(store)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load partitions for deadline %d", update.Deadline)
+
+			var partition Partition
+			found, err := partitions.Get(update.Partition, &partition)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load partition %v", key)
+			if !found {
+				rt.Abortf(exitcode.ErrNotFound, "no such partition %v", key)
+			}
+
+			// Remove the old sector from the partition and add the new one, with the same number and expiration.
+			sectorPowerDelta, sectorPledgeDelta, err := partition.ReplaceSectors(store,
+				[]*SectorOnChainInfo{oldSectors[i]}, []*SectorOnChainInfo{newSectors[i]}, info.SectorSize, quant)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to replace sector at %v", key)
+			powerDelta = powerDelta.Add(sectorPowerDelta)
+			pledgeDelta = big.Add(pledgeDelta, sectorPledgeDelta)
+
+			err = partitions.Set(update.Partition, &partition)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save partition %v", key)
+
+			deadline.Partitions, err = partitions.Root()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save partitions for deadline %d", update.Deadline)
+
+			err = deadlines.UpdateDeadline(store, update.Deadline, deadline)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save deadline %d", update.Deadline)
+		}
+
+		err = st.SaveDeadlines(store, deadlines)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save deadlines")
+
+		err = st.PutSectors(store, newSectors...)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update sectors")
+
+		newlyVestedFund, err := st.UnlockVestedFunds(store, currEpoch)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to vest funds")
+
+		// Lock up any additional initial pledge for the new power.
+		st.AddInitialPledgeRequirement(pledgeDelta)
+		availableBalance := st.GetAvailableBalance(rt.CurrentBalance())
+		if availableBalance.LessThan(pledgeDelta) {
+			rt.Abortf(exitcode.ErrInsufficientFunds, "insufficient funds for additional initial pledge requirement %s, available: %s", pledgeDelta, availableBalance)
+		}
+		if err := st.AddLockedFunds(store, currEpoch, pledgeDelta, &PledgeVestingSpec); err != nil {
+			rt.Abortf(exitcode.ErrIllegalState, "failed to add additional pledge: %v", err)
+		}
+		st.AssertBalanceInvariants(rt.CurrentBalance())
+
+		return newlyVestedFund
+	}).(abi.TokenAmount)
+
+	requestUpdatePower(rt, powerDelta)
+	notifyPledgeChanged(rt, big.Sub(pledgeDelta, newlyVestedAmount))
+	return nil
+}
+
 type CheckSectorProvenParams struct {
 	SectorNumber abi.SectorNumber
 }
@@ -916,6 +1391,37 @@ type TerminateSectorsReturn struct {
 	// terminations. While pending terminations are outstanding, the miner
 	// will not be able to withdraw funds.
 	Done bool
+	// Details the early terminations processed by this call, which may include
+	// sectors terminated by earlier calls.
+	Report TerminationReport
+	// The claimed power removed for the sectors newly terminated by this call.
+	PowerRemoved PowerPair
+}
+
+// Summarises a batch of early terminations processed from the queue.
+type TerminationReport struct {
+	Sectors []SectorTerminationReport
+	// The total penalty burnt. This may be less than the sum of the per-sector penalties
+	// if the miner's locked funds could not cover them.
+	PenaltyCharged abi.TokenAmount
+	// The total initial pledge requirement released.
+	PledgeReleased abi.TokenAmount
+	// The total power of the terminated sectors.
+	Power PowerPair
+}
+
+type SectorTerminationReport struct {
+	SectorNumber abi.SectorNumber
+	// The epoch at which the sector was terminated.
+	Epoch abi.ChainEpoch
+	// The termination penalty computed for the sector.
+	Penalty abi.TokenAmount
+	// The initial pledge requirement released for the sector.
+	Pledge abi.TokenAmount
+	// The sector's power, which is no longer claimed.
+	Power PowerPair
+	// The sector's deals, which are terminated along with it.
+	Deals []abi.DealID
 }
 
 // Marks some sectors as terminated at the present epoch, earlier than their
@@ -999,40 +1505,30 @@ func (a Actor) TerminateSectors(rt Runtime, params *TerminateSectorsParams) *Ter
 			partitions, err := deadline.PartitionsArray(store)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load partitions for deadline %d", dlIdx)
 
+			partitionSectors := map[uint64][]*SectorOnChainInfo{}
 			for _, decl := range declsByDeadline[dlIdx] {
-				key := PartitionKey{dlIdx, decl.Partition}
-				var partition Partition
-				found, err := partitions.Get(decl.Partition, &partition)
-				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load partition %v", key)
-				if !found {
-					rt.Abortf(exitcode.ErrNotFound, "no such partition %v", key)
+				if decl.Partition >= partitions.Length() {
+					rt.Abortf(exitcode.ErrNotFound, "no such partition %v", PartitionKey{dlIdx, decl.Partition})
 				}
-
 				sectors, err := st.LoadSectorInfos(store, decl.Sectors)
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load sectors")
-
-				// Note: we could inspect each sector's expiration epoch here and kindly ignore any that
-				// have already expired but just not been processed yet.
-
-				// Remove  sectors from partition.
-				// The sectors infos are not mutated; their on-time expiration epoch remains in state until defrag.
-				pwr, err := partition.TerminateSectors(store, currEpoch, sectors, info.SectorSize, quant)
-				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to replaces sector expirations at %v", key)
-
-				err = partitions.Set(decl.Partition, &partition)
-				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store updated partition", key)
-
-				// Record that partition now has pending early terminations.
-				deadline.EarlyTerminations.Set(decl.Partition)
-
-				powerDelta = powerDelta.Sub(pwr)
+				partitionSectors[decl.Partition] = append(partitionSectors[decl.Partition], sectors...)
 			}
 
-			deadline.Partitions, err = partitions.Root()
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store partitions")
+			// Note: we could inspect each sector's expiration epoch here and kindly ignore any that
+			// have already expired but just not been processed yet.
+
+			// Remove sectors from partitions.
+			// The sectors infos are not mutated; their on-time expiration epoch remains in state until defrag.
+			removed, _, err := deadline.TerminateSectors(store, currEpoch, partitionSectors, info.SectorSize, quant)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to terminate sectors in deadline %d", dlIdx)
+			powerDelta = powerDelta.Sub(removed)
 
 			err = deadlines.UpdateDeadline(store, dlIdx, deadline)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update deadline %d", dlIdx)
+
+			// Record that deadline now has pending early terminations.
+			st.EarlyTerminations.Set(dlIdx)
 		}
 
 		err = st.SaveDeadlines(store, deadlines)
@@ -1042,7 +1538,8 @@ func (a Actor) TerminateSectors(rt Runtime, params *TerminateSectorsParams) *Ter
 	})
 
 	// Now, try to process these sectors.
-	more := processEarlyTerminations(rt)
+	// Every processed sector is listed in the return value, so this is limited to what can be encoded.
+	report, more := processEarlyTerminations(rt, min64(AddressedSectorsMax, cbg.MaxLength))
 	if more && !hadEarlyTerminations {
 		// We have remaining terminations, and we didn't _previously_
 		// have early terminations to process, schedule a cron job.
@@ -1059,7 +1556,11 @@ func (a Actor) TerminateSectors(rt Runtime, params *TerminateSectorsParams) *Ter
 	// We could charge at least the undeclared fault fee here, which is a lower bound on the penalty.
 	// https://github.com/filecoin-project/specs-actors/issues/674
 
-	return &TerminateSectorsReturn{Done: !more}
+	return &TerminateSectorsReturn{
+		Done:         !more,
+		Report:       report,
+		PowerRemoved: powerDelta.Neg(),
+	}
 }
 
 ////////////
@@ -1367,27 +1868,233 @@ func (a Actor) CompactPartitions(rt Runtime, params *CompactPartitionsParams) *a
 	return nil
 }
 
-///////////////////////
-// Pledge Collateral //
-///////////////////////
+type MovePartitionsParams struct {
+	OrigDeadline uint64
+	DestDeadline uint64
+	Partitions   *abi.BitField
+}
 
-// Locks up some amount of a the miner's unlocked balance (including any received alongside the invoking message).
-func (a Actor) AddLockedFund(rt Runtime, amountToLock *abi.TokenAmount) *adt.EmptyValue {
-	if amountToLock.Sign() < 0 {
-		rt.Abortf(exitcode.ErrIllegalArgument, "cannot lock up a negative amount of funds")
+// Moves whole partitions from one deadline to another, e.g. to rebalance proving work across the proving period.
+// Both deadlines must be mutable. The partitions must have been proven at the original deadline in the current
+// proving period, and that deadline's proofs must no longer be disputable, since the remaining partitions are
+// re-indexed. The destination's next challenge window must open no later than the original deadline's next window.
+// The moved partitions are appended to the destination deadline with their faults, recoveries, expirations and
+// pending early terminations intact, and are next due for proof at that deadline.
+// Expirations scheduled for the original deadline are processed at the next close of the destination deadline.
+func (a Actor) MovePartitions(rt Runtime, params *MovePartitionsParams) *adt.EmptyValue {
+	if params.OrigDeadline >= WPoStPeriodDeadlines {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid original deadline %d", params.OrigDeadline)
+	}
+	if params.DestDeadline >= WPoStPeriodDeadlines {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid destination deadline %d", params.DestDeadline)
+	}
+	if params.OrigDeadline == params.DestDeadline {
+		rt.Abortf(exitcode.ErrIllegalArgument, "cannot move partitions within deadline %d", params.OrigDeadline)
+	}
+	partitionCount, err := params.Partitions.Count()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to count partitions")
+	if partitionCount == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "no partitions to move")
 	}
 
+	currEpoch := rt.CurrEpoch()
 	store := adt.AsStore(rt)
 	var st State
-	newlyVested := rt.State().Transaction(&st, func() interface{} {
+	rt.State().Transaction(&st, func() interface{} {
 		info := getMinerInfo(rt, &st)
-		rt.ValidateImmediateCallerIs(info.Worker, info.Owner, builtin.RewardActorAddr)
+		rt.ValidateImmediateCallerIs(info.Worker)
 
-		newlyVestedFund, err := st.UnlockVestedFunds(store, rt.CurrEpoch())
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to vest funds")
+		partitionLimit := loadPartitionsSectorsMax(info.WindowPoStPartitionSectors)
+		if partitionCount > partitionLimit {
+			rt.Abortf(exitcode.ErrIllegalArgument, "too many partitions %d, limit %d", partitionCount, partitionLimit)
+		}
 
-		availableBalance := st.GetAvailableBalance(rt.CurrentBalance())
-		if availableBalance.LessThan(*amountToLock) {
+		if !deadlineIsMutable(st.ProvingPeriodStart, params.OrigDeadline, currEpoch) {
+			rt.Abortf(exitcode.ErrForbidden, "cannot move partitions from immutable deadline %d", params.OrigDeadline)
+		}
+		if !deadlineIsMutable(st.ProvingPeriodStart, params.DestDeadline, currEpoch) {
+			rt.Abortf(exitcode.ErrForbidden, "cannot move partitions to immutable deadline %d", params.DestDeadline)
+		}
+		// The partitions must have been proven at the original deadline this period, so they can't skip a proof.
+		if !st.DeadlineInfo(currEpoch).PeriodStarted() || params.OrigDeadline >= st.CurrentDeadline {
+			rt.Abortf(exitcode.ErrForbidden, "cannot move partitions from deadline %d before it is proven this period", params.OrigDeadline)
+		}
+		if deadlineInDisputeWindow(st.ProvingPeriodStart, params.OrigDeadline, currEpoch) {
+			rt.Abortf(exitcode.ErrForbidden, "cannot move partitions from deadline %d while it is open to disputes", params.OrigDeadline)
+		}
+		// Nor may moving them delay their next proof beyond when it would have been due at the original deadline.
+		origNext := NewDeadlineInfo(st.ProvingPeriodStart, params.OrigDeadline, currEpoch).NextNotElapsed()
+		destNext := NewDeadlineInfo(st.ProvingPeriodStart, params.DestDeadline, currEpoch).NextNotElapsed()
+		if destNext.Open > origNext.Open {
+			rt.Abortf(exitcode.ErrForbidden, "cannot move partitions from deadline %d, next due at %d, to deadline %d, next due at %d",
+				params.OrigDeadline, origNext.Open, params.DestDeadline, destNext.Open)
+		}
+
+		quant := st.QuantEndOfDeadline()
+		deadlines, err := st.LoadDeadlines(store)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadlines")
+
+		origDeadline, err := deadlines.LoadDeadline(store, params.OrigDeadline)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadline %d", params.OrigDeadline)
+		destDeadline, err := deadlines.LoadDeadline(store, params.DestDeadline)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadline %d", params.DestDeadline)
+
+		moved, err := origDeadline.RemovePartitions(store, params.Partitions, quant)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to remove partitions from deadline %d", params.OrigDeadline)
+
+		err = destDeadline.AppendPartitions(store, moved, quant)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add partitions to deadline %d", params.DestDeadline)
+
+		err = deadlines.UpdateDeadline(store, params.OrigDeadline, origDeadline)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update deadline %d", params.OrigDeadline)
+		err = deadlines.UpdateDeadline(store, params.DestDeadline, destDeadline)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update deadline %d", params.DestDeadline)
+
+		err = st.SaveDeadlines(store, deadlines)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save deadlines")
+
+		// Pending early terminations move with their partitions.
+		if empty, err := origDeadline.EarlyTerminations.IsEmpty(); err != nil {
+			rt.Abortf(exitcode.ErrIllegalState, "failed to count early terminations: %v", err)
+		} else if empty {
+			st.EarlyTerminations.Unset(params.OrigDeadline)
+		}
+		if empty, err := destDeadline.EarlyTerminations.IsEmpty(); err != nil {
+			rt.Abortf(exitcode.ErrIllegalState, "failed to count early terminations: %v", err)
+		} else if !empty {
+			st.EarlyTerminations.Set(params.DestDeadline)
+		}
+		return nil
+	})
+	return nil
+}
+
+type MaskSectorNumbersParams struct {
+	Mask *abi.BitField
+}
+
+// Marks a set of sector numbers as allocated, so that they may never be pre-committed.
+// This allows the owner to reserve ranges of numbers, e.g. those used by an off-chain system, or skip over numbers
+// so that later sectors are allocated from a compact range.
+func (a Actor) MaskSectorNumbers(rt Runtime, params *MaskSectorNumbersParams) *adt.EmptyValue {
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		info := getMinerInfo(rt, &st)
+		rt.ValidateImmediateCallerIs(info.Owner)
+
+		lastSectorNo, err := lastBitSet(params.Mask)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "invalid mask bitfield")
+		if lastSectorNo > abi.MaxSectorNumber {
+			rt.Abortf(exitcode.ErrIllegalArgument, "masked sector number %d exceeds maximum %d", lastSectorNo, abi.MaxSectorNumber)
+		}
+
+		err = st.MaskSectorNumbers(adt.AsStore(rt), params.Mask)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to mask sector numbers")
+		return nil
+	})
+	return nil
+}
+
+type CompactSectorNumbersParams struct {
+	Deadline          uint64
+	Partition         uint64
+	FirstSectorNumber abi.SectorNumber
+}
+
+// Assigns the live sectors of a partition new, consecutive sector numbers starting at FirstSectorNumber, and drops
+// the partition's terminated sectors, so that long-lived partitions are represented by compact bitfields.
+// The new numbers must never have been allocated; the old numbers remain allocated and are never reused.
+// Renumbered sectors continue to be proven with the number they were sealed with.
+func (a Actor) CompactSectorNumbers(rt Runtime, params *CompactSectorNumbersParams) *adt.EmptyValue {
+	if params.Deadline >= WPoStPeriodDeadlines {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid deadline %d", params.Deadline)
+	}
+	if params.FirstSectorNumber > abi.MaxSectorNumber {
+		rt.Abortf(exitcode.ErrIllegalArgument, "sector number %d exceeds maximum %d", params.FirstSectorNumber, abi.MaxSectorNumber)
+	}
+
+	currEpoch := rt.CurrEpoch()
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		info := getMinerInfo(rt, &st)
+		rt.ValidateImmediateCallerIs(info.Owner)
+
+		if !deadlineIsMutable(st.ProvingPeriodStart, params.Deadline, currEpoch) {
+			rt.Abortf(exitcode.ErrForbidden, "cannot renumber sectors in immutable deadline %d", params.Deadline)
+		}
+		// Disputable proofs identify the sectors they proved by number.
+		if deadlineInDisputeWindow(st.ProvingPeriodStart, params.Deadline, currEpoch) {
+			rt.Abortf(exitcode.ErrForbidden, "cannot renumber sectors in deadline %d while it is open to disputes", params.Deadline)
+		}
+
+		count, err := st.RenumberSectors(adt.AsStore(rt), params.Deadline, params.Partition, params.FirstSectorNumber)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to renumber sectors in deadline %d partition %d",
+			params.Deadline, params.Partition)
+		rt.Log(vmr.INFO, "renumbered %d sectors in deadline %d partition %d from %d",
+			count, params.Deadline, params.Partition, params.FirstSectorNumber)
+		return nil
+	})
+	return nil
+}
+
+// Terminates all of the miner's remaining sectors, charging the usual termination penalties, and begins shutting
+// the miner down. Once the early terminations are processed and all locked funds have vested, the miner's power
+// claim is removed and the actor deleted, releasing its remaining balance to the owner.
+// The miner must have no pre-committed sectors.
+// Up to AddressedSectorsMax sectors are terminated immediately, and the remainder in batches by cron.
+func (a Actor) ShutdownMiner(rt Runtime, _ *adt.EmptyValue) *adt.EmptyValue {
+	var hadEarlyTerminations bool
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		hadEarlyTerminations = havePendingEarlyTerminations(rt, &st)
+
+		info := getMinerInfo(rt, &st)
+		rt.ValidateImmediateCallerIs(info.Owner)
+
+		if st.ShuttingDown() {
+			rt.Abortf(exitcode.ErrForbidden, "miner already shutting down since epoch %d", st.ShutdownEpoch)
+		}
+		if !st.PreCommitDeposits.IsZero() {
+			rt.Abortf(exitcode.ErrForbidden, "cannot shut down with pre-committed sectors (deposits %v)", st.PreCommitDeposits)
+		}
+
+		st.ShutdownEpoch = rt.CurrEpoch()
+		return nil
+	})
+
+	more := terminateLiveSectors(rt, AddressedSectorsMax)
+
+	// Charge the termination penalties now if possible, otherwise in cron, which also terminates any sectors remaining.
+	if _, morePenalties := processEarlyTerminations(rt, AddressedSectorsMax); (more || morePenalties) && !hadEarlyTerminations {
+		scheduleEarlyTerminationWork(rt)
+	}
+
+	completeShutdownIfReady(rt)
+	return nil
+}
+
+///////////////////////
+// Pledge Collateral //
+///////////////////////
+
+// Locks up some amount of a the miner's unlocked balance (including any received alongside the invoking message).
+// Block rewards are locked by the reward actor through ApplyRewards instead.
+func (a Actor) AddLockedFund(rt Runtime, amountToLock *abi.TokenAmount) *adt.EmptyValue {
+	if amountToLock.Sign() < 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "cannot lock up a negative amount of funds")
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	newlyVested := rt.State().Transaction(&st, func() interface{} {
+		info := getMinerInfo(rt, &st)
+		rt.ValidateImmediateCallerIs(info.Worker, info.Owner)
+
+		newlyVestedFund, err := st.UnlockVestedFunds(store, rt.CurrEpoch())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to vest funds")
+
+		availableBalance := st.GetAvailableBalance(rt.CurrentBalance())
+		if availableBalance.LessThan(*amountToLock) {
 			rt.Abortf(exitcode.ErrInsufficientFunds, "insufficient funds to lock, available: %v, requested: %v", availableBalance, *amountToLock)
 		}
 
@@ -1401,15 +2108,71 @@ func (a Actor) AddLockedFund(rt Runtime, amountToLock *abi.TokenAmount) *adt.Emp
 	return nil
 }
 
+// Receives a block reward, sent as the message value by the reward actor, and any penalty for the block.
+// The penalty is added to the miner's fee debt, which is repaid from the reward first. A fraction of what remains
+// of the reward is locked to vest on its own schedule, and the rest becomes available. Any debt the reward
+// doesn't cover is repaid from the miner's other funds as far as possible.
+func (a Actor) ApplyRewards(rt Runtime, params *builtin.ApplyRewardParams) *builtin.ApplyRewardsReturn {
+	rt.ValidateImmediateCallerIs(builtin.RewardActorAddr)
+	if params.Reward.LessThan(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "cannot apply a negative reward: %v", params.Reward)
+	}
+	if params.Penalty.LessThan(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "cannot apply a negative penalty: %v", params.Penalty)
+	}
+
+	rewardToLock := big.Zero()
+	pledgeDelta := big.Zero()
+	toBurn := big.Zero()
+	store := adt.AsStore(rt)
+	currEpoch := rt.CurrEpoch()
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		newlyVested, err := st.UnlockVestedFunds(store, currEpoch)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to vest funds")
+
+		err = st.ApplyPenalty(params.Penalty)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
+
+		// Fee debt is repaid from the reward before any of it is locked.
+		// The reward arrives with this message, so the unlocked balance always covers the remainder to lock.
+		fromReward := big.Min(params.Reward, st.FeeDebt)
+		st.FeeDebt = big.Sub(st.FeeDebt, fromReward)
+		rewardToLock = LockedRewardFromReward(big.Sub(params.Reward, fromReward))
+		err = st.AddLockedFunds(store, currEpoch, rewardToLock, &LockedRewardVestingSpec)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to lock reward")
+
+		// Any debt the reward didn't cover is repaid from the miner's other funds.
+		fromVesting, fromBalance, err := st.RepayDebts(store, currEpoch, big.Sub(rt.CurrentBalance(), fromReward))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to repay fee debt")
+		pledgeDelta = big.Sub(big.Sub(rewardToLock, newlyVested), fromVesting)
+		toBurn = big.Sum(fromReward, fromVesting, fromBalance)
+
+		st.AssertBalanceInvariants(big.Sub(rt.CurrentBalance(), toBurn))
+		return nil
+	})
+
+	notifyPledgeChanged(rt, pledgeDelta)
+	burnFunds(rt, toBurn)
+	return &builtin.ApplyRewardsReturn{
+		Locked:     rewardToLock,
+		DebtRepaid: toBurn,
+	}
+}
+
 type ReportConsensusFaultParams struct {
 	BlockHeader1     []byte
 	BlockHeader2     []byte
 	BlockHeaderExtra []byte
 }
 
+// Penalizes a miner for a verified consensus fault, rewarding the reporter with a share of the miner's balance.
+// The miner is terminated: its deals are terminated, its claim is removed from the power actor, and its remaining
+// funds are burnt. The end of the fault's election ineligibility period is recorded in MinerInfo.ConsensusFaultElapsed,
+// and a report of a fault committed before the end of a recorded period is rejected.
 func (a Actor) ReportConsensusFault(rt Runtime, params *ReportConsensusFaultParams) *adt.EmptyValue {
 	// Note: only the first reporter of any fault is rewarded.
-	// Subsequent invocations fail because the target miner has been removed.
+	// Subsequent invocations fail because the fault has been recorded and the target miner removed.
 	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
 	reporter := rt.Message().Caller()
 
@@ -1419,19 +2182,31 @@ func (a Actor) ReportConsensusFault(rt Runtime, params *ReportConsensusFaultPara
 	}
 
 	// Elapsed since the fault (i.e. since the higher of the two blocks)
-	faultAge := rt.CurrEpoch() - fault.Epoch
+	currEpoch := rt.CurrEpoch()
+	faultAge := currEpoch - fault.Epoch
 	if faultAge <= 0 {
-		rt.Abortf(exitcode.ErrIllegalArgument, "invalid fault epoch %v ahead of current %v", fault.Epoch, rt.CurrEpoch())
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid fault epoch %v ahead of current %v", fault.Epoch, currEpoch)
 	}
 
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		info := getMinerInfo(rt, &st)
+		if fault.Epoch <= info.ConsensusFaultElapsed {
+			rt.Abortf(exitcode.ErrForbidden, "fault epoch %d already covered by a consensus fault ineligible until %d",
+				fault.Epoch, info.ConsensusFaultElapsed)
+		}
+
+		info.ConsensusFaultElapsed = currEpoch + ConsensusFaultIneligibilityDuration
+		err = st.SaveInfo(adt.AsStore(rt), info)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "could not save miner info")
+		return nil
+	})
+
 	// Reward reporter with a share of the miner's current balance.
 	slasherReward := RewardForConsensusSlashReport(faultAge, rt.CurrentBalance())
 	_, code := rt.Send(reporter, builtin.MethodSend, nil, slasherReward)
 	builtin.RequireSuccess(rt, code, "failed to reward reporter")
 
-	var st State
-	rt.State().Readonly(&st)
-
 	// Notify power actor with lock-up total being removed.
 	_, code = rt.Send(
 		builtin.StoragePowerActorAddr,
@@ -1451,15 +2226,18 @@ type WithdrawBalanceParams struct {
 	AmountRequested abi.TokenAmount
 }
 
+// Withdraws available balance to the beneficiary, which is the owner unless another beneficiary has been set.
+// A beneficiary other than the owner may withdraw only within its quota, and until its term expires.
 func (a Actor) WithdrawBalance(rt Runtime, params *WithdrawBalanceParams) *adt.EmptyValue {
 	var st State
 	if params.AmountRequested.LessThan(big.Zero()) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "negative fund requested for withdrawal: %s", params.AmountRequested)
 	}
 	var info *MinerInfo
+	var amountWithdrawn abi.TokenAmount
 	newlyVestedAmount := rt.State().Transaction(&st, func() interface{} {
 		info = getMinerInfo(rt, &st)
-		rt.ValidateImmediateCallerIs(info.Owner)
+		rt.ValidateImmediateCallerIs(info.Owner, info.Beneficiary)
 		// Ensure we don't have any pending terminations.
 		if count, err := st.EarlyTerminations.Count(); err != nil {
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to count early terminations")
@@ -1480,14 +2258,25 @@ func (a Actor) WithdrawBalance(rt Runtime, params *WithdrawBalanceParams) *adt.E
 		// TODO: simplify this just to refuse to vest if pledge requirement is unmet https://github.com/filecoin-project/specs-actors/issues/537
 		verifyPledgeMeetsInitialRequirements(rt, &st)
 
+		amountWithdrawn = big.Min(st.GetAvailableBalance(rt.CurrentBalance()), params.AmountRequested)
+		if info.Beneficiary != info.Owner {
+			remainingQuota := info.BeneficiaryTerm.Available(rt.CurrEpoch())
+			if remainingQuota.IsZero() {
+				rt.Abortf(exitcode.ErrForbidden, "beneficiary %v term expired at %d or quota %v used",
+					info.Beneficiary, info.BeneficiaryTerm.Expiration, info.BeneficiaryTerm.Quota)
+			}
+			amountWithdrawn = big.Min(amountWithdrawn, remainingQuota)
+			info.BeneficiaryTerm.UsedQuota = big.Add(info.BeneficiaryTerm.UsedQuota, amountWithdrawn)
+			err = st.SaveInfo(adt.AsStore(rt), info)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "could not save miner info")
+		}
+
 		return newlyVestedFund
 	}).(abi.TokenAmount)
 
-	currBalance := rt.CurrentBalance()
-	amountWithdrawn := big.Min(st.GetAvailableBalance(currBalance), params.AmountRequested)
-	Assert(amountWithdrawn.LessThanEqual(currBalance))
+	Assert(amountWithdrawn.LessThanEqual(rt.CurrentBalance()))
 
-	_, code := rt.Send(info.Owner, builtin.MethodSend, nil, amountWithdrawn)
+	_, code := rt.Send(info.Beneficiary, builtin.MethodSend, nil, amountWithdrawn)
 	builtin.RequireSuccess(rt, code, "failed to withdraw balance")
 
 	pledgeDelta := newlyVestedAmount.Neg()
@@ -1514,7 +2303,8 @@ func (a Actor) OnDeferredCronEvent(rt Runtime, payload *CronEventPayload) *adt.E
 	case CronEventWorkerKeyChange:
 		commitWorkerKeyChange(rt)
 	case CronEventProcessEarlyTerminations:
-		if processEarlyTerminations(rt) {
+		more := terminateLiveSectors(rt, AddressedSectorsMax)
+		if _, morePenalties := processEarlyTerminations(rt, AddressedSectorsMax); more || morePenalties {
 			scheduleEarlyTerminationWork(rt)
 		}
 	}
@@ -1526,26 +2316,30 @@ func (a Actor) OnDeferredCronEvent(rt Runtime, payload *CronEventPayload) *adt.E
 // Utility functions & helpers
 ////////////////////////////////////////////////////////////////////////////////
 
-func processEarlyTerminations(rt Runtime) (more bool) {
+// Processes up to AddressedPartitionsMax partitions and maxSectors sectors from the early termination queue,
+// paying penalties, releasing pledge and terminating deals, and reports what was done.
+func processEarlyTerminations(rt Runtime, maxSectors uint64) (report TerminationReport, more bool) {
 	store := adt.AsStore(rt)
 
 	// TODO: We're using the current power+epoch reward. Technically, we
 	// should use the power/reward at the time of termination.
 	// https://github.com/filecoin-project/specs-actors/pull/648
-	epochReward := requestCurrentEpochBlockReward(rt)
-	pwrTotal := requestCurrentTotalPower(rt).QualityAdjPower
+	rewardStats := requestCurrentEpochBlockReward(rt)
+	pwrTotal := requestCurrentTotalPower(rt).QualityAdjPowerSmoothed
 
 	var (
 		result           TerminationResult
 		dealsToTerminate []market.OnMinerSectorsTerminateParams
 		penalty          = big.Zero()
 		pledge           = big.Zero()
+		power            = NewPowerPairZero()
+		sectorReports    []SectorTerminationReport
 	)
 
 	var st State
 	rt.State().Transaction(&st, func() interface{} {
 		var err error
-		result, more, err = st.PopEarlyTerminations(store, AddressedPartitionsMax, AddressedSectorsMax)
+		result, more, err = st.PopEarlyTerminations(store, AddressedPartitionsMax, maxSectors)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to pop early terminations")
 
 		// Nothing to do, don't waste any time.
@@ -1558,6 +2352,7 @@ func processEarlyTerminations(rt Runtime) (more bool) {
 		info := getMinerInfo(rt, &st)
 
 		dealsToTerminate = make([]market.OnMinerSectorsTerminateParams, 0, len(result.Sectors))
+		sectorReports = make([]SectorTerminationReport, 0, result.SectorsProcessed)
 		err = result.ForEach(func(epoch abi.ChainEpoch, sectorNos *abi.BitField) error {
 			// Note: this loads the sectors array root multiple times, redundantly.
 			// In the grand scheme of data being loaded here, it's not a big deal.
@@ -1568,10 +2363,22 @@ func processEarlyTerminations(rt Runtime) (more bool) {
 				DealIDs: make([]abi.DealID, 0, len(sectors)), // estimate ~one deal per sector.
 			}
 			for _, sector := range sectors {
+				sectorPower := PowerForSector(info.SectorSize, sector)
+				sectorPenalty := PledgePenaltyForTermination(sector.InitialPledge, epoch-sector.Activation, rewardStats.ThisEpochRewardSmoothed, pwrTotal, sectorPower.QA)
+				sectorReports = append(sectorReports, SectorTerminationReport{
+					SectorNumber: sector.SectorNumber,
+					Epoch:        epoch,
+					Penalty:      sectorPenalty,
+					Pledge:       sector.InitialPledge,
+					Power:        sectorPower,
+					Deals:        sector.DealIDs,
+				})
+
 				params.DealIDs = append(params.DealIDs, sector.DealIDs...)
+				penalty = big.Add(penalty, sectorPenalty)
 				pledge = big.Add(pledge, sector.InitialPledge)
+				power = power.Add(sectorPower)
 			}
-			penalty = big.Add(penalty, terminationPenalty(info.SectorSize, epoch, epochReward, pwrTotal, sectors))
 			dealsToTerminate = append(dealsToTerminate, params)
 
 			return nil
@@ -1590,11 +2397,21 @@ func processEarlyTerminations(rt Runtime) (more bool) {
 		return nil
 	})
 
+	report = TerminationReport{
+		Sectors:        sectorReports,
+		PenaltyCharged: penalty,
+		PledgeReleased: pledge,
+		Power:          power,
+	}
+
 	// We didn't do anything, abort.
 	if result.IsEmpty() {
-		return more
+		return report, more
 	}
 
+	rt.Log(vmr.INFO, "processed early termination of %d sectors with power %v: penalty %v, pledge released %v",
+		len(sectorReports), power, penalty, pledge)
+
 	// Burn penalty.
 	burnFundsAndNotifyPledgeChange(rt, penalty)
 
@@ -1607,7 +2424,7 @@ func processEarlyTerminations(rt Runtime) (more bool) {
 	}
 
 	// reschedule cron worker, if necessary.
-	return more
+	return report, more
 }
 
 // Invoked at the end of the last epoch for each proving deadline.
@@ -1615,13 +2432,14 @@ func handleProvingDeadline(rt Runtime) {
 	currEpoch := rt.CurrEpoch()
 	store := adt.AsStore(rt)
 
-	epochReward := requestCurrentEpochBlockReward(rt)
+	rewardStats := requestCurrentEpochBlockReward(rt)
 	pwrTotal := requestCurrentTotalPower(rt)
 
 	hadEarlyTerminations := false
 
 	powerDelta := PowerPair{big.Zero(), big.Zero()}
 	newlyVested := big.Zero()
+	expiredDeposits := big.Zero()
 	penaltyTotal := abi.NewTokenAmount(0)
 	pledgeDelta := abi.NewTokenAmount(0)
 
@@ -1635,6 +2453,12 @@ func handleProvingDeadline(rt Runtime) {
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to vest funds")
 		}
 
+		{
+			// Remove pre-commitments that were not proven in time, forfeiting their deposits.
+			expiredDeposits, err = st.ExpirePreCommits(store, currEpoch)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to expire pre-commits")
+		}
+
 		// Record whether or not we _had_ early terminations in the queue before this method.
 		// That way, don't re-schedule a cron callback if one is already scheduled.
 		hadEarlyTerminations = havePendingEarlyTerminations(rt, &st)
@@ -1690,21 +2514,23 @@ func handleProvingDeadline(rt Runtime) {
 			}
 
 			// Unlock sector penalty for all undeclared faults.
-			penaltyTarget := PledgePenaltyForUndeclaredFault(epochReward, pwrTotal.QualityAdjPower, penalizePowerTotal)
+			penaltyTarget := PledgePenaltyForUndeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, penalizePowerTotal)
 			// Subtract the "ongoing" fault fee from the amount charged now, since it will be added on just below.
-			penaltyTarget = big.Sub(penaltyTarget, PledgePenaltyForDeclaredFault(epochReward, pwrTotal.QualityAdjPower, penalizePowerTotal))
+			penaltyTarget = big.Sub(penaltyTarget, PledgePenaltyForDeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, penalizePowerTotal))
 			penalty, err := st.UnlockUnvestedFunds(store, currEpoch, penaltyTarget)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to unlock penalty")
 			penaltyTotal = big.Add(penaltyTotal, penalty)
 
-			// Reset PoSt submissions.
+			// Reset PoSt submissions, and snapshot the proofs accepted without verification for dispute.
 			deadline.PostSubmissions = abi.NewBitField()
+			err = deadline.TakePoStSnapshot(store)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to snapshot proofs for deadline %d", dlInfo.Index)
 		}
 		{
 			// Record faulty power for penalisation of ongoing faults, before popping expirations.
 			// This includes any power that was just faulted from missing a PoSt.
 			faultyPower := st.FaultyPower.QA
-			penaltyTarget := PledgePenaltyForDeclaredFault(epochReward, pwrTotal.QualityAdjPower, faultyPower)
+			penaltyTarget := PledgePenaltyForDeclaredFault(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, faultyPower)
 			penalty, err := st.UnlockUnvestedFunds(store, currEpoch, penaltyTarget)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to unlock penalty")
 			penaltyTotal = big.Add(penaltyTotal, penalty)
@@ -1762,11 +2588,17 @@ func handleProvingDeadline(rt Runtime) {
 		return nil
 	})
 
-	// Remove power for new faults, and burn penalties.
+	// Remove power for new faults, and burn penalties and expired pre-commit deposits.
+	// Deposits were locked separately to pledge collateral so there's no pledge change for them.
 	requestUpdatePower(rt, powerDelta)
-	burnFunds(rt, penaltyTotal)
+	burnFunds(rt, big.Add(penaltyTotal, expiredDeposits))
 	notifyPledgeChanged(rt, big.Sum(newlyVested, penaltyTotal, pledgeDelta).Neg())
 
+	// A miner that has finished shutting down is deleted, and needs no further callbacks.
+	if completeShutdownIfReady(rt) {
+		return
+	}
+
 	// Schedule cron callback for next deadline's last epoch.
 	newDlInfo := st.DeadlineInfo(currEpoch)
 	enrollCronEvent(rt, newDlInfo.Last(), &CronEventPayload{
@@ -1780,7 +2612,7 @@ func handleProvingDeadline(rt Runtime) {
 	// handle them at the next epoch.
 	if !hadEarlyTerminations && hasEarlyTerminations {
 		// First, try to process some of these terminations.
-		if processEarlyTerminations(rt) {
+		if _, more := processEarlyTerminations(rt, AddressedSectorsMax); more {
 			// If that doesn't work, just defer till the next epoch.
 			scheduleEarlyTerminationWork(rt)
 		}
@@ -1904,44 +2736,14 @@ func validateReplaceSector(rt Runtime, st *State, store adt.Store, params *Secto
 	return replaceSector
 }
 
+// Processes an expiry event for pre-commitments enrolled before their expirations were queued in state.
 func checkPrecommitExpiry(rt Runtime, sectors *abi.BitField) {
-	store := adt.AsStore(rt)
 	var st State
-
-	// initialize here to add together for all sectors and minimize calls across actors
-	depositToBurn := abi.NewTokenAmount(0)
-	rt.State().Transaction(&st, func() interface{} {
-		var sectorNos []abi.SectorNumber
-		err := sectors.ForEach(func(i uint64) error {
-			sectorNo := abi.SectorNumber(i)
-			sector, found, err := st.GetPrecommittedSector(store, sectorNo)
-			if err != nil {
-				return err
-			}
-			if !found {
-				// already committed/deleted
-				return nil
-			}
-
-			// mark it for deletion
-			sectorNos = append(sectorNos, sectorNo)
-
-			// increment deposit to burn
-			depositToBurn = big.Add(depositToBurn, sector.PreCommitDeposit)
-			return nil
-		})
+	depositToBurn := rt.State().Transaction(&st, func() interface{} {
+		deposits, err := st.ForfeitPreCommits(adt.AsStore(rt), sectors)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check pre-commit expiries")
-
-		// Actually delete it.
-		if len(sectorNos) > 0 {
-			err = st.DeletePrecommittedSectors(store, sectorNos...)
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete pre-commits")
-		}
-
-		st.PreCommitDeposits = big.Sub(st.PreCommitDeposits, depositToBurn)
-		Assert(st.PreCommitDeposits.GreaterThanEqual(big.Zero()))
-		return nil
-	})
+		return deposits
+	}).(abi.TokenAmount)
 
 	// This deposit was locked separately to pledge collateral so there's no pledge change here.
 	burnFunds(rt, depositToBurn)
@@ -1981,6 +2783,20 @@ func requestUpdatePower(rt Runtime, delta PowerPair) {
 	builtin.RequireSuccess(rt, code, "failed to update power with %v", delta)
 }
 
+func requestTerminateAllDeals(rt Runtime, st *State) {
+	// TODO: red flag this is an ~unbounded computation.
+	// Transform into an idempotent partial computation that can be progressed on each invocation.
+	// https://github.com/filecoin-project/specs-actors/issues/675
+	dealIds := []abi.DealID{}
+	if err := st.ForEachSector(adt.AsStore(rt), func(sector *SectorOnChainInfo) {
+		dealIds = append(dealIds, sector.DealIDs...)
+	}); err != nil {
+		rt.Abortf(exitcode.ErrIllegalState, "failed to traverse sectors for termination: %v", err)
+	}
+
+	requestTerminateDeals(rt, rt.CurrEpoch(), dealIds)
+}
+
 func requestTerminateDeals(rt Runtime, epoch abi.ChainEpoch, dealIDs []abi.DealID) {
 	for len(dealIDs) > 0 {
 		size := min64(cbg.MaxLength, uint64(len(dealIDs)))
@@ -1994,28 +2810,172 @@ func requestTerminateDeals(rt Runtime, epoch abi.ChainEpoch, dealIDs []abi.DealI
 			abi.NewTokenAmount(0),
 		)
 		builtin.RequireSuccess(rt, code, "failed to terminate deals, exit code %v", code)
-		dealIDs = dealIDs[:size]
+		dealIDs = dealIDs[size:]
 	}
 }
 
-func requestTerminateAllDeals(rt Runtime, st *State) { //nolint:deadcode,unused
-	// TODO: red flag this is an ~unbounded computation.
-	// Transform into an idempotent partial computation that can be progressed on each invocation.
-	// https://github.com/filecoin-project/specs-actors/issues/675
-	dealIds := []abi.DealID{}
-	if err := st.ForEachSector(adt.AsStore(rt), func(sector *SectorOnChainInfo) {
-		dealIds = append(dealIds, sector.DealIDs...)
-	}); err != nil {
-		rt.Abortf(exitcode.ErrIllegalState, "failed to traverse sectors for termination: %v", err)
+func scheduleEarlyTerminationWork(rt Runtime) {
+	enrollCronEvent(rt, rt.CurrEpoch()+1, &CronEventPayload{
+		EventType: CronEventProcessEarlyTerminations,
+	})
+}
+
+// Terminates up to maxSectors of a shutting-down miner's live sectors, removing their power and queueing them
+// for early termination.
+// Returns whether live sectors remain.
+func terminateLiveSectors(rt Runtime, maxSectors uint64) (more bool) {
+	var st State
+	store := adt.AsStore(rt)
+	currEpoch := rt.CurrEpoch()
+	powerDelta := NewPowerPairZero()
+	var sectorCount uint64
+	rt.State().Transaction(&st, func() interface{} {
+		if !st.ShuttingDown() {
+			return nil
+		}
+		info := getMinerInfo(rt, &st)
+		deadlines, err := st.LoadDeadlines(store)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadlines")
+		quant := st.QuantEndOfDeadline()
+
+		for dlIdx := uint64(0); dlIdx < WPoStPeriodDeadlines; dlIdx++ {
+			deadline, err := deadlines.LoadDeadline(store, dlIdx)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadline %d", dlIdx)
+			if deadline.LiveSectors == 0 {
+				continue
+			}
+			if sectorCount == maxSectors {
+				more = true
+				break
+			}
+
+			partitions, err := deadline.PartitionsArray(store)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load partitions for deadline %d", dlIdx)
+
+			partitionSectors := map[uint64][]*SectorOnChainInfo{}
+			for partIdx := uint64(0); partIdx < partitions.Length() && sectorCount < maxSectors; partIdx++ {
+				key := PartitionKey{dlIdx, partIdx}
+				var partition Partition
+				found, err := partitions.Get(partIdx, &partition)
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load partition %v", key)
+				if !found {
+					rt.Abortf(exitcode.ErrIllegalState, "no partition %v", key)
+				}
+
+				live, err := partition.LiveSectors()
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to compute live sectors for %v", key)
+				count, err := live.Count()
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to count live sectors for %v", key)
+				if count == 0 {
+					continue
+				}
+				if count > maxSectors-sectorCount {
+					count = maxSectors - sectorCount
+					live, err = live.Slice(0, count)
+					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to slice live sectors for %v", key)
+				}
+				sectorCount += count
+
+				partitionSectors[partIdx], err = st.LoadSectorInfos(store, live)
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load sectors for %v", key)
+			}
+
+			// Faulty power has already been removed from the claim, so only active power is removed now.
+			removed, removedFaulty, err := deadline.TerminateSectors(store, currEpoch, partitionSectors, info.SectorSize, quant)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to terminate sectors in deadline %d", dlIdx)
+			powerDelta = powerDelta.Sub(removed.Sub(removedFaulty))
+			st.FaultyPower = st.FaultyPower.Sub(removedFaulty)
+			more = deadline.LiveSectors > 0
+
+			err = deadlines.UpdateDeadline(store, dlIdx, deadline)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update deadline %d", dlIdx)
+
+			st.EarlyTerminations.Set(dlIdx)
+		}
+
+		err = st.SaveDeadlines(store, deadlines)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save deadlines")
+		return nil
+	})
+
+	if sectorCount > 0 {
+		rt.Log(vmr.INFO, "shutting down miner, terminated %d sectors with power %v", sectorCount, powerDelta.Neg())
+		requestUpdatePower(rt, powerDelta)
 	}
+	return more
+}
 
-	requestTerminateDeals(rt, rt.CurrEpoch(), dealIds)
+// Deletes a shutting-down miner once its early terminations have been processed and its locked funds have vested,
+// first removing its claim from the power actor. Any fee debt is paid, as far as possible, and the remaining balance
+// released to the owner.
+// Returns whether the actor was deleted.
+func completeShutdownIfReady(rt Runtime) bool {
+	var st State
+	var info *MinerInfo
+	ready := false
+	var cronEpochs []abi.ChainEpoch
+	pledgeDelta := big.Zero()
+	toBurn := big.Zero()
+	rt.State().Transaction(&st, func() interface{} {
+		if !st.ShuttingDown() {
+			return nil
+		}
+		info = getMinerInfo(rt, &st)
+		store := adt.AsStore(rt)
+
+		newlyVested, err := st.UnlockVestedFunds(store, rt.CurrEpoch())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to vest funds")
+		fromVesting, fromBalance, err := st.RepayDebts(store, rt.CurrEpoch(), rt.CurrentBalance())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to repay fee debt")
+		pledgeDelta = big.Add(newlyVested, fromVesting).Neg()
+		toBurn = big.Add(fromVesting, fromBalance)
+
+		ready = !havePendingEarlyTerminations(rt, &st) && st.LockedFunds.IsZero() && st.PreCommitDeposits.IsZero() &&
+			!haveLiveSectors(rt, &st)
+
+		// The miner's only enrolled callbacks are for its current proving deadline and any pending worker key change.
+		cronEpochs = []abi.ChainEpoch{st.DeadlineInfo(rt.CurrEpoch()).Last()}
+		if info.PendingWorkerKey != nil {
+			cronEpochs = append(cronEpochs, info.PendingWorkerKey.EffectiveAt)
+		}
+		return nil
+	})
+	notifyPledgeChanged(rt, pledgeDelta)
+	burnFunds(rt, toBurn)
+	if !ready {
+		return false
+	}
+
+	_, code := rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.DeleteMiner, &power.DeleteMinerParams{
+		CronEpochs: cronEpochs,
+	}, big.Zero())
+	builtin.RequireSuccess(rt, code, "failed to remove power claim")
+
+	// A beneficiary other than the owner is paid what remains of its quota before the owner receives the rest.
+	if info.Beneficiary != info.Owner {
+		toBeneficiary := big.Min(info.BeneficiaryTerm.Available(rt.CurrEpoch()), rt.CurrentBalance())
+		if toBeneficiary.GreaterThan(big.Zero()) {
+			_, code = rt.Send(info.Beneficiary, builtin.MethodSend, nil, toBeneficiary)
+			builtin.RequireSuccess(rt, code, "failed to pay beneficiary %v", info.Beneficiary)
+		}
+	}
+
+	rt.Log(vmr.INFO, "miner shut down, releasing balance %v to owner %v", rt.CurrentBalance(), info.Owner)
+	rt.DeleteActor(info.Owner)
+	return true
 }
 
-func scheduleEarlyTerminationWork(rt Runtime) {
-	enrollCronEvent(rt, rt.CurrEpoch()+1, &CronEventPayload{
-		EventType: CronEventProcessEarlyTerminations,
+func haveLiveSectors(rt Runtime, st *State) bool {
+	store := adt.AsStore(rt)
+	deadlines, err := st.LoadDeadlines(store)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadlines")
+	live := false
+	err = deadlines.ForEach(store, func(_ uint64, dl *Deadline) error {
+		live = live || dl.LiveSectors > 0
+		return nil
 	})
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to iterate deadlines")
+	return live
 }
 
 func havePendingEarlyTerminations(rt Runtime, st *State) bool {
@@ -2025,7 +2985,8 @@ func havePendingEarlyTerminations(rt Runtime, st *State) bool {
 	return !noEarlyTerminations
 }
 
-func verifyWindowedPost(rt Runtime, challengeEpoch abi.ChainEpoch, sectors []*SectorOnChainInfo, proofs []abi.PoStProof) {
+// Verifies a Window PoSt over some sectors, returning an error if the proof is invalid.
+func verifyWindowedPost(rt Runtime, challengeEpoch abi.ChainEpoch, sectors []abi.SectorInfo, proofs []abi.PoStProof) error {
 	minerActorID, err := addr.IDFromAddress(rt.Message().Receiver())
 	AssertNoError(err) // Runtime always provides ID-addresses
 
@@ -2035,27 +2996,33 @@ func verifyWindowedPost(rt Runtime, challengeEpoch abi.ChainEpoch, sectors []*Se
 	AssertNoError(err)
 	postRandomness := rt.GetRandomness(crypto.DomainSeparationTag_WindowedPoStChallengeSeed, challengeEpoch, addrBuf.Bytes())
 
-	sectorProofInfo := make([]abi.SectorInfo, len(sectors))
-	for i, s := range sectors {
-		sectorProofInfo[i] = abi.SectorInfo{
-			SealProof:    s.SealProof,
-			SectorNumber: s.SectorNumber,
-			SealedCID:    s.SealedCID,
-		}
-	}
-
 	// Get public inputs
 	pvInfo := abi.WindowPoStVerifyInfo{
 		Randomness:        abi.PoStRandomness(postRandomness),
 		Proofs:            proofs,
-		ChallengedSectors: sectorProofInfo,
+		ChallengedSectors: sectors,
 		Prover:            abi.ActorID(minerActorID),
 	}
 
 	// Verify the PoSt Proof
 	if err = rt.Syscalls().VerifyPoSt(pvInfo); err != nil {
-		rt.Abortf(exitcode.ErrIllegalArgument, "invalid PoSt %+v: %s", pvInfo, err)
+		return xerrors.Errorf("invalid PoSt %+v: %w", pvInfo, err)
+	}
+	return nil
+}
+
+// Returns the information with which sectors are challenged by a proof.
+// Each sector is identified by the number it was sealed with.
+func sectorProofInfos(sectors []*SectorOnChainInfo) []abi.SectorInfo {
+	infos := make([]abi.SectorInfo, len(sectors))
+	for i, s := range sectors {
+		infos[i] = abi.SectorInfo{
+			SealProof:    s.SealProof,
+			SectorNumber: s.SealedSectorNumber,
+			SealedCID:    s.SealedCID,
+		}
 	}
+	return infos
 }
 
 // SealVerifyParams is the structure of information that must be sent with a
@@ -2110,7 +3077,7 @@ func getVerifyInfo(rt Runtime, params *SealVerifyStuff) *abi.SealVerifyInfo {
 	}
 }
 
-// Closes down this miner by erasing its power, terminating all its deals and burning its funds
+// Closes down this miner by terminating all its deals and burning its funds
 func terminateMiner(rt Runtime) {
 	var st State
 	rt.State().Readonly(&st)
@@ -2178,19 +3145,14 @@ func commitWorkerKeyChange(rt Runtime) *adt.EmptyValue {
 	return nil
 }
 
-// Requests the current epoch target block reward from the reward actor.
-func requestCurrentEpochBlockReward(rt Runtime) abi.TokenAmount {
-	_, rwd := requestCurrentEpochBaselinePowerAndReward(rt)
-	return rwd
-}
-
-func requestCurrentEpochBaselinePowerAndReward(rt Runtime) (abi.StoragePower, abi.TokenAmount) {
+// Requests the current epoch block reward, its smoothed estimate, and the baseline power from the reward actor.
+func requestCurrentEpochBlockReward(rt Runtime) reward.ThisEpochRewardReturn {
 	rwret, code := rt.Send(builtin.RewardActorAddr, builtin.MethodsReward.ThisEpochReward, nil, big.Zero())
-	builtin.RequireSuccess(rt, code, "failed to check epoch baseline power")
+	builtin.RequireSuccess(rt, code, "failed to check epoch reward")
 	var ret reward.ThisEpochRewardReturn
 	err := rwret.Into(&ret)
-	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to unmarshal target power value")
-	return ret.ThisEpochBaselinePower, ret.ThisEpochReward
+	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to unmarshal epoch reward value")
+	return ret
 }
 
 // Requests the current network total power and pledge from the power actor.
@@ -2355,16 +3317,6 @@ func validateFRDeclarationPartition(key PartitionKey, partition *Partition, sect
 	return nil
 }
 
-func terminationPenalty(sectorSize abi.SectorSize, currEpoch abi.ChainEpoch, epochTargetReward abi.TokenAmount, networkQAPower abi.StoragePower, sectors []*SectorOnChainInfo) abi.TokenAmount {
-	totalFee := big.Zero()
-	for _, s := range sectors {
-		sectorPower := QAPowerForSector(sectorSize, s)
-		fee := PledgePenaltyForTermination(s.InitialPledge, currEpoch-s.Activation, epochTargetReward, networkQAPower, sectorPower)
-		totalFee = big.Add(fee, totalFee)
-	}
-	return totalFee
-}
-
 func PowerForSector(sectorSize abi.SectorSize, sector *SectorOnChainInfo) PowerPair {
 	return PowerPair{
 		Raw: big.NewIntUnsigned(uint64(sectorSize)),
@@ -2396,6 +3348,27 @@ func getMinerInfo(rt Runtime, st *State) *MinerInfo {
 	return info
 }
 
+// Returns the highest bit set in a bitfield, or zero if the bitfield is empty.
+// This walks the bitfield's runs rather than its bits, so is cheap even for large ranges.
+func lastBitSet(bf *abi.BitField) (uint64, error) {
+	iter, err := bf.RunIterator()
+	if err != nil {
+		return 0, err
+	}
+	var last, pos uint64
+	for iter.HasNext() {
+		run, err := iter.NextRun()
+		if err != nil {
+			return 0, err
+		}
+		pos += run.Len
+		if run.Val {
+			last = pos - 1
+		}
+	}
+	return last, nil
+}
+
 func min64(a, b uint64) uint64 {
 	if a < b {
 		return a
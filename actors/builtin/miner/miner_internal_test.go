@@ -8,6 +8,7 @@ import (
 
 	"github.com/filecoin-project/specs-actors/actors/abi"
 	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/util/smoothing"
 	tutils "github.com/filecoin-project/specs-actors/support/testing"
 )
 
@@ -94,8 +95,8 @@ type e = abi.ChainEpoch
 
 func TestFaultFeeInvariants(t *testing.T) {
 	t.Run("Undeclared faults are more expensive than declared faults", func(t *testing.T) {
-		epochReward := abi.NewTokenAmount(1_000)
-		networkPower := abi.NewStoragePower(100 << 50)
+		epochReward := smoothing.NewEstimate(abi.NewTokenAmount(1_000), big.Zero())
+		networkPower := smoothing.NewEstimate(abi.NewStoragePower(100<<50), big.Zero())
 		faultySectorPower := abi.NewStoragePower(1 << 50)
 
 		ff := PledgePenaltyForDeclaredFault(epochReward, networkPower, faultySectorPower)
@@ -104,8 +105,8 @@ func TestFaultFeeInvariants(t *testing.T) {
 	})
 
 	t.Run("Declared and Undeclared fault penalties are linear over sectorQAPower term", func(t *testing.T) {
-		epochReward := abi.NewTokenAmount(1_000)
-		networkPower := abi.NewStoragePower(100 << 50)
+		epochReward := smoothing.NewEstimate(abi.NewTokenAmount(1_000), big.Zero())
+		networkPower := smoothing.NewEstimate(abi.NewStoragePower(100<<50), big.Zero())
 		faultySectorAPower := abi.NewStoragePower(1 << 50)
 		faultySectorBPower := abi.NewStoragePower(19 << 50)
 		faultySectorCPower := abi.NewStoragePower(63 << 50)
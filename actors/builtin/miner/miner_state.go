@@ -33,6 +33,14 @@ type State struct {
 	// Sectors that have been pre-committed but not yet proven.
 	PreCommittedSectors cid.Cid // Map, HAMT[SectorNumber]SectorPreCommitOnChainInfo
 
+	// Sector numbers of pre-commitments, by the epoch at which they expire if not proven.
+	// Entries are not removed when sectors are proven, so may refer to pre-commitments that no longer exist.
+	PreCommittedSectorsExpiry cid.Cid // BitFieldQueue (AMT[Epoch]*BitField)
+
+	// Sector numbers that have ever been pre-committed, or masked by the owner.
+	// A sector number can never be reused once allocated.
+	AllocatedSectors cid.Cid // BitField
+
 	// Information for all proven and not-yet-expired sectors.
 	Sectors cid.Cid // Array, AMT[SectorNumber]SectorOnChainInfo (sparse)
 
@@ -58,14 +66,23 @@ type State struct {
 	// Deadlines with outstanding fees for early sector termination.
 	EarlyTerminations *bitfield.BitField
 
+	// The epoch at which the owner requested the miner be shut down, or -1 if it has not been.
+	// A miner that is shutting down has terminated all its sectors and may not pre-commit new ones. It is deleted
+	// once its early terminations are processed and its locked funds have vested.
+	ShutdownEpoch abi.ChainEpoch
+
 	// Memoized power information
 	FaultyPower PowerPair
+
+	// Penalties owed to the network that the miner has not had the funds to pay.
+	// Funds owed are not available to the miner, and are paid as soon as they become available.
+	FeeDebt abi.TokenAmount
 }
 
 type MinerInfo struct {
 	// Account that owns this miner.
-	// - Income and returned collateral are paid to this address.
-	// - This address is also allowed to change the worker address for the miner.
+	// - Income and returned collateral are paid to this address, unless a separate beneficiary is set.
+	// - This address is also allowed to change the worker address and beneficiary for the miner.
 	Owner addr.Address // Must be an ID-address.
 
 	// Worker account for this miner.
@@ -74,6 +91,16 @@ type MinerInfo struct {
 
 	PendingWorkerKey *WorkerKeyChange
 
+	// Account to which withdrawn funds are paid, within the limits of BeneficiaryTerm.
+	// This is the owner unless the owner has nominated another account, and that account has accepted.
+	Beneficiary addr.Address // Must be an ID-address.
+
+	// The funds the beneficiary may withdraw, and until when. Not enforced while the beneficiary is the owner.
+	BeneficiaryTerm BeneficiaryTerm
+
+	// A change of beneficiary proposed by the owner, awaiting approval.
+	PendingBeneficiaryTerm *PendingBeneficiaryChange
+
 	// Byte array representing a Libp2p identity that should be used when connecting to this miner.
 	PeerId abi.PeerID
 
@@ -90,6 +117,10 @@ type MinerInfo struct {
 	// The number of sectors in each Window PoSt partition (proof).
 	// This is computed from the proof type and represented here redundantly.
 	WindowPoStPartitionSectors uint64
+
+	// The last epoch of the ineligibility period following the miner's most recently reported consensus fault,
+	// or -1 if no fault has been reported. The miner may not win elections up to and including this epoch.
+	ConsensusFaultElapsed abi.ChainEpoch
 }
 
 type WorkerKeyChange struct {
@@ -97,6 +128,33 @@ type WorkerKeyChange struct {
 	EffectiveAt abi.ChainEpoch
 }
 
+type BeneficiaryTerm struct {
+	// Total amount the beneficiary may withdraw.
+	Quota abi.TokenAmount
+	// Amount the beneficiary has withdrawn so far.
+	UsedQuota abi.TokenAmount
+	// The beneficiary may not withdraw at or after this epoch.
+	Expiration abi.ChainEpoch
+}
+
+// The amount the beneficiary may still withdraw at some epoch.
+func (t *BeneficiaryTerm) Available(currEpoch abi.ChainEpoch) abi.TokenAmount {
+	if currEpoch >= t.Expiration {
+		return big.Zero()
+	}
+	return big.Max(big.Sub(t.Quota, t.UsedQuota), big.Zero())
+}
+
+type PendingBeneficiaryChange struct {
+	NewBeneficiary addr.Address // Must be an ID address
+	NewQuota       abi.TokenAmount
+	NewExpiration  abi.ChainEpoch
+	// Whether the current beneficiary has approved the change.
+	ApprovedByBeneficiary bool
+	// Whether the nominated beneficiary has accepted the change.
+	ApprovedByNominee bool
+}
+
 // Information provided by a miner when pre-committing a sector.
 type SectorPreCommitInfo struct {
 	SealProof       abi.RegisteredSealProof
@@ -124,13 +182,15 @@ type SectorPreCommitOnChainInfo struct {
 // Information stored on-chain for a proven sector.
 type SectorOnChainInfo struct {
 	SectorNumber       abi.SectorNumber
+	SealedSectorNumber abi.SectorNumber        // Number bound into the replica and its proofs, which differs from SectorNumber once renumbered
 	SealProof          abi.RegisteredSealProof // The seal proof type implies the PoSt proof/s
 	SealedCID          cid.Cid                 // CommR
 	DealIDs            []abi.DealID
 	Activation         abi.ChainEpoch  // Epoch during which the sector proof was accepted
+	ReplicaUpdateEpoch abi.ChainEpoch  // Epoch during which the sector's replica was last updated, or zero if never
 	Expiration         abi.ChainEpoch  // Epoch during which the sector expires
-	DealWeight         abi.DealWeight  // Integral of active deals over sector lifetime
-	VerifiedDealWeight abi.DealWeight  // Integral of active verified deals over sector lifetime
+	DealWeight         abi.DealWeight  // Integral of active deals over sector lifetime, since any replica update
+	VerifiedDealWeight abi.DealWeight  // Integral of active verified deals over sector lifetime, since any replica update
 	InitialPledge      abi.TokenAmount // Pledge collected to commit this sector
 }
 
@@ -149,7 +209,7 @@ const (
 	SectorHealthy
 )
 
-func ConstructState(infoCid cid.Cid, periodStart abi.ChainEpoch, emptyArrayCid, emptyMapCid, emptyDeadlinesCid cid.Cid) (*State, error) {
+func ConstructState(infoCid cid.Cid, periodStart abi.ChainEpoch, emptyBitfieldCid, emptyArrayCid, emptyMapCid, emptyDeadlinesCid cid.Cid) (*State, error) {
 	return &State{
 		Info: infoCid,
 
@@ -158,14 +218,18 @@ func ConstructState(infoCid cid.Cid, periodStart abi.ChainEpoch, emptyArrayCid,
 		VestingFunds:             emptyArrayCid,
 		InitialPledgeRequirement: abi.NewTokenAmount(0),
 
-		PreCommittedSectors: emptyMapCid,
-		Sectors:             emptyArrayCid,
-		ProvingPeriodStart:  periodStart,
-		CurrentDeadline:     0,
-		Deadlines:           emptyDeadlinesCid,
+		PreCommittedSectors:       emptyMapCid,
+		PreCommittedSectorsExpiry: emptyArrayCid,
+		AllocatedSectors:          emptyBitfieldCid,
+		Sectors:                   emptyArrayCid,
+		ProvingPeriodStart:        periodStart,
+		CurrentDeadline:           0,
+		Deadlines:                 emptyDeadlinesCid,
 
 		FaultyPower:       NewPowerPairZero(),
 		EarlyTerminations: abi.NewBitField(),
+		ShutdownEpoch:     -1,
+		FeeDebt:           abi.NewTokenAmount(0),
 	}, nil
 }
 
@@ -184,14 +248,24 @@ func ConstructMinerInfo(owner addr.Address, worker addr.Address, pid []byte, mul
 		Owner:                      owner,
 		Worker:                     worker,
 		PendingWorkerKey:           nil,
+		Beneficiary:                owner,
+		BeneficiaryTerm:            BeneficiaryTerm{Quota: big.Zero(), UsedQuota: big.Zero(), Expiration: 0},
+		PendingBeneficiaryTerm:     nil,
 		PeerId:                     pid,
 		Multiaddrs:                 multiAddrs,
 		SealProofType:              sealProofType,
 		SectorSize:                 sectorSize,
 		WindowPoStPartitionSectors: partitionSectors,
+		ConsensusFaultElapsed:      abi.ChainEpoch(-1),
 	}, nil
 }
 
+// Returns whether the miner is serving the ineligibility period of a consensus fault at some epoch, during which
+// it may not win elections.
+func ConsensusFaultActive(info *MinerInfo, currEpoch abi.ChainEpoch) bool {
+	return currEpoch <= info.ConsensusFaultElapsed
+}
+
 func (st *State) GetInfo(store adt.Store) (*MinerInfo, error) {
 	var info MinerInfo
 	if err := store.Get(store.Context(), st.Info, &info); err != nil {
@@ -214,6 +288,11 @@ func (st *State) DeadlineInfo(currEpoch abi.ChainEpoch) *DeadlineInfo {
 	return NewDeadlineInfo(st.ProvingPeriodStart, st.CurrentDeadline, currEpoch)
 }
 
+// Whether the owner has requested the miner be shut down.
+func (st *State) ShuttingDown() bool {
+	return st.ShutdownEpoch >= 0
+}
+
 func (st *State) GetSectorCount(store adt.Store) (uint64, error) {
 	arr, err := adt.AsArray(store, st.Sectors)
 	if err != nil {
@@ -301,6 +380,199 @@ func (st *State) DeletePrecommittedSectors(store adt.Store, sectorNos ...abi.Sec
 	return err
 }
 
+// Schedules a pre-committed sector to expire at some epoch, if it has not been proven by then.
+// The expiration is quantized to the end of a deadline, when expirations are processed.
+func (st *State) AddPreCommitExpiry(store adt.Store, expireEpoch abi.ChainEpoch, sectorNo abi.SectorNumber) error {
+	queue, err := LoadBitfieldQueue(store, st.PreCommittedSectorsExpiry, st.QuantEndOfDeadline())
+	if err != nil {
+		return xerrors.Errorf("failed to load pre-commit expiry queue: %w", err)
+	}
+	if err = queue.AddToQueueValues(expireEpoch, uint64(sectorNo)); err != nil {
+		return xerrors.Errorf("failed to add pre-commit expiry for sector %d: %w", sectorNo, err)
+	}
+	st.PreCommittedSectorsExpiry, err = queue.Root()
+	return err
+}
+
+// Removes pre-commitments that expire at or before some epoch without having been proven.
+// Returns the total of their deposits, which the caller must burn.
+func (st *State) ExpirePreCommits(store adt.Store, currEpoch abi.ChainEpoch) (abi.TokenAmount, error) {
+	queue, err := LoadBitfieldQueue(store, st.PreCommittedSectorsExpiry, st.QuantEndOfDeadline())
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load pre-commit expiry queue: %w", err)
+	}
+	sectors, modified, err := queue.PopUntil(currEpoch)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to pop pre-commit expiry queue: %w", err)
+	}
+	if !modified {
+		return big.Zero(), nil
+	}
+	if st.PreCommittedSectorsExpiry, err = queue.Root(); err != nil {
+		return big.Zero(), xerrors.Errorf("failed to save pre-commit expiry queue: %w", err)
+	}
+	return st.ForfeitPreCommits(store, sectors)
+}
+
+// Deletes those of some sectors that remain pre-committed, releasing their deposits from PreCommitDeposits.
+// Returns the total of the deposits, which the caller must burn.
+func (st *State) ForfeitPreCommits(store adt.Store, sectors *abi.BitField) (abi.TokenAmount, error) {
+	deposits := big.Zero()
+	var sectorNos []abi.SectorNumber
+	err := sectors.ForEach(func(i uint64) error {
+		sectorNo := abi.SectorNumber(i)
+		precommit, found, err := st.GetPrecommittedSector(store, sectorNo)
+		if err != nil {
+			return err
+		}
+		if !found {
+			// already committed/deleted
+			return nil
+		}
+		sectorNos = append(sectorNos, sectorNo)
+		deposits = big.Add(deposits, precommit.PreCommitDeposit)
+		return nil
+	})
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to check pre-commits: %w", err)
+	}
+
+	if len(sectorNos) > 0 {
+		if err = st.DeletePrecommittedSectors(store, sectorNos...); err != nil {
+			return big.Zero(), xerrors.Errorf("failed to delete pre-commits: %w", err)
+		}
+	}
+	st.PreCommitDeposits = big.Sub(st.PreCommitDeposits, deposits)
+	Assert(st.PreCommitDeposits.GreaterThanEqual(big.Zero()))
+	return deposits, nil
+}
+
+// Marks a sector number as allocated.
+// Returns false, and leaves the allocated set unchanged, if the number was already allocated.
+func (st *State) AllocateSectorNumber(store adt.Store, sectorNo abi.SectorNumber) (bool, error) {
+	var allocatedSectors bitfield.BitField
+	if err := store.Get(store.Context(), st.AllocatedSectors, &allocatedSectors); err != nil {
+		return false, xerrors.Errorf("failed to load allocated sectors bitfield: %w", err)
+	}
+	if allocated, err := allocatedSectors.IsSet(uint64(sectorNo)); err != nil {
+		return false, xerrors.Errorf("failed to lookup sector number %d in allocated sectors bitfield: %w", sectorNo, err)
+	} else if allocated {
+		return false, nil
+	}
+	allocatedSectors.Set(uint64(sectorNo))
+
+	root, err := store.Put(store.Context(), &allocatedSectors)
+	if err != nil {
+		return false, xerrors.Errorf("failed to store allocated sectors bitfield after adding sector %d: %w", sectorNo, err)
+	}
+	st.AllocatedSectors = root
+	return true, nil
+}
+
+// Marks a set of sector numbers as allocated, whether or not they were already allocated.
+func (st *State) MaskSectorNumbers(store adt.Store, sectorNos *bitfield.BitField) error {
+	var allocatedSectors bitfield.BitField
+	if err := store.Get(store.Context(), st.AllocatedSectors, &allocatedSectors); err != nil {
+		return xerrors.Errorf("failed to load allocated sectors bitfield: %w", err)
+	}
+
+	merged, err := bitfield.MergeBitFields(&allocatedSectors, sectorNos)
+	if err != nil {
+		return xerrors.Errorf("failed to merge allocated sector numbers with mask: %w", err)
+	}
+
+	root, err := store.Put(store.Context(), merged)
+	if err != nil {
+		return xerrors.Errorf("failed to store allocated sectors bitfield after masking: %w", err)
+	}
+	st.AllocatedSectors = root
+	return nil
+}
+
+// Assigns the live sectors of a partition new, consecutive numbers starting at firstSectorNo, re-keying their
+// sector infos, and removes the partition's terminated sectors along with their sector infos.
+// The new numbers must not already be allocated, and are marked allocated. The old numbers remain allocated.
+// Returns the number of sectors renumbered.
+func (st *State) RenumberSectors(store adt.Store, dlIdx, partIdx uint64, firstSectorNo abi.SectorNumber) (uint64, error) {
+	deadlines, err := st.LoadDeadlines(store)
+	if err != nil {
+		return 0, err
+	}
+	deadline, err := deadlines.LoadDeadline(store, dlIdx)
+	if err != nil {
+		return 0, err
+	}
+	renumbered, removed, err := deadline.RenumberSectors(store, partIdx, firstSectorNo, st.QuantEndOfDeadline())
+	if err != nil {
+		return 0, err
+	}
+	// The terminated sectors are no longer referenced by any partition.
+	if err = st.DeleteSectors(store, removed); err != nil {
+		return 0, xerrors.Errorf("failed to delete terminated sectors: %w", err)
+	}
+
+	oldSectorNos := make([]uint64, 0, len(renumbered))
+	newSectorNos := make([]uint64, 0, len(renumbered))
+	for oldSectorNo, newSectorNo := range renumbered { // nolint:nomaprange // subsequently sorted
+		oldSectorNos = append(oldSectorNos, oldSectorNo)
+		newSectorNos = append(newSectorNos, newSectorNo)
+	}
+	sort.Slice(oldSectorNos, func(i, j int) bool { return oldSectorNos[i] < oldSectorNos[j] })
+	if len(newSectorNos) > 0 && uint64(firstSectorNo)+uint64(len(newSectorNos))-1 > abi.MaxSectorNumber {
+		return 0, xerrors.Errorf("%d sectors numbered from %d exceed maximum sector number %d",
+			len(newSectorNos), firstSectorNo, abi.MaxSectorNumber)
+	}
+
+	// Allocate the new numbers.
+	newSectors := bitfield.NewFromSet(newSectorNos)
+	var allocatedSectors bitfield.BitField
+	if err = store.Get(store.Context(), st.AllocatedSectors, &allocatedSectors); err != nil {
+		return 0, xerrors.Errorf("failed to load allocated sectors bitfield: %w", err)
+	}
+	if overlap, err := bitfield.IntersectBitField(&allocatedSectors, newSectors); err != nil {
+		return 0, xerrors.Errorf("failed to intersect new sector numbers with allocated sectors: %w", err)
+	} else if empty, err := overlap.IsEmpty(); err != nil {
+		return 0, xerrors.Errorf("failed to check new sector numbers: %w", err)
+	} else if !empty {
+		return 0, xerrors.Errorf("new sector numbers %v already allocated", overlap)
+	}
+	if err = st.MaskSectorNumbers(store, newSectors); err != nil {
+		return 0, err
+	}
+
+	// Re-key the sector infos.
+	sectors, err := adt.AsArray(store, st.Sectors)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to load sectors: %w", err)
+	}
+	for _, oldSectorNo := range oldSectorNos {
+		var info SectorOnChainInfo
+		if found, err := sectors.Get(oldSectorNo, &info); err != nil {
+			return 0, xerrors.Errorf("failed to load sector %d: %w", oldSectorNo, err)
+		} else if !found {
+			return 0, xerrors.Errorf("sector %d not found", oldSectorNo)
+		}
+		if err = sectors.Delete(oldSectorNo); err != nil {
+			return 0, xerrors.Errorf("failed to delete sector %d: %w", oldSectorNo, err)
+		}
+		info.SectorNumber = abi.SectorNumber(renumbered[oldSectorNo])
+		if err = sectors.Set(uint64(info.SectorNumber), &info); err != nil {
+			return 0, xerrors.Errorf("failed to put sector %d renumbered from %d: %w", info.SectorNumber, oldSectorNo, err)
+		}
+	}
+	if st.Sectors, err = sectors.Root(); err != nil {
+		return 0, xerrors.Errorf("failed to persist sectors: %w", err)
+	}
+
+	if err = deadlines.UpdateDeadline(store, dlIdx, deadline); err != nil {
+		return 0, err
+	}
+	if err = st.SaveDeadlines(store, deadlines); err != nil {
+		return 0, err
+	}
+	return uint64(len(oldSectorNos)), nil
+}
+
 func (st *State) HasSectorNo(store adt.Store, sectorNo abi.SectorNumber) (bool, error) {
 	sectors, err := adt.AsArray(store, st.Sectors)
 	if err != nil {
@@ -532,7 +804,12 @@ func (st *State) RescheduleSectorExpirations(store adt.Store, currEpoch abi.Chai
 		func(dl *Deadline, partition *Partition, dlIdx, partIdx uint64, sectors *bitfield.BitField) (bool, error) {
 			// Sectors are uniquified by being represented in a bitfield.
 			// This is an important property for the reschedule logic to be correct.
-			live, err := bitfield.SubtractBitField(sectors, partition.Terminated)
+			// Sectors renumbered since they were located are no longer in the partition, and are ignored.
+			present, err := bitfield.IntersectBitField(sectors, partition.Sectors)
+			if err != nil {
+				return false, err
+			}
+			live, err := bitfield.SubtractBitField(present, partition.Terminated)
 			if err != nil {
 				return false, err
 			}
@@ -1064,10 +1341,42 @@ func (st *State) CheckVestedFunds(store adt.Store, currEpoch abi.ChainEpoch) (ab
 	return amountUnlocked, nil
 }
 
+// Returns the balance that is neither locked, deposited for pre-commitments, nor owed as fee debt.
 func (st *State) GetAvailableBalance(actorBalance abi.TokenAmount) abi.TokenAmount {
-	availableBal := big.Sub(big.Sub(actorBalance, st.LockedFunds), st.PreCommitDeposits)
-	Assert(availableBal.GreaterThanEqual(big.Zero()))
-	return availableBal
+	unlockedBal := st.getUnlockedBalance(actorBalance)
+	return big.Max(big.Sub(unlockedBal, st.FeeDebt), big.Zero())
+}
+
+func (st *State) getUnlockedBalance(actorBalance abi.TokenAmount) abi.TokenAmount {
+	unlockedBal := big.Sub(big.Sub(actorBalance, st.LockedFunds), st.PreCommitDeposits)
+	Assert(unlockedBal.GreaterThanEqual(big.Zero()))
+	return unlockedBal
+}
+
+// Records a penalty as fee debt, to be paid by RepayDebts.
+func (st *State) ApplyPenalty(penalty abi.TokenAmount) error {
+	if penalty.LessThan(big.Zero()) {
+		return xerrors.Errorf("applying negative penalty %v not allowed", penalty)
+	}
+	st.FeeDebt = big.Add(st.FeeDebt, penalty)
+	return nil
+}
+
+// Repays as much fee debt as possible, first from locked funds and then from the unlocked balance.
+// Returns the amounts taken from each, which the caller must burn.
+func (st *State) RepayDebts(store adt.Store, currEpoch abi.ChainEpoch, actorBalance abi.TokenAmount) (fromVesting, fromBalance abi.TokenAmount, err error) {
+	// Locked funds taken to repay debt become unlocked balance, so this is computed first.
+	unlockedBal := st.getUnlockedBalance(actorBalance)
+
+	fromVesting, err = st.UnlockUnvestedFunds(store, currEpoch, st.FeeDebt)
+	if err != nil {
+		return big.Zero(), big.Zero(), xerrors.Errorf("failed to unlock funds to repay debt: %w", err)
+	}
+	st.FeeDebt = big.Sub(st.FeeDebt, fromVesting)
+
+	fromBalance = big.Min(unlockedBal, st.FeeDebt)
+	st.FeeDebt = big.Sub(st.FeeDebt, fromBalance)
+	return fromVesting, fromBalance, nil
 }
 
 // Returns a quantization spec that quantizes values to the last epoch in each deadline.
@@ -1079,6 +1388,7 @@ func (st *State) QuantEndOfDeadline() QuantSpec {
 func (st *State) AssertBalanceInvariants(balance abi.TokenAmount) {
 	Assert(st.PreCommitDeposits.GreaterThanEqual(big.Zero()))
 	Assert(st.LockedFunds.GreaterThanEqual(big.Zero()))
+	Assert(st.FeeDebt.GreaterThanEqual(big.Zero()))
 	Assert(balance.GreaterThanEqual(big.Add(st.PreCommitDeposits, st.LockedFunds)))
 }
 
@@ -47,6 +47,32 @@ func TestPrecommittedSectorsStore(t *testing.T) {
 		sectorNo := abi.SectorNumber(1)
 		assert.False(t, harness.hasPreCommit(sectorNo))
 	})
+
+	t.Run("Expire pre-commits at the end of the deadline containing their expiry", func(t *testing.T) {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+		for i, expiry := range []abi.ChainEpoch{10, 100, 10} {
+			sectorNo := abi.SectorNumber(i + 1)
+			pc := newSectorPreCommitOnChainInfo(sectorNo, tutils.MakeCID(fmt.Sprint(i), &miner.SealedCIDPrefix), abi.NewTokenAmount(int64(10*(i+1))), abi.ChainEpoch(1))
+			harness.putPreCommit(pc)
+			harness.s.AddPreCommitDeposit(pc.PreCommitDeposit)
+			require.NoError(t, harness.s.AddPreCommitExpiry(harness.store, expiry, sectorNo))
+		}
+		// Sector 3 is proven before it expires.
+		harness.deletePreCommit(abi.SectorNumber(3))
+		harness.s.AddPreCommitDeposit(abi.NewTokenAmount(-30))
+
+		// Expirations are quantized to the last epoch of the deadline.
+		deposits, err := harness.s.ExpirePreCommits(harness.store, miner.WPoStChallengeWindow-2)
+		require.NoError(t, err)
+		assert.Equal(t, big.Zero(), deposits)
+
+		deposits, err = harness.s.ExpirePreCommits(harness.store, miner.WPoStChallengeWindow-1)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(10), deposits)
+		assert.False(t, harness.hasPreCommit(abi.SectorNumber(1)))
+		assert.True(t, harness.hasPreCommit(abi.SectorNumber(2)))
+		assert.Equal(t, abi.NewTokenAmount(20), harness.s.PreCommitDeposits)
+	})
 }
 
 func TestSectorsStore(t *testing.T) {
@@ -182,6 +208,44 @@ func TestSectorsStore(t *testing.T) {
 //	})
 //}
 
+func TestAllocatedSectorNumbers(t *testing.T) {
+	t.Run("allocates each sector number once", func(t *testing.T) {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+
+		allocated, err := harness.s.AllocateSectorNumber(harness.store, 1)
+		require.NoError(t, err)
+		assert.True(t, allocated)
+
+		allocated, err = harness.s.AllocateSectorNumber(harness.store, 1)
+		require.NoError(t, err)
+		assert.False(t, allocated)
+
+		allocated, err = harness.s.AllocateSectorNumber(harness.store, 2)
+		require.NoError(t, err)
+		assert.True(t, allocated)
+	})
+
+	t.Run("masked sector numbers are allocated", func(t *testing.T) {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+
+		allocated, err := harness.s.AllocateSectorNumber(harness.store, 3)
+		require.NoError(t, err)
+		assert.True(t, allocated)
+
+		err = harness.s.MaskSectorNumbers(harness.store, bitfield.NewFromSet([]uint64{3, 4, 5}))
+		require.NoError(t, err)
+
+		for _, sectorNo := range []abi.SectorNumber{3, 4, 5} {
+			allocated, err := harness.s.AllocateSectorNumber(harness.store, sectorNo)
+			require.NoError(t, err)
+			assert.False(t, allocated)
+		}
+		allocated, err = harness.s.AllocateSectorNumber(harness.store, 6)
+		require.NoError(t, err)
+		assert.True(t, allocated)
+	})
+}
+
 func TestVesting_AddLockedFunds_Table(t *testing.T) {
 	vestStartDelay := abi.ChainEpoch(10)
 	vestSum := int64(100)
@@ -649,6 +713,8 @@ func constructStateHarness(t *testing.T, periodBoundary abi.ChainEpoch) *stateHa
 
 	emptyArray, err := adt.MakeEmptyArray(store).Root()
 	require.NoError(t, err)
+	emptyBitfieldCid, err := store.Put(store.Context(), abi.NewBitField())
+	require.NoError(t, err)
 	emptyDeadline := miner.ConstructDeadline(emptyArray)
 	emptyDeadlineCid, err := store.Put(store.Context(), emptyDeadline)
 	require.NoError(t, err)
@@ -673,6 +739,8 @@ func constructStateHarness(t *testing.T, periodBoundary abi.ChainEpoch) *stateHa
 		Owner:                      owner,
 		Worker:                     worker,
 		PendingWorkerKey:           nil,
+		Beneficiary:                owner,
+		BeneficiaryTerm:            miner.BeneficiaryTerm{Quota: big.Zero(), UsedQuota: big.Zero()},
 		PeerId:                     abi.PeerID("peer"),
 		Multiaddrs:                 testMultiaddrs,
 		SealProofType:              testSealProofType,
@@ -682,7 +750,7 @@ func constructStateHarness(t *testing.T, periodBoundary abi.ChainEpoch) *stateHa
 	infoCid, err := store.Put(context.Background(), &info)
 	require.NoError(t, err)
 
-	state, err := miner.ConstructState(infoCid, periodBoundary, emptyArray, emptyMap, emptyDeadlinesCid)
+	state, err := miner.ConstructState(infoCid, periodBoundary, emptyBitfieldCid, emptyArray, emptyMap, emptyDeadlinesCid)
 	require.NoError(t, err)
 
 	return &stateHarness{
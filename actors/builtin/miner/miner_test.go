@@ -27,6 +27,7 @@ import (
 	"github.com/filecoin-project/specs-actors/actors/runtime"
 	"github.com/filecoin-project/specs-actors/actors/runtime/exitcode"
 	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/actors/util/smoothing"
 	"github.com/filecoin-project/specs-actors/support/mock"
 	tutil "github.com/filecoin-project/specs-actors/support/testing"
 )
@@ -179,7 +180,7 @@ func TestCommitments(t *testing.T) {
 		assert.Equal(t, big.NewInt(int64(sectorSize/2)), onChainPrecommit.VerifiedDealWeight)
 
 		qaPower := miner.QAPowerForWeight(sectorSize, precommit.Expiration-precommitEpoch, onChainPrecommit.DealWeight, onChainPrecommit.VerifiedDealWeight)
-		expectedDeposit := miner.InitialPledgeForPower(qaPower, actor.networkQAPower, actor.baselinePower, actor.networkPledge, actor.epochReward, rt.TotalFilCircSupply())
+		expectedDeposit := miner.InitialPledgeForPower(qaPower, actor.networkQAPowerEstimate(), actor.baselinePower, actor.networkPledge, actor.rewardEstimate(), rt.TotalFilCircSupply())
 		assert.Equal(t, expectedDeposit, onChainPrecommit.PreCommitDeposit)
 
 		// expect total precommit deposit to equal our new deposit
@@ -525,7 +526,7 @@ func TestCommitments(t *testing.T) {
 
 		// Declare the old sector faulty
 		_, qaPower := powerForSectors(actor.sectorSize, []*miner.SectorOnChainInfo{oldSector})
-		fee := miner.PledgePenaltyForDeclaredFault(actor.epochReward, actor.networkQAPower, qaPower)
+		fee := miner.PledgePenaltyForDeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), qaPower)
 		actor.declareFaults(rt, actor.networkQAPower, fee, oldSector)
 
 		rt.SetEpoch(upgrade.PreCommitEpoch + miner.PreCommitChallengeDelay + 1)
@@ -546,7 +547,7 @@ func TestCommitments(t *testing.T) {
 		assert.Equal(t, oldSector.Expiration, oldSectorAgain.Expiration)
 
 		// Roll forward to PP cron. The faulty old sector pays a fee, but is not terminated.
-		penalty := miner.PledgePenaltyForDeclaredFault(actor.epochReward, actor.networkQAPower,
+		penalty := miner.PledgePenaltyForDeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(),
 			miner.QAPowerForSector(actor.sectorSize, oldSector))
 		completeProvingPeriod(rt, actor, &cronConfig{
 			newSectors:           true,
@@ -686,6 +687,126 @@ func TestCommitments(t *testing.T) {
 		}
 
 	})
+
+	t.Run("expired pre-commit is removed at deadline cron", func(t *testing.T) {
+		actor := newHarness(t, periodOffset)
+		rt := builderForHarness(actor).
+			WithBalance(bigBalance, big.Zero()).
+			Build(t)
+		precommitEpoch := periodOffset + 1
+		rt.SetEpoch(precommitEpoch)
+		actor.constructAndVerify(rt)
+		deadline := actor.deadline(rt)
+
+		sectorNo := abi.SectorNumber(100)
+		precommit := actor.preCommitSector(rt, actor.makePreCommit(sectorNo, precommitEpoch-1, deadline.PeriodEnd(), nil))
+		assert.Equal(t, precommit.PreCommitDeposit, getState(rt).PreCommitDeposits)
+
+		// The pre-commit survives until the cron for the deadline containing its expiry.
+		expiry := precommitEpoch + miner.MaxSealDuration[actor.sealProofType] + 1
+		for actor.deadline(rt).Last() < expiry {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
+		actor.getPreCommit(rt, sectorNo)
+
+		advanceDeadline(rt, actor, &cronConfig{expiredPrecommitDeposit: &precommit.PreCommitDeposit})
+
+		st := getState(rt)
+		_, found, err := st.GetPrecommittedSector(rt.AdtStore(), sectorNo)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, big.Zero(), st.PreCommitDeposits)
+	})
+}
+
+func TestMaskSectorNumbers(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	t.Run("expired pre-commit sector number cannot be reused", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetEpoch(periodOffset + 1)
+		deadline := actor.deadline(rt)
+		expiration := deadline.PeriodEnd() + 100*miner.WPoStProvingPeriod
+
+		precommit := actor.preCommitSector(rt, actor.makePreCommit(100, rt.Epoch()-1, expiration, nil))
+
+		// The pre-commit expires without being proven, and its deposit is burnt.
+		rt.SetCaller(builtin.StoragePowerActorAddr, builtin.StoragePowerActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.StoragePowerActorAddr)
+		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, precommit.PreCommitDeposit, nil, exitcode.Ok)
+		rt.Call(actor.a.OnDeferredCronEvent, &miner.CronEventPayload{
+			EventType: miner.CronEventPreCommitExpiry,
+			Sectors:   bitfield.NewFromSet([]uint64{100}),
+		})
+		rt.Verify()
+
+		rt.ExpectAbortConstainsMessage(exitcode.ErrIllegalArgument, "already allocated", func() {
+			actor.preCommitSector(rt, actor.makePreCommit(100, rt.Epoch()-1, expiration, nil))
+		})
+	})
+
+	t.Run("masked sector numbers cannot be pre-committed", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetEpoch(periodOffset + 1)
+		deadline := actor.deadline(rt)
+		expiration := deadline.PeriodEnd() + 100*miner.WPoStProvingPeriod
+
+		mask := bitfield.NewFromSet([]uint64{200, 201, 202})
+		actor.maskSectorNumbers(rt, mask)
+
+		rt.ExpectAbortConstainsMessage(exitcode.ErrIllegalArgument, "already allocated", func() {
+			actor.preCommitSector(rt, actor.makePreCommit(201, rt.Epoch()-1, expiration, nil))
+		})
+		rt.Reset()
+
+		// Numbers outside the mask are still available.
+		actor.preCommitSector(rt, actor.makePreCommit(203, rt.Epoch()-1, expiration, nil))
+	})
+
+	t.Run("only the owner can mask sector numbers", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.MaskSectorNumbers, &miner.MaskSectorNumbersParams{Mask: bitfield.NewFromSet([]uint64{1})})
+		})
+		rt.Verify()
+	})
+
+	t.Run("rejects mask beyond the maximum sector number", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.a.MaskSectorNumbers, &miner.MaskSectorNumbersParams{
+				Mask: bitfield.NewFromSet([]uint64{abi.MaxSectorNumber + 1}),
+			})
+		})
+		rt.Verify()
+	})
+
+	t.Run("validates caller before parameters", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.MaskSectorNumbers, &miner.MaskSectorNumbersParams{
+				Mask: bitfield.NewFromSet([]uint64{abi.MaxSectorNumber + 1}),
+			})
+		})
+		rt.Verify()
+	})
 }
 
 func TestWindowPost(t *testing.T) {
@@ -730,6 +851,131 @@ func TestWindowPost(t *testing.T) {
 		advanceDeadline(rt, actor, &cronConfig{})
 	})
 
+	// Commits a sector and submits an optimistic proof for it, then closes the deadline.
+	// Returns the info for the deadline in which the proof was submitted.
+	proveOptimistically := func(rt *mock.Runtime) (*miner.SectorOnChainInfo, *miner.DeadlineInfo) {
+		actor.constructAndVerify(rt)
+		store := rt.AdtStore()
+		sector := actor.commitAndProveSectors(rt, 1, 100, nil)[0]
+
+		st := getState(rt)
+		dlIdx, pIdx, err := st.FindSector(store, sector.SectorNumber)
+		require.NoError(t, err)
+
+		dlinfo := actor.deadline(rt)
+		for dlinfo.Index != dlIdx {
+			advanceDeadline(rt, actor, &cronConfig{})
+			dlinfo = actor.deadline(rt)
+		}
+
+		partitions := []miner.PoStPartition{
+			{Index: pIdx, Skipped: abi.NewBitField()},
+		}
+		actor.submitWindowPoSt(rt, dlinfo, partitions, []*miner.SectorOnChainInfo{sector}, nil)
+
+		// The proof is recorded for dispute rather than verified.
+		deadline := actor.getDeadline(rt, dlIdx)
+		proofs, err := adt.AsArray(store, deadline.OptimisticPoStSubmissions)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), proofs.Length())
+
+		// Closing the deadline moves the proof into the snapshot.
+		advanceDeadline(rt, actor, &cronConfig{})
+		deadline = actor.getDeadline(rt, dlIdx)
+		proofs, err = adt.AsArray(store, deadline.OptimisticPoStSubmissions)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), proofs.Length())
+		proofs, err = adt.AsArray(store, deadline.OptimisticPoStSubmissionsSnapshot)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), proofs.Length())
+
+		return sector, dlinfo
+	}
+
+	t.Run("valid proof cannot be disputed", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlinfo := proveOptimistically(rt)
+
+		disputer := tutil.NewIDAddr(t, 200)
+		actor.disputeWindowPoSt(rt, disputer, dlinfo, 0, []*miner.SectorOnChainInfo{sector}, nil)
+	})
+
+	t.Run("invalid proof is disputed", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlinfo := proveOptimistically(rt)
+
+		// Lock enough funds to cover the whole penalty.
+		actor.addLockedFund(rt, big.Mul(big.NewInt(100), abi.TokenPrecision))
+
+		_, qaPower := powerForSectors(actor.sectorSize, []*miner.SectorOnChainInfo{sector})
+		expectedPenalty := big.Sum(
+			miner.PledgePenaltyForUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), qaPower),
+			miner.PledgePenaltyForDeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), qaPower).Neg(),
+			miner.BasePenaltyForDisputedWindowPoSt,
+		)
+
+		disputer := tutil.NewIDAddr(t, 200)
+		actor.disputeWindowPoSt(rt, disputer, dlinfo, 0, []*miner.SectorOnChainInfo{sector}, &poStDisputeResult{
+			expectedPowerDelta: actor.claimParamsForSectors([]*miner.SectorOnChainInfo{sector}, false),
+			expectedPenalty:    expectedPenalty,
+			expectedReward:     miner.BaseRewardForDisputedWindowPoSt,
+		})
+
+		// The sector is now faulty.
+		st := getState(rt)
+		_, partition := actor.getDeadlineAndPartition(rt, dlinfo.Index, 0)
+		faulty, err := partition.Faults.IsSet(uint64(sector.SectorNumber))
+		require.NoError(t, err)
+		assert.True(t, faulty)
+		assert.Equal(t, miner.PowerForSectors(actor.sectorSize, []*miner.SectorOnChainInfo{sector}), st.FaultyPower)
+
+		// The proof can't be disputed again.
+		rt.SetCaller(disputer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		expectQueryNetworkInfo(rt, actor)
+		rt.ExpectAbort(exitcode.ErrNotFound, func() {
+			rt.Call(actor.a.DisputeWindowedPoSt, &miner.DisputeWindowedPoStParams{Deadline: dlinfo.Index, PoStIndex: 0})
+		})
+		rt.Verify()
+	})
+
+	t.Run("disputes proof over sectors since terminated", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlinfo := proveOptimistically(rt)
+
+		actor.addLockedFund(rt, big.Mul(big.NewInt(100), abi.TokenPrecision))
+		expectedFee := miner.PledgePenaltyForTermination(sector.InitialPledge, rt.Epoch()-sector.Activation,
+			actor.rewardEstimate(), actor.networkQAPowerEstimate(), miner.QAPowerForSector(actor.sectorSize, sector))
+		actor.terminateSectors(rt, bitfield.NewFromSet([]uint64{uint64(sector.SectorNumber)}), expectedFee)
+
+		// Remove the terminated sector's info too, so the dispute can rely only on the proof's record.
+		st := getState(rt)
+		require.NoError(t, st.DeleteSectors(rt.AdtStore(), bitfield.NewFromSet([]uint64{uint64(sector.SectorNumber)})))
+		rt.ReplaceState(st)
+
+		// The proof is verified against the sector as proven, and the miner is penalized for the invalid proof.
+		disputer := tutil.NewIDAddr(t, 200)
+		actor.disputeWindowPoSt(rt, disputer, dlinfo, 0, []*miner.SectorOnChainInfo{sector}, &poStDisputeResult{
+			expectedPenalty: miner.BasePenaltyForDisputedWindowPoSt,
+			expectedReward:  miner.BaseRewardForDisputedWindowPoSt,
+		})
+	})
+
+	t.Run("cannot dispute after dispute window", func(t *testing.T) {
+		rt := builder.Build(t)
+		_, dlinfo := proveOptimistically(rt)
+
+		rt.SetEpoch(dlinfo.Close + miner.WPoStDisputeWindow)
+		disputer := tutil.NewIDAddr(t, 200)
+		rt.SetCaller(disputer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		expectQueryNetworkInfo(rt, actor)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.DisputeWindowedPoSt, &miner.DisputeWindowedPoStParams{Deadline: dlinfo.Index, PoStIndex: 0})
+		})
+		rt.Verify()
+	})
+
 	//runTillNextDeadline := func(rt *mock.Runtime) (*miner.DeadlineInfo, []*miner.SectorOnChainInfo, []uint64) {
 	//	st := getState(rt)
 	//	deadlines, err := st.LoadDeadlines(rt.AdtStore())
@@ -795,7 +1041,7 @@ func TestWindowPost(t *testing.T) {
 		//pwr := miner.PowerForSectors(actor.sectorSize, infos[:1])
 		//
 		//// expected penalty is the fee for an undeclared fault
-		//expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.epochReward, actor.networkQAPower, pwr.QA)
+		//expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), pwr.QA)
 		//
 		//cfg := &poStConfig{
 		//	expectedRawPowerDelta: pwr.Raw.Neg(),
@@ -823,7 +1069,7 @@ func TestWindowPost(t *testing.T) {
 	//	pwr := miner.PowerForSectors(actor.sectorSize, infos)
 	//
 	//	// expected penalty is the fee for an undeclared fault
-	//	expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.epochReward, actor.networkQAPower, pwr.QA)
+	//	expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), pwr.QA)
 	//
 	//	cfg := &poStConfig{
 	//		skipped:               skipped,
@@ -854,7 +1100,7 @@ func TestWindowPost(t *testing.T) {
 	//	// skip the first sector in the partition
 	//	skipped := bitfield.NewFromSet([]uint64{uint64(infos[0].SectorNumber)})
 	//	// expected penalty is the fee for an undeclared fault
-	//	expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.epochReward, actor.networkQAPower, pwr.QA)
+	//	expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), pwr.QA)
 	//
 	//	cfg := &poStConfig{
 	//		expectedRawPowerDelta: big.Zero(),
@@ -882,7 +1128,7 @@ func TestWindowPost(t *testing.T) {
 	//	// skip the first sector in the partition
 	//	skipped := bitfield.NewFromSet([]uint64{uint64(nextInfos[0].SectorNumber)})
 	//	// expected penalty is the fee for an undeclared fault
-	//	expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.epochReward, actor.networkQAPower, pwr.QA)
+	//	expectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), pwr.QA)
 	//
 	//	cfg := &poStConfig{
 	//		expectedRawPowerDelta: big.Zero(),
@@ -914,7 +1160,7 @@ func TestWindowPost(t *testing.T) {
 	//	pwr := miner.PowerForSectors(actor.sectorSize, append(infos1, infos2...))
 	//
 	//	// expected penalty is the late undeclared fault penalty for all faulted sectors including retracted recoveries..
-	//	expectedPenalty := miner.PledgePenaltyForLateUndeclaredFault(actor.epochReward, actor.networkQAPower, pwr.QA)
+	//	expectedPenalty := miner.PledgePenaltyForLateUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), pwr.QA)
 	//
 	//	cfg := &poStConfig{
 	//		skipped:               abi.NewBitField(),
@@ -1008,124 +1254,492 @@ func TestProveCommit(t *testing.T) {
 	})
 }
 
-func TestProvingPeriodCron(t *testing.T) {
+func TestProveReplicaUpdates(t *testing.T) {
 	periodOffset := abi.ChainEpoch(100)
 	actor := newHarness(t, periodOffset)
 	builder := builderForHarness(actor).
 		WithBalance(bigBalance, big.Zero())
 
-	t.Run("empty periods", func(t *testing.T) {
-		t.Skip("Disabled in miner state refactor #648, restore soon")
+	makeUpdate := func(sector *miner.SectorOnChainInfo, dlinfo *miner.DeadlineInfo, pIdx uint64) miner.ReplicaUpdate {
+		return miner.ReplicaUpdate{
+			SectorNumber:   sector.SectorNumber,
+			Deadline:       dlinfo.Index,
+			Partition:      pIdx,
+			NewSealedCID:   tutil.MakeCID("replica", &miner.SealedCIDPrefix),
+			NewUnsealedCID: tutil.MakeCID("commd", &market.PieceCIDPrefix),
+			Deals:          []abi.DealID{1},
+			ReplicaProof:   []byte{1, 2, 3, 4},
+		}
+	}
+
+	t.Run("updates sector with verified deals", func(t *testing.T) {
 		rt := builder.Build(t)
-		actor.constructAndVerify(rt)
-		st := getState(rt)
-		assert.Equal(t, periodOffset, st.ProvingPeriodStart)
+		sector, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
 
-		// First cron invocation just before the first proving period starts.
-		rt.SetEpoch(periodOffset - 1)
-		secondCronEpoch := periodOffset + miner.WPoStProvingPeriod - 1
-		actor.onDeadlineCron(rt, &cronConfig{
-			expectedEntrollment: secondCronEpoch,
-		})
-		// The proving period start isn't changed, because the period hadn't started yet.
-		st = getState(rt)
-		assert.Equal(t, periodOffset, st.ProvingPeriodStart)
+		// Wait out the dispute window for the proof.
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
 
-		rt.SetEpoch(secondCronEpoch)
-		actor.onDeadlineCron(rt, &cronConfig{
-			expectedEntrollment: periodOffset + 2*miner.WPoStProvingPeriod - 1,
+		update := makeUpdate(sector, dlinfo, pIdx)
+		dealWeight := market.VerifyDealsForActivationReturn{
+			DealWeight:         big.Zero(),
+			VerifiedDealWeight: big.Mul(big.NewIntUnsigned(uint64(actor.sectorSize)), big.NewInt(int64(sector.Expiration-rt.Epoch()))),
+		}
+		expected := actor.proveReplicaUpdate(rt, sector, update, dealWeight)
+
+		updated := actor.getSector(rt, sector.SectorNumber)
+		assert.Equal(t, expected, updated)
+		assert.Equal(t, update.NewSealedCID, updated.SealedCID)
+		assert.Equal(t, update.Deals, updated.DealIDs)
+		assert.True(t, miner.QAPowerForSector(actor.sectorSize, updated).GreaterThan(miner.QAPowerForSector(actor.sectorSize, sector)))
+
+		// The sector keeps its age, and so the termination fee accrued from it.
+		assert.Equal(t, sector.Activation, updated.Activation)
+		assert.Equal(t, rt.Epoch(), updated.ReplicaUpdateEpoch)
+
+		// The partition's power reflects the new sector.
+		_, partition := actor.getDeadlineAndPartition(rt, dlinfo.Index, pIdx)
+		assert.Equal(t, miner.PowerForSectors(actor.sectorSize, []*miner.SectorOnChainInfo{updated}), partition.LivePower)
+
+		// The sector can't be updated again, since it now has deals.
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.worker)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.a.ProveReplicaUpdates, &miner.ProveReplicaUpdatesParams{Updates: []miner.ReplicaUpdate{update}})
 		})
-		// Proving period moves forward
-		st = getState(rt)
-		assert.Equal(t, periodOffset+miner.WPoStProvingPeriod, st.ProvingPeriodStart)
+		rt.Verify()
+		// Terminating the sector charges a fee for its age since its original activation.
+		actor.addLockedFund(rt, big.Mul(big.NewInt(1000), abi.TokenPrecision))
+		expectedFee := miner.PledgePenaltyForTermination(updated.InitialPledge, rt.Epoch()-sector.Activation,
+			actor.rewardEstimate(), actor.networkQAPowerEstimate(), miner.QAPowerForSector(actor.sectorSize, updated))
+		actor.terminateSectors(rt, bitfield.NewFromSet([]uint64{uint64(sector.SectorNumber)}), expectedFee)
 	})
 
-	t.Run("first period gets randomness from previous epoch", func(t *testing.T) {
-		t.Skip("Disabled in miner state refactor #648, restore soon")
+	t.Run("rejects update while deadline is open to disputes", func(t *testing.T) {
 		rt := builder.Build(t)
-		actor.constructAndVerify(rt)
-		//st := getState(rt)
+		sector, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
 
-		//sectorInfo := actor.commitAndProveSectors(rt, 1, 100, nil)
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.worker)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.ProveReplicaUpdates, &miner.ProveReplicaUpdatesParams{
+				Updates: []miner.ReplicaUpdate{makeUpdate(sector, dlinfo, pIdx)},
+			})
+		})
+		rt.Verify()
+	})
 
-		// Flag new sectors to trigger request for randomness
-		//rt.Transaction(st, func() interface{} {
-		//	st.NewSectors.Set(uint64(sectorInfo[0].SectorNumber))
-		//	return nil
-		//})
+	t.Run("rejects update without deals", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
 
-		// First cron invocation just before the first proving period starts
-		// requires randomness come from current epoch minus lookback
-		rt.SetEpoch(periodOffset - 1)
-		secondCronEpoch := periodOffset + miner.WPoStProvingPeriod - 1
-		actor.onDeadlineCron(rt, &cronConfig{
-			expectedEntrollment: secondCronEpoch,
-			newSectors:          true,
+		update := makeUpdate(sector, dlinfo, pIdx)
+		update.Deals = nil
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.worker)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.a.ProveReplicaUpdates, &miner.ProveReplicaUpdatesParams{Updates: []miner.ReplicaUpdate{update}})
 		})
+		rt.Verify()
+	})
+}
 
-		// cron invocation after the proving period starts, requires randomness come from end of proving period
-		rt.SetEpoch(periodOffset)
-		actor.advanceProvingPeriodWithoutFaults(rt)
-
-		// triggers a new request for randomness
-		// TODO minerstate
-		//rt.Transaction(st, func() interface{} {
-		//	st.NewSectors.Set(uint64(sectorInfo[0].SectorNumber))
-		//	return nil
-		//})
+func TestMovePartitions(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
 
-		thirdCronEpoch := secondCronEpoch + miner.WPoStProvingPeriod
-		actor.onDeadlineCron(rt, &cronConfig{
-			expectedEntrollment: thirdCronEpoch,
-			newSectors:          true,
+	movePartitions := func(rt *mock.Runtime, orig, dest uint64, partitions ...uint64) {
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.worker)
+		rt.Call(actor.a.MovePartitions, &miner.MovePartitionsParams{
+			OrigDeadline: orig,
+			DestDeadline: dest,
+			Partitions:   bitfield.NewFromSet(partitions),
 		})
-	})
+		rt.Verify()
+	}
 
-	t.Run("detects and penalizes faults", func(t *testing.T) {
-		t.Skip("Disabled in miner state refactor #648, restore soon")
+	t.Run("moves proven partition to another deadline", func(t *testing.T) {
 		rt := builder.Build(t)
-		actor.constructAndVerify(rt)
+		sector, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
 
-		allSectors := actor.commitAndProveSectors(rt, 2, 100, nil)
+		destIdx := actor.deadline(rt).Index + 5
+		movePartitions(rt, dlinfo.Index, destIdx, pIdx)
 
-		// advance to end of proving period to add sectors to proving set
 		st := getState(rt)
-		deadline := st.DeadlineInfo(rt.Epoch())
-		nextCron := deadline.NextPeriodStart() + miner.WPoStProvingPeriod - 1
-		rt.SetEpoch(deadline.PeriodEnd())
-		actor.onDeadlineCron(rt, &cronConfig{
-			expectedEntrollment: nextCron,
-			newSectors:          true,
-		})
+		dlIdx, newPIdx, err := st.FindSector(rt.AdtStore(), sector.SectorNumber)
+		require.NoError(t, err)
+		assert.Equal(t, destIdx, dlIdx)
+		assert.Equal(t, uint64(0), newPIdx)
 
-		// advance to next deadline where we expect the first sectors to appear
-		st = getState(rt)
-		deadline = st.DeadlineInfo(rt.Epoch() + 1)
-		rt.SetEpoch(deadline.NextOpen())
-		deadline = st.DeadlineInfo(rt.Epoch())
+		orig := actor.getDeadline(rt, dlinfo.Index)
+		partitions, err := orig.PartitionsArray(rt.AdtStore())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), partitions.Length())
+		assert.Equal(t, uint64(0), orig.LiveSectors)
+		assert.Equal(t, uint64(0), orig.TotalSectors)
 
-		// Skip to end of proving period, cron detects all sectors as faulty
-		rt.SetEpoch(deadline.PeriodEnd())
-		nextCron = deadline.NextPeriodStart() + miner.WPoStProvingPeriod - 1
+		dest := actor.getDeadline(rt, destIdx)
+		assert.Equal(t, uint64(1), dest.LiveSectors)
+		assert.Equal(t, uint64(1), dest.TotalSectors)
 
-		// Undetected faults penalized once as a late undetected fault
-		rawPower, qaPower := powerForSectors(actor.sectorSize, allSectors)
-		undetectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.epochReward, actor.networkQAPower, qaPower)
+		// The sector's expiration is indexed at the destination.
+		expirations, err := miner.LoadBitfieldQueue(rt.AdtStore(), dest.ExpirationsEpochs, st.QuantEndOfDeadline())
+		require.NoError(t, err)
+		var expiringPartitions []uint64
+		err = expirations.ForEach(func(epoch abi.ChainEpoch, bf *bitfield.BitField) error {
+			assert.Equal(t, st.QuantEndOfDeadline().QuantizeUp(sector.Expiration), epoch)
+			expiringPartitions, err = bf.All(miner.AddressedPartitionsMax)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{0}, expiringPartitions)
 
-		// power for sectors is removed
-		powerDeltaClaim := &power.UpdateClaimedPowerParams{
-			RawByteDelta:         rawPower.Neg(),
-			QualityAdjustedDelta: qaPower.Neg(),
+		// The partition is now proven at its new deadline.
+		dlinfo = actor.deadline(rt)
+		for dlinfo.Index != destIdx {
+			advanceDeadline(rt, actor, &cronConfig{})
+			dlinfo = actor.deadline(rt)
 		}
+		partitionsToProve := []miner.PoStPartition{{Index: 0, Skipped: abi.NewBitField()}}
+		actor.submitWindowPoSt(rt, dlinfo, partitionsToProve, []*miner.SectorOnChainInfo{sector}, nil)
+		advanceDeadline(rt, actor, &cronConfig{})
 
-		// Faults are charged again as ongoing faults
-		ongoingPenalty := miner.PledgePenaltyForDeclaredFault(actor.epochReward, actor.networkQAPower, qaPower)
+		_, partition := actor.getDeadlineAndPartition(rt, destIdx, 0)
+		assertEmptyBitfield(t, partition.Faults)
+	})
 
-		actor.onDeadlineCron(rt, &cronConfig{
-			expectedEntrollment:        nextCron,
-			undetectedFaultsPenalty:    &undetectedPenalty,
-			undetectedFaultsPowerDelta: powerDeltaClaim,
-			ongoingFaultsPenalty:       &ongoingPenalty,
+	t.Run("rejects move while original deadline is open to disputes", func(t *testing.T) {
+		rt := builder.Build(t)
+		_, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			movePartitions(rt, dlinfo.Index, dlinfo.Index+10, pIdx)
+		})
+	})
+
+	t.Run("rejects move from deadline not yet proven this period", func(t *testing.T) {
+		rt := builder.Build(t)
+		_, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
+
+		current := actor.deadline(rt).Index
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			movePartitions(rt, current+5, dlinfo.Index, pIdx)
+		})
+	})
+
+	t.Run("rejects move to immutable deadline", func(t *testing.T) {
+		rt := builder.Build(t)
+		_, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
+
+		next := actor.deadline(rt).Index + 1
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			movePartitions(rt, dlinfo.Index, next, pIdx)
+		})
+	})
+
+	t.Run("rejects move to deadline next due after the original", func(t *testing.T) {
+		rt := builder.Build(t)
+		_, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
+
+		// The destination has also passed this period, so is next due after the original deadline next period.
+		current := actor.deadline(rt).Index
+		dest := dlinfo.Index + 1
+		require.True(t, dest < current)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			movePartitions(rt, dlinfo.Index, dest, pIdx)
+		})
+	})
+}
+
+func TestCompactSectorNumbers(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	compactSectorNumbers := func(rt *mock.Runtime, dlIdx, pIdx uint64, first abi.SectorNumber) {
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.Call(actor.a.CompactSectorNumbers, &miner.CompactSectorNumbersParams{
+			Deadline:          dlIdx,
+			Partition:         pIdx,
+			FirstSectorNumber: first,
+		})
+		rt.Verify()
+	}
+
+	t.Run("renumbers live sectors", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
+
+		compactSectorNumbers(rt, dlinfo.Index, pIdx, 1000)
+
+		st := getState(rt)
+		_, found, err := st.GetSector(rt.AdtStore(), sector.SectorNumber)
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		renumbered := actor.getSector(rt, 1000)
+		assert.Equal(t, sector.SectorNumber, renumbered.SealedSectorNumber)
+		assert.Equal(t, sector.SealedCID, renumbered.SealedCID)
+		assert.Equal(t, sector.Expiration, renumbered.Expiration)
+
+		dlIdx, newPIdx, err := st.FindSector(rt.AdtStore(), 1000)
+		require.NoError(t, err)
+		assert.Equal(t, dlinfo.Index, dlIdx)
+		assert.Equal(t, pIdx, newPIdx)
+
+		// The new number is allocated, and the old one is never released.
+		allocated, err := st.AllocateSectorNumber(rt.AdtStore(), 1000)
+		require.NoError(t, err)
+		assert.False(t, allocated)
+		allocated, err = st.AllocateSectorNumber(rt.AdtStore(), sector.SectorNumber)
+		require.NoError(t, err)
+		assert.False(t, allocated)
+
+		// The sector can be terminated by its new number.
+		actor.addLockedFund(rt, big.Mul(big.NewInt(1000), abi.TokenPrecision))
+		expectedFee := miner.PledgePenaltyForTermination(renumbered.InitialPledge, rt.Epoch()-renumbered.Activation,
+			actor.rewardEstimate(), actor.networkQAPowerEstimate(), miner.QAPowerForSector(actor.sectorSize, renumbered))
+		ret := actor.terminateSectors(rt, bitfield.NewFromSet([]uint64{1000}), expectedFee)
+		require.Len(t, ret.Report.Sectors, 1)
+		assert.Equal(t, abi.SectorNumber(1000), ret.Report.Sectors[0].SectorNumber)
+	})
+
+	t.Run("prunes terminated sectors and proves renumbered sectors by sealed number", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectors := actor.commitAndProveSectors(rt, 2, 100, nil)
+		kept, terminated := sectors[0], sectors[1]
+
+		st := getState(rt)
+		dlIdx, pIdx, err := st.FindSector(rt.AdtStore(), kept.SectorNumber)
+		require.NoError(t, err)
+		otherDlIdx, otherPIdx, err := st.FindSector(rt.AdtStore(), terminated.SectorNumber)
+		require.NoError(t, err)
+		require.Equal(t, dlIdx, otherDlIdx)
+		require.Equal(t, pIdx, otherPIdx)
+
+		// Prove the partition, then wait until its proof can no longer be disputed.
+		dlinfo := actor.deadline(rt)
+		for dlinfo.Index != dlIdx {
+			advanceDeadline(rt, actor, &cronConfig{})
+			dlinfo = actor.deadline(rt)
+		}
+		partitions := []miner.PoStPartition{{Index: pIdx, Skipped: abi.NewBitField()}}
+		actor.submitWindowPoSt(rt, dlinfo, partitions, sectors, nil)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
+
+		actor.addLockedFund(rt, big.Mul(big.NewInt(1000), abi.TokenPrecision))
+		expectedFee := miner.PledgePenaltyForTermination(terminated.InitialPledge, rt.Epoch()-terminated.Activation,
+			actor.rewardEstimate(), actor.networkQAPowerEstimate(), miner.QAPowerForSector(actor.sectorSize, terminated))
+		actor.terminateSectors(rt, bitfield.NewFromSet([]uint64{uint64(terminated.SectorNumber)}), expectedFee)
+
+		compactSectorNumbers(rt, dlIdx, pIdx, 1000)
+
+		// The terminated sector's info is pruned along with the kept sector's old number.
+		st = getState(rt)
+		for _, sectorNo := range []abi.SectorNumber{kept.SectorNumber, terminated.SectorNumber} {
+			_, found, err := st.GetSector(rt.AdtStore(), sectorNo)
+			require.NoError(t, err)
+			assert.False(t, found)
+		}
+		deadline := actor.getDeadline(rt, dlIdx)
+		assert.Equal(t, uint64(1), deadline.TotalSectors)
+
+		// The renumbered sector is proven with the number it was sealed with.
+		renumbered := actor.getSector(rt, 1000)
+		require.Equal(t, kept.SectorNumber, renumbered.SealedSectorNumber)
+		dlinfo = actor.deadline(rt)
+		for dlinfo.Index != dlIdx {
+			advanceDeadline(rt, actor, &cronConfig{})
+			dlinfo = actor.deadline(rt)
+		}
+		actor.submitWindowPoSt(rt, dlinfo, partitions, []*miner.SectorOnChainInfo{renumbered}, nil)
+		_, partition := actor.getDeadlineAndPartition(rt, dlIdx, pIdx)
+		assertBitfieldEquals(t, partition.Sectors, 1000)
+		assertEmptyBitfield(t, partition.Faults)
+	})
+
+	t.Run("rejects new numbers already allocated", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+		for rt.Epoch() < dlinfo.Close+miner.WPoStDisputeWindow {
+			advanceDeadline(rt, actor, &cronConfig{})
+		}
+
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			compactSectorNumbers(rt, dlinfo.Index, pIdx, sector.SectorNumber)
+		})
+	})
+
+	t.Run("rejects renumbering while deadline is open to disputes", func(t *testing.T) {
+		rt := builder.Build(t)
+		_, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			compactSectorNumbers(rt, dlinfo.Index, pIdx, 1000)
+		})
+	})
+
+	t.Run("rejects caller other than owner", func(t *testing.T) {
+		rt := builder.Build(t)
+		_, dlinfo, pIdx := actor.commitAndProveDeadline(rt)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.CompactSectorNumbers, &miner.CompactSectorNumbersParams{
+				Deadline:          dlinfo.Index,
+				Partition:         pIdx,
+				FirstSectorNumber: 1000,
+			})
+		})
+	})
+}
+
+func TestProvingPeriodCron(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	t.Run("empty periods", func(t *testing.T) {
+		t.Skip("Disabled in miner state refactor #648, restore soon")
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		st := getState(rt)
+		assert.Equal(t, periodOffset, st.ProvingPeriodStart)
+
+		// First cron invocation just before the first proving period starts.
+		rt.SetEpoch(periodOffset - 1)
+		secondCronEpoch := periodOffset + miner.WPoStProvingPeriod - 1
+		actor.onDeadlineCron(rt, &cronConfig{
+			expectedEntrollment: secondCronEpoch,
+		})
+		// The proving period start isn't changed, because the period hadn't started yet.
+		st = getState(rt)
+		assert.Equal(t, periodOffset, st.ProvingPeriodStart)
+
+		rt.SetEpoch(secondCronEpoch)
+		actor.onDeadlineCron(rt, &cronConfig{
+			expectedEntrollment: periodOffset + 2*miner.WPoStProvingPeriod - 1,
+		})
+		// Proving period moves forward
+		st = getState(rt)
+		assert.Equal(t, periodOffset+miner.WPoStProvingPeriod, st.ProvingPeriodStart)
+	})
+
+	t.Run("first period gets randomness from previous epoch", func(t *testing.T) {
+		t.Skip("Disabled in miner state refactor #648, restore soon")
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		//st := getState(rt)
+
+		//sectorInfo := actor.commitAndProveSectors(rt, 1, 100, nil)
+
+		// Flag new sectors to trigger request for randomness
+		//rt.Transaction(st, func() interface{} {
+		//	st.NewSectors.Set(uint64(sectorInfo[0].SectorNumber))
+		//	return nil
+		//})
+
+		// First cron invocation just before the first proving period starts
+		// requires randomness come from current epoch minus lookback
+		rt.SetEpoch(periodOffset - 1)
+		secondCronEpoch := periodOffset + miner.WPoStProvingPeriod - 1
+		actor.onDeadlineCron(rt, &cronConfig{
+			expectedEntrollment: secondCronEpoch,
+			newSectors:          true,
+		})
+
+		// cron invocation after the proving period starts, requires randomness come from end of proving period
+		rt.SetEpoch(periodOffset)
+		actor.advanceProvingPeriodWithoutFaults(rt)
+
+		// triggers a new request for randomness
+		// TODO minerstate
+		//rt.Transaction(st, func() interface{} {
+		//	st.NewSectors.Set(uint64(sectorInfo[0].SectorNumber))
+		//	return nil
+		//})
+
+		thirdCronEpoch := secondCronEpoch + miner.WPoStProvingPeriod
+		actor.onDeadlineCron(rt, &cronConfig{
+			expectedEntrollment: thirdCronEpoch,
+			newSectors:          true,
+		})
+	})
+
+	t.Run("detects and penalizes faults", func(t *testing.T) {
+		t.Skip("Disabled in miner state refactor #648, restore soon")
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		allSectors := actor.commitAndProveSectors(rt, 2, 100, nil)
+
+		// advance to end of proving period to add sectors to proving set
+		st := getState(rt)
+		deadline := st.DeadlineInfo(rt.Epoch())
+		nextCron := deadline.NextPeriodStart() + miner.WPoStProvingPeriod - 1
+		rt.SetEpoch(deadline.PeriodEnd())
+		actor.onDeadlineCron(rt, &cronConfig{
+			expectedEntrollment: nextCron,
+			newSectors:          true,
+		})
+
+		// advance to next deadline where we expect the first sectors to appear
+		st = getState(rt)
+		deadline = st.DeadlineInfo(rt.Epoch() + 1)
+		rt.SetEpoch(deadline.NextOpen())
+		deadline = st.DeadlineInfo(rt.Epoch())
+
+		// Skip to end of proving period, cron detects all sectors as faulty
+		rt.SetEpoch(deadline.PeriodEnd())
+		nextCron = deadline.NextPeriodStart() + miner.WPoStProvingPeriod - 1
+
+		// Undetected faults penalized once as a late undetected fault
+		rawPower, qaPower := powerForSectors(actor.sectorSize, allSectors)
+		undetectedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), qaPower)
+
+		// power for sectors is removed
+		powerDeltaClaim := &power.UpdateClaimedPowerParams{
+			RawByteDelta:         rawPower.Neg(),
+			QualityAdjustedDelta: qaPower.Neg(),
+		}
+
+		// Faults are charged again as ongoing faults
+		ongoingPenalty := miner.PledgePenaltyForDeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), qaPower)
+
+		actor.onDeadlineCron(rt, &cronConfig{
+			expectedEntrollment:        nextCron,
+			undetectedFaultsPenalty:    &undetectedPenalty,
+			undetectedFaultsPowerDelta: powerDeltaClaim,
+			ongoingFaultsPenalty:       &ongoingPenalty,
 		})
 
 		// expect both faults are added to state
@@ -1150,11 +1764,11 @@ func TestProvingPeriodCron(t *testing.T) {
 
 		// Retracted recovery is penalized as an undetected fault, but power is unchanged
 		_, retractedQAPower := powerForSectors(actor.sectorSize, allSectors[1:])
-		retractedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.epochReward, actor.networkQAPower, retractedQAPower)
+		retractedPenalty := miner.PledgePenaltyForUndeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), retractedQAPower)
 
 		// Faults are charged again as ongoing faults
 		_, faultQAPower := powerForSectors(actor.sectorSize, allSectors)
-		ongoingPenalty = miner.PledgePenaltyForDeclaredFault(actor.epochReward, actor.networkQAPower, faultQAPower)
+		ongoingPenalty = miner.PledgePenaltyForDeclaredFault(actor.rewardEstimate(), actor.networkQAPowerEstimate(), faultQAPower)
 
 		actor.onDeadlineCron(rt, &cronConfig{
 			expectedEntrollment:     nextCron,
@@ -1188,7 +1802,7 @@ func TestDeclareFaults(t *testing.T) {
 		require.NoError(t, err)
 		sectorQAPower := miner.QAPowerForSector(ss, info)
 		totalQAPower := big.NewInt(1 << 52)
-		fee := miner.PledgePenaltyForDeclaredFault(actor.epochReward, totalQAPower, sectorQAPower)
+		fee := miner.PledgePenaltyForDeclaredFault(actor.rewardEstimate(), smoothing.NewEstimate(totalQAPower, big.Zero()), sectorQAPower)
 
 		actor.declareFaults(rt, totalQAPower, fee, info)
 	})
@@ -1326,11 +1940,11 @@ func TestExtendSectorExpiration(t *testing.T) {
 }
 
 func TestTerminateSectors(t *testing.T) {
-	//periodOffset := abi.ChainEpoch(100)
-	//actor := newHarness(t, periodOffset)
-	//builder := builderForHarness(actor).
-	//	WithBalance(bigBalance, big.Zero())
-	//
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
 	//commitSector := func(t *testing.T, rt *mock.Runtime) *miner.SectorOnChainInfo {
 	//	actor.constructAndVerify(rt)
 	//	precommitEpoch := abi.ChainEpoch(1)
@@ -1339,6 +1953,97 @@ func TestTerminateSectors(t *testing.T) {
 	//	return sectorInfo[0]
 	//}
 
+	t.Run("reports penalty, pledge, power and deals for terminated sectors", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectorInfos := actor.commitAndProveSectors(rt, 2, 100, [][]abi.DealID{{10}, {20, 21}})
+
+		// Lock enough funds to cover the penalties.
+		actor.addLockedFund(rt, big.Mul(big.NewInt(1000), abi.TokenPrecision))
+
+		rt.SetEpoch(rt.Epoch() + 100)
+		expectedPenalties := make([]abi.TokenAmount, len(sectorInfos))
+		expectedFee := big.Zero()
+		for i, sector := range sectorInfos {
+			sectorPower := miner.QAPowerForSector(actor.sectorSize, sector)
+			expectedPenalties[i] = miner.PledgePenaltyForTermination(sector.InitialPledge, rt.Epoch()-sector.Activation,
+				actor.rewardEstimate(), actor.networkQAPowerEstimate(), sectorPower)
+			expectedFee = big.Add(expectedFee, expectedPenalties[i])
+		}
+
+		sectors := bitfield.NewFromSet([]uint64{uint64(sectorInfos[0].SectorNumber), uint64(sectorInfos[1].SectorNumber)})
+		ret := actor.terminateSectors(rt, sectors, expectedFee)
+
+		assert.True(t, ret.Done)
+		expectedPower := miner.PowerForSectors(actor.sectorSize, sectorInfos)
+		assert.Equal(t, expectedPower, ret.PowerRemoved)
+		assert.Equal(t, expectedPower, ret.Report.Power)
+		assert.Equal(t, expectedFee, ret.Report.PenaltyCharged)
+		assert.Equal(t, big.Add(sectorInfos[0].InitialPledge, sectorInfos[1].InitialPledge), ret.Report.PledgeReleased)
+
+		require.Len(t, ret.Report.Sectors, 2)
+		for i, sector := range sectorInfos {
+			assert.Equal(t, miner.SectorTerminationReport{
+				SectorNumber: sector.SectorNumber,
+				Epoch:        rt.Epoch(),
+				Penalty:      expectedPenalties[i],
+				Pledge:       sector.InitialPledge,
+				Power:        miner.PowerForSector(actor.sectorSize, sector),
+				Deals:        sector.DealIDs,
+			}, ret.Report.Sectors[i])
+		}
+
+		st := getState(rt)
+		assert.Equal(t, big.Zero(), st.InitialPledgeRequirement)
+	})
+
+	t.Run("processes terminated sectors immediately", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sector := actor.commitAndProveSectors(rt, 1, 100, nil)[0]
+		actor.addLockedFund(rt, big.Mul(big.NewInt(1000), abi.TokenPrecision))
+
+		st := getState(rt)
+		dlIdx, _, err := st.FindSector(rt.AdtStore(), sector.SectorNumber)
+		require.NoError(t, err)
+
+		rt.SetEpoch(rt.Epoch() + 100)
+		sectorPower := miner.QAPowerForSector(actor.sectorSize, sector)
+		expectedFee := miner.PledgePenaltyForTermination(sector.InitialPledge, rt.Epoch()-sector.Activation,
+			actor.rewardEstimate(), actor.networkQAPowerEstimate(), sectorPower)
+		ret := actor.terminateSectors(rt, bitfield.NewFromSet([]uint64{uint64(sector.SectorNumber)}), expectedFee)
+		assert.True(t, ret.Done)
+		require.Len(t, ret.Report.Sectors, 1)
+		assert.Equal(t, sector.SectorNumber, ret.Report.Sectors[0].SectorNumber)
+
+		// The deadline was flagged with early terminations, so they were processed and nothing remains queued.
+		st = getState(rt)
+		assertEmptyBitfield(t, st.EarlyTerminations)
+		assertEmptyBitfield(t, actor.getDeadline(rt, dlIdx).EarlyTerminations)
+	})
+
+	t.Run("notifies market of all terminated deals once", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectorInfos := actor.commitAndProveSectors(rt, 3, 100, [][]abi.DealID{{10}, {20, 21}, {30, 31, 32}})
+		actor.addLockedFund(rt, big.Mul(big.NewInt(1000), abi.TokenPrecision))
+
+		rt.SetEpoch(rt.Epoch() + 100)
+		expectedFee := big.Zero()
+		sectors := abi.NewBitField()
+		for _, sector := range sectorInfos {
+			sectorPower := miner.QAPowerForSector(actor.sectorSize, sector)
+			expectedFee = big.Add(expectedFee, miner.PledgePenaltyForTermination(sector.InitialPledge,
+				rt.Epoch()-sector.Activation, actor.rewardEstimate(), actor.networkQAPowerEstimate(), sectorPower))
+			sectors.Set(uint64(sector.SectorNumber))
+		}
+
+		// The harness expects a single OnMinerSectorsTerminate send listing every deal; any further send fails.
+		ret := actor.terminateSectors(rt, sectors, expectedFee)
+		assert.True(t, ret.Done)
+		assert.Len(t, ret.Report.Sectors, 3)
+	})
+
 	// TODO minerstate
 	//t.Run("removes sector with correct accounting", func(t *testing.T) {
 	//	rt := builder.Build(t)
@@ -1365,7 +2070,7 @@ func TestTerminateSectors(t *testing.T) {
 	//	require.NoError(t, err)
 	//	sectorPower := miner.QAPowerForSector(sectorSize, sector)
 	//	sectorAge := rt.Epoch() - sector.Activation
-	//	expectedFee := miner.PledgePenaltyForTermination(sector.InitialPledge, sectorAge, actor.epochReward, actor.networkQAPower, sectorPower)
+	//	expectedFee := miner.PledgePenaltyForTermination(sector.InitialPledge, sectorAge, actor.rewardEstimate(), actor.networkQAPowerEstimate(), sectorPower)
 	//
 	//	sectors := bitfield.New()
 	//	sectors.Set(uint64(sector.SectorNumber))
@@ -1395,66 +2100,672 @@ func TestTerminateSectors(t *testing.T) {
 	//})
 }
 
+func TestStateView(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	loadView := func(rt *mock.Runtime) *miner.StateView {
+		view, err := miner.LoadStateView(rt.AdtStore(), rt.StateRoot())
+		require.NoError(t, err)
+		return view
+	}
+
+	t.Run("reports sector location, status and next post", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlInfo, pIdx := actor.commitAndProveDeadline(rt)
+		view := loadView(rt)
+
+		summary, found, err := view.Sector(sector.SectorNumber)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, *sector, summary.Info)
+		assert.Equal(t, dlInfo.Index, summary.Deadline)
+		assert.Equal(t, pIdx, summary.Partition)
+		assert.Equal(t, miner.SectorHealthy, summary.Status)
+		assert.Equal(t, miner.QAPowerForSector(actor.sectorSize, sector), summary.QAPower)
+		assert.Equal(t, miner.ExpectedDayRewardForPower(actor.rewardEstimate(), actor.networkQAPowerEstimate(), summary.QAPower),
+			summary.ExpectedDayReward(actor.rewardEstimate(), actor.networkQAPowerEstimate()))
+
+		// The deadline just proven next opens one proving period later.
+		next, err := view.NextPoSt(summary.Deadline, rt.Epoch())
+		require.NoError(t, err)
+		assert.Equal(t, dlInfo.Open+miner.WPoStProvingPeriod, next.Open)
+		assert.False(t, next.HasElapsed())
+
+		_, err = view.NextPoSt(miner.WPoStPeriodDeadlines, rt.Epoch())
+		assert.Error(t, err)
+	})
+
+	t.Run("reports faulty sector", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlInfo, pIdx := actor.commitAndProveDeadline(rt)
+
+		rawPower, qaPower := powerForSectors(actor.sectorSize, []*miner.SectorOnChainInfo{sector})
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.worker)
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, &power.UpdateClaimedPowerParams{
+			RawByteDelta:         rawPower.Neg(),
+			QualityAdjustedDelta: qaPower.Neg(),
+		}, big.Zero(), nil, exitcode.Ok)
+		rt.Call(actor.a.DeclareFaults, &miner.DeclareFaultsParams{Faults: []miner.FaultDeclaration{{
+			Deadline:  dlInfo.Index,
+			Partition: pIdx,
+			Sectors:   bitfield.NewFromSet([]uint64{uint64(sector.SectorNumber)}),
+		}}})
+		rt.Verify()
+
+		summary, found, err := loadView(rt).Sector(sector.SectorNumber)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, miner.SectorFaulty, summary.Status)
+
+		// The faulty sector need not be proven.
+		schedule, err := loadView(rt).ProvingSchedule(rt.Epoch(), int(miner.WPoStPeriodDeadlines))
+		require.NoError(t, err)
+		for _, entry := range schedule {
+			assert.Equal(t, uint64(0), entry.SectorCount)
+		}
+	})
+
+	t.Run("projects proving schedule", func(t *testing.T) {
+		rt := builder.Build(t)
+		sector, dlInfo, pIdx := actor.commitAndProveDeadline(rt)
+		view := loadView(rt)
+
+		// A full proving period of windows, plus the first again.
+		count := int(miner.WPoStPeriodDeadlines) + 1
+		schedule, err := view.ProvingSchedule(rt.Epoch(), count)
+		require.NoError(t, err)
+		require.Len(t, schedule, count)
+
+		assert.Equal(t, actor.deadline(rt).Open, schedule[0].Info.Open)
+		assert.Equal(t, schedule[0].Info.Index, schedule[count-1].Info.Index)
+		assert.Equal(t, schedule[0].Info.Open+miner.WPoStProvingPeriod, schedule[count-1].Info.Open)
+		for i, entry := range schedule {
+			assert.False(t, entry.Info.HasElapsed())
+			if i > 0 {
+				assert.Equal(t, schedule[i-1].Info.Close, entry.Info.Open)
+			}
+			if entry.Info.Index != dlInfo.Index {
+				assert.Empty(t, entry.Partitions)
+				assert.Equal(t, uint64(0), entry.SectorCount)
+				continue
+			}
+			require.Len(t, entry.Partitions, 1)
+			assert.Equal(t, pIdx, entry.Partitions[0].Index)
+			assert.Equal(t, uint64(1), entry.SectorCount)
+			set, err := entry.Partitions[0].Sectors.IsSet(uint64(sector.SectorNumber))
+			require.NoError(t, err)
+			assert.True(t, set)
+		}
+
+		// Windows that elapsed before cron ran are skipped.
+		late := actor.deadline(rt).Close + 1
+		schedule, err = view.ProvingSchedule(late, 1)
+		require.NoError(t, err)
+		require.Len(t, schedule, 1)
+		assert.True(t, schedule[0].Info.IsOpen())
+		assert.Equal(t, late, schedule[0].Info.CurrentEpoch)
+	})
+
+	t.Run("unknown sector is not found", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		view := loadView(rt)
+
+		_, found, err := view.Sector(100)
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		info, err := view.Info()
+		require.NoError(t, err)
+		assert.Equal(t, actor.owner, info.Owner)
+
+		sectors, next, done, err := view.Sectors(0, abi.MaxSectorNumber, 10)
+		require.NoError(t, err)
+		assert.Empty(t, sectors)
+		assert.Equal(t, abi.SectorNumber(abi.MaxSectorNumber), next)
+		assert.True(t, done)
+	})
+}
+
+func TestShutdownMiner(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	t.Run("deletes miner without sectors or locked funds immediately", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		actor.shutdownMiner(rt, nil, big.Zero(), true)
+	})
+
+	t.Run("terminates sectors and deletes miner once locked funds vest", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectorInfos := actor.commitAndProveSectors(rt, 2, 100, [][]abi.DealID{{10}, {20, 21}})
+
+		// Lock enough funds to cover the penalties, leaving some to vest.
+		actor.addLockedFund(rt, big.Mul(big.NewInt(1000), abi.TokenPrecision))
+
+		rt.SetEpoch(rt.Epoch() + 100)
+		expectedFee := big.Zero()
+		for _, sector := range sectorInfos {
+			sectorPower := miner.QAPowerForSector(actor.sectorSize, sector)
+			expectedFee = big.Add(expectedFee, miner.PledgePenaltyForTermination(sector.InitialPledge,
+				rt.Epoch()-sector.Activation, actor.rewardEstimate(), actor.networkQAPowerEstimate(), sectorPower))
+		}
+		actor.shutdownMiner(rt, sectorInfos, expectedFee, false)
+
+		st := getState(rt)
+		assert.Equal(t, rt.Epoch(), st.ShutdownEpoch)
+		assert.Equal(t, big.Zero(), st.InitialPledgeRequirement)
+		for _, sector := range sectorInfos {
+			dlIdx, pIdx, err := st.FindSector(rt.AdtStore(), sector.SectorNumber)
+			require.NoError(t, err)
+			status, err := st.SectorStatus(rt.AdtStore(), dlIdx, pIdx, sector.SectorNumber)
+			require.NoError(t, err)
+			assert.Equal(t, miner.SectorTerminated, status)
+		}
+		remaining := st.LockedFunds
+		require.True(t, remaining.GreaterThan(big.Zero()))
+
+		// No new sectors may be committed, and the miner can't be shut down again.
+		expiration := actor.deadline(rt).PeriodEnd() + 100*miner.WPoStProvingPeriod
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.worker)
+		expectQueryNetworkInfo(rt, actor)
+		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.VerifyDealsForActivation,
+			&market.VerifyDealsForActivationParams{DealIDs: nil, SectorStart: rt.Epoch(), SectorExpiry: expiration},
+			big.Zero(), &market.VerifyDealsForActivationReturn{DealWeight: big.Zero(), VerifiedDealWeight: big.Zero()}, exitcode.Ok)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.PreCommitSector, actor.makePreCommit(100, rt.Epoch()-1, expiration, nil))
+		})
+		rt.Reset()
+
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.ShutdownMiner, nil)
+		})
+		rt.Reset()
+
+		// Once the locked funds have vested, the deadline cron deletes the miner rather than re-enrolling.
+		// Funds were locked under both the pledge and reward vesting schedules, of which the pledge schedule is longer.
+		rt.SetEpoch(rt.Epoch() + miner.PledgeVestingSpec.InitialDelay + miner.PledgeVestingSpec.VestPeriod + builtin.EpochsInDay)
+		rt.ExpectValidateCallerAddr(builtin.StoragePowerActorAddr)
+		expectQueryNetworkInfo(rt, actor)
+		vested := remaining.Neg()
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &vested, big.Zero(), nil, exitcode.Ok)
+		// The cron advances the miner's deadline before deleting it.
+		st = getState(rt)
+		actor.expectDeleteMiner(rt, miner.NewDeadlineInfo(st.ProvingPeriodStart, st.CurrentDeadline+1, rt.Epoch()))
+		rt.ExpectDeleteActor(actor.owner)
+		rt.SetCaller(builtin.StoragePowerActorAddr, builtin.StoragePowerActorCodeID)
+		rt.Call(actor.a.OnDeferredCronEvent, &miner.CronEventPayload{
+			EventType: miner.CronEventProvingDeadline,
+		})
+		rt.Verify()
+	})
+
+	t.Run("terminates sectors in excess of AddressedSectorsMax in cron", func(t *testing.T) {
+		rt := builderForHarness(actor).
+			WithBalance(big.Zero(), big.Zero()).
+			Build(t)
+		actor.constructAndVerify(rt)
+
+		// Place the sectors directly in state rather than committing each one.
+		expiration := actor.deadline(rt).PeriodEnd() + 100*miner.WPoStProvingPeriod
+		sectors := make([]*miner.SectorOnChainInfo, miner.AddressedSectorsMax+10)
+		for i := range sectors {
+			sectors[i] = &miner.SectorOnChainInfo{
+				SectorNumber:       abi.SectorNumber(i),
+				SealProof:          actor.sealProofType,
+				SealedCID:          tutil.MakeCID(fmt.Sprintf("commr-%d", i), &miner.SealedCIDPrefix),
+				Activation:         rt.Epoch(),
+				Expiration:         expiration,
+				DealWeight:         big.Zero(),
+				VerifiedDealWeight: big.Zero(),
+				InitialPledge:      big.Zero(),
+			}
+		}
+		st := getState(rt)
+		require.NoError(t, st.PutSectors(rt.AdtStore(), sectors...))
+		_, err := st.AssignSectorsToDeadlines(rt.AdtStore(), rt.Epoch(), sectors, actor.partitionSize, actor.sectorSize, st.QuantEndOfDeadline())
+		require.NoError(t, err)
+		rt.ReplaceState(st)
+
+		// The first AddressedSectorsMax sectors are terminated immediately, and the rest deferred to cron.
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower,
+			actor.claimParamsForSectors(sectors[:miner.AddressedSectorsMax], false), big.Zero(), nil, exitcode.Ok)
+		expectQueryNetworkInfo(rt, actor)
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.EnrollCronEvent,
+			makeEarlyTerminationCronEventParams(t, rt.Epoch()+1), big.Zero(), nil, exitcode.Ok)
+		rt.Call(actor.a.ShutdownMiner, nil)
+		rt.Verify()
+		assert.Equal(t, uint64(10), liveSectorCount(t, rt))
+
+		// Cron terminates the remaining sectors. With nothing locked, the miner is deleted at its next deadline.
+		rt.SetEpoch(rt.Epoch() + 1)
+		rt.SetCaller(builtin.StoragePowerActorAddr, builtin.StoragePowerActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.StoragePowerActorAddr)
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower,
+			actor.claimParamsForSectors(sectors[miner.AddressedSectorsMax:], false), big.Zero(), nil, exitcode.Ok)
+		expectQueryNetworkInfo(rt, actor)
+		rt.Call(actor.a.OnDeferredCronEvent, &miner.CronEventPayload{
+			EventType: miner.CronEventProcessEarlyTerminations,
+		})
+		rt.Verify()
+		assert.Equal(t, uint64(0), liveSectorCount(t, rt))
+
+		rt.SetEpoch(actor.deadline(rt).Last())
+		rt.ExpectValidateCallerAddr(builtin.StoragePowerActorAddr)
+		expectQueryNetworkInfo(rt, actor)
+		st = getState(rt)
+		actor.expectDeleteMiner(rt, miner.NewDeadlineInfo(st.ProvingPeriodStart, st.CurrentDeadline+1, rt.Epoch()))
+		rt.ExpectDeleteActor(actor.owner)
+		rt.Call(actor.a.OnDeferredCronEvent, &miner.CronEventPayload{
+			EventType: miner.CronEventProvingDeadline,
+		})
+		rt.Verify()
+	})
+
+	t.Run("rejects shutdown with pre-committed sectors", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetEpoch(periodOffset + 1)
+		expiration := actor.deadline(rt).PeriodEnd() + 100*miner.WPoStProvingPeriod
+		actor.preCommitSector(rt, actor.makePreCommit(100, rt.Epoch()-1, expiration, nil))
+
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.ShutdownMiner, nil)
+		})
+	})
+
+	t.Run("only the owner may shut down the miner", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.ShutdownMiner, nil)
+		})
+	})
+}
+
 func TestWithdrawBalance(t *testing.T) {
 	periodOffset := abi.ChainEpoch(100)
 	actor := newHarness(t, periodOffset)
 	builder := builderForHarness(actor).
 		WithBalance(bigBalance, big.Zero())
 
-	t.Run("happy path withdraws funds", func(t *testing.T) {
+	t.Run("happy path withdraws funds", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		// withdraw 1% of balance
+		amount := big.Mul(big.NewInt(10), big.NewInt(1e18))
+		actor.withdrawFunds(rt, actor.owner, amount, amount)
+	})
+
+	t.Run("fails if miner is currently undercollateralized", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		// prove one sector to establish collateral and locked funds
+		actor.commitAndProveSectors(rt, 1, 100, nil)
+
+		// alter lock funds to simulate vesting since last prove
+		st := getState(rt)
+		st.LockedFunds = big.Div(st.LockedFunds, big.NewInt(2))
+		rt.ReplaceState(st)
+
+		// withdraw 1% of balance
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			amount := big.Mul(big.NewInt(10), big.NewInt(1e18))
+			actor.withdrawFunds(rt, actor.owner, amount, amount)
+		})
+	})
+}
+
+func TestBeneficiary(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	beneficiary := tutil.NewIDAddr(t, 999)
+	other := tutil.NewIDAddr(t, 998)
+	builder := builderForHarness(actor).
+		WithActorType(beneficiary, builtin.AccountActorCodeID).
+		WithActorType(other, builtin.AccountActorCodeID).
+		WithBalance(bigBalance, big.Zero())
+	quota := big.Mul(big.NewInt(5), abi.TokenPrecision)
+
+	t.Run("owner is the initial beneficiary", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		ret := actor.getBeneficiary(rt)
+		assert.Equal(t, actor.owner, ret.Beneficiary)
+		assert.Nil(t, ret.Proposed)
+	})
+
+	t.Run("nominee accepts owner proposal", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		expiration := rt.Epoch() + 1000
+
+		actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, expiration)
+		ret := actor.getBeneficiary(rt)
+		assert.Equal(t, actor.owner, ret.Beneficiary)
+		require.NotNil(t, ret.Proposed)
+		assert.Equal(t, beneficiary, ret.Proposed.NewBeneficiary)
+		assert.False(t, ret.Proposed.ApprovedByNominee)
+
+		actor.changeBeneficiary(rt, beneficiary, beneficiary, quota, expiration)
+		ret = actor.getBeneficiary(rt)
+		assert.Equal(t, beneficiary, ret.Beneficiary)
+		assert.Equal(t, miner.BeneficiaryTerm{Quota: quota, UsedQuota: big.Zero(), Expiration: expiration}, ret.Term)
+		assert.Nil(t, ret.Proposed)
+	})
+
+	t.Run("nominee must accept the proposed terms", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		expiration := rt.Epoch() + 1000
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.changeBeneficiary(rt, beneficiary, beneficiary, quota, expiration)
+		})
+
+		actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, expiration)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.changeBeneficiary(rt, beneficiary, beneficiary, big.Add(quota, big.NewInt(1)), expiration)
+		})
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.changeBeneficiary(rt, other, other, quota, expiration)
+		})
+	})
+
+	t.Run("owner proposal must have positive quota and future expiration", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.changeBeneficiary(rt, actor.owner, beneficiary, big.Zero(), rt.Epoch()+1000)
+		})
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, rt.Epoch())
+		})
+	})
+
+	t.Run("beneficiary withdraws up to its quota", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		expiration := rt.Epoch() + 1000
+		actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, expiration)
+		actor.changeBeneficiary(rt, beneficiary, beneficiary, quota, expiration)
+
+		// Withdrawals by either the owner or the beneficiary are paid to the beneficiary.
+		withdrawn := big.Mul(big.NewInt(3), abi.TokenPrecision)
+		actor.withdrawFunds(rt, actor.owner, withdrawn, withdrawn)
+		actor.withdrawFunds(rt, beneficiary, quota, big.Sub(quota, withdrawn))
+		assert.Equal(t, quota, actor.getInfo(rt).BeneficiaryTerm.UsedQuota)
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.withdrawFunds(rt, beneficiary, big.NewInt(1), big.Zero())
+		})
+		rt.Reset()
+	})
+
+	t.Run("beneficiary may not withdraw after expiration", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		expiration := rt.Epoch() + 1000
+		actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, expiration)
+		actor.changeBeneficiary(rt, beneficiary, beneficiary, quota, expiration)
+
+		rt.SetEpoch(expiration)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.withdrawFunds(rt, beneficiary, quota, big.Zero())
+		})
+		rt.Reset()
+
+		// The owner may then revert to itself without the beneficiary's approval.
+		actor.changeBeneficiary(rt, actor.owner, actor.owner, big.Zero(), 0)
+		assert.Equal(t, actor.owner, actor.getBeneficiary(rt).Beneficiary)
+		actor.withdrawFunds(rt, actor.owner, quota, quota)
+	})
+
+	t.Run("change from active beneficiary requires its approval", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		expiration := rt.Epoch() + 1000
+		actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, expiration)
+		actor.changeBeneficiary(rt, beneficiary, beneficiary, quota, expiration)
+
+		actor.changeBeneficiary(rt, actor.owner, other, quota, expiration)
+		actor.changeBeneficiary(rt, other, other, quota, expiration)
+		ret := actor.getBeneficiary(rt)
+		assert.Equal(t, beneficiary, ret.Beneficiary)
+		require.NotNil(t, ret.Proposed)
+		assert.True(t, ret.Proposed.ApprovedByNominee)
+		assert.False(t, ret.Proposed.ApprovedByBeneficiary)
+
+		actor.changeBeneficiary(rt, beneficiary, other, quota, expiration)
+		ret = actor.getBeneficiary(rt)
+		assert.Equal(t, other, ret.Beneficiary)
+		assert.Nil(t, ret.Proposed)
+	})
+	t.Run("shutdown pays beneficiary its remaining quota before the owner", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		expiration := rt.Epoch() + 1000
+		actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, expiration)
+		actor.changeBeneficiary(rt, beneficiary, beneficiary, quota, expiration)
+
+		// The beneficiary has already withdrawn part of its quota.
+		withdrawn := big.Mul(big.NewInt(2), abi.TokenPrecision)
+		actor.withdrawFunds(rt, beneficiary, withdrawn, withdrawn)
+
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner)
+		expectQueryNetworkInfo(rt, actor)
+		actor.expectDeleteMiner(rt, actor.deadline(rt))
+		rt.ExpectSend(beneficiary, builtin.MethodSend, nil, big.Sub(quota, withdrawn), nil, exitcode.Ok)
+		rt.ExpectDeleteActor(actor.owner)
+		rt.Call(actor.a.ShutdownMiner, nil)
+		rt.Verify()
+	})
+
+	t.Run("shutdown after the beneficiary term expires pays only the owner", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		expiration := rt.Epoch() + 1000
+		actor.changeBeneficiary(rt, actor.owner, beneficiary, quota, expiration)
+		actor.changeBeneficiary(rt, beneficiary, beneficiary, quota, expiration)
+
+		rt.SetEpoch(expiration)
+		actor.shutdownMiner(rt, nil, big.Zero(), true)
+	})
+}
+
+func TestApplyRewards(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(big.Zero(), big.Zero())
+	reward := big.Mul(big.NewInt(100), abi.TokenPrecision)
+
+	applyRewards := func(rt *mock.Runtime, reward, penalty, expectedPledgeDelta, expectedBurn abi.TokenAmount) *builtin.ApplyRewardsReturn {
+		rt.SetBalance(big.Add(rt.Balance(), reward))
+		rt.SetCaller(builtin.RewardActorAddr, builtin.RewardActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.RewardActorAddr)
+		if !expectedPledgeDelta.IsZero() {
+			rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &expectedPledgeDelta, big.Zero(), nil, exitcode.Ok)
+		}
+		if !expectedBurn.IsZero() {
+			rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedBurn, nil, exitcode.Ok)
+		}
+		ret := rt.Call(actor.a.ApplyRewards, &builtin.ApplyRewardParams{Reward: reward, Penalty: penalty}).(*builtin.ApplyRewardsReturn)
+		rt.Verify()
+		return ret
+	}
+
+	t.Run("locks a fraction of the reward", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		locked := miner.LockedRewardFromReward(reward)
+		assert.Equal(t, big.Mul(big.NewInt(75), abi.TokenPrecision), locked)
+		ret := applyRewards(rt, reward, big.Zero(), locked, big.Zero())
+		assert.Equal(t, locked, ret.Locked)
+		assert.Equal(t, big.Zero(), ret.DebtRepaid)
+
+		st := getState(rt)
+		assert.Equal(t, locked, st.LockedFunds)
+		assert.Equal(t, big.Sub(reward, locked), st.GetAvailableBalance(rt.Balance()))
+
+		// The first funds vest one step after the reward is received.
+		rt.SetEpoch(rt.Epoch() + miner.LockedRewardVestingSpec.StepDuration + miner.LockedRewardVestingSpec.Quantization)
+		st = getState(rt)
+		vested, err := st.CheckVestedFunds(rt.AdtStore(), rt.Epoch())
+		require.NoError(t, err)
+		assert.True(t, vested.GreaterThan(big.Zero()))
+		assert.True(t, vested.LessThan(locked))
+	})
+
+	t.Run("pays penalty from reward before locking", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		penalty := big.Mul(big.NewInt(10), abi.TokenPrecision)
+		locked := miner.LockedRewardFromReward(big.Sub(reward, penalty))
+		ret := applyRewards(rt, reward, penalty, locked, penalty)
+		assert.Equal(t, locked, ret.Locked)
+		assert.Equal(t, penalty, ret.DebtRepaid)
+
+		st := getState(rt)
+		assert.Equal(t, locked, st.LockedFunds)
+		assert.Equal(t, big.Zero(), st.FeeDebt)
+		assert.Equal(t, big.Sub(big.Sub(reward, penalty), locked), st.GetAvailableBalance(rt.Balance()))
+	})
+
+	t.Run("pays debt not covered by the reward from locked funds", func(t *testing.T) {
 		rt := builder.Build(t)
 		actor.constructAndVerify(rt)
 
-		// withdraw 1% of balance
-		actor.withdrawFunds(rt, big.Mul(big.NewInt(10), big.NewInt(1e18)))
+		locked := miner.LockedRewardFromReward(reward)
+		applyRewards(rt, reward, big.Zero(), locked, big.Zero())
+
+		// The penalty exhausts the second reward, and the rest is taken from the first reward's locked funds.
+		excess := big.Mul(big.NewInt(10), abi.TokenPrecision)
+		penalty := big.Add(reward, excess)
+		ret := applyRewards(rt, reward, penalty, excess.Neg(), penalty)
+		assert.Equal(t, big.Zero(), ret.Locked)
+		assert.Equal(t, penalty, ret.DebtRepaid)
+
+		st := getState(rt)
+		assert.Equal(t, big.Sub(locked, excess), st.LockedFunds)
+		assert.Equal(t, big.Zero(), st.FeeDebt)
 	})
 
-	t.Run("fails if miner is currently undercollateralized", func(t *testing.T) {
+	t.Run("penalty in excess of funds becomes fee debt", func(t *testing.T) {
 		rt := builder.Build(t)
 		actor.constructAndVerify(rt)
 
-		// prove one sector to establish collateral and locked funds
-		actor.commitAndProveSectors(rt, 1, 100, nil)
+		penalty := big.Mul(reward, big.NewInt(2))
+		ret := applyRewards(rt, reward, penalty, big.Zero(), reward)
+		assert.Equal(t, reward, ret.DebtRepaid)
 
-		// alter lock funds to simulate vesting since last prove
 		st := getState(rt)
-		st.LockedFunds = big.Div(st.LockedFunds, big.NewInt(2))
-		rt.ReplaceState(st)
+		assert.Equal(t, big.Zero(), st.LockedFunds)
+		assert.Equal(t, reward, st.FeeDebt)
 
-		// withdraw 1% of balance
-		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
-			actor.withdrawFunds(rt, big.Mul(big.NewInt(10), big.NewInt(1e18)))
+		// The debt is repaid from the next reward before any is available.
+		rt.SetBalance(big.Zero())
+		ret = applyRewards(rt, reward, big.Zero(), big.Zero(), reward)
+		assert.Equal(t, reward, ret.DebtRepaid)
+		st = getState(rt)
+		assert.Equal(t, big.Zero(), st.FeeDebt)
+		assert.Equal(t, big.Zero(), st.GetAvailableBalance(rt.Balance()))
+	})
+
+	t.Run("only the reward actor may apply rewards", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.RewardActorAddr)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.ApplyRewards, &builtin.ApplyRewardParams{Reward: reward, Penalty: big.Zero()})
 		})
 	})
 }
 
 func TestReportConsensusFault(t *testing.T) {
-	t.Skip("Disabled in miner state refactor #648, restore soon")
 	periodOffset := abi.ChainEpoch(100)
 	actor := newHarness(t, periodOffset)
 	builder := builderForHarness(actor).
 		WithBalance(bigBalance, big.Zero())
+	reporter := tutil.NewIDAddr(t, 1000)
 
-	rt := builder.Build(t)
-	actor.constructAndVerify(rt)
-	precommitEpoch := abi.ChainEpoch(1)
-	rt.SetEpoch(precommitEpoch)
-	dealIDs := [][]abi.DealID{{1, 2}, {3, 4}}
-	sectorInfo := actor.commitAndProveSectors(rt, 2, 10, dealIDs)
-	_ = sectorInfo
+	t.Run("terminates miner and records ineligibility period", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		precommitEpoch := abi.ChainEpoch(1)
+		rt.SetEpoch(precommitEpoch)
+		dealIDs := [][]abi.DealID{{1, 2}, {3, 4}}
+		actor.commitAndProveSectors(rt, 2, 10, dealIDs)
+		assert.Equal(t, abi.ChainEpoch(-1), actor.getInfo(rt).ConsensusFaultElapsed)
+
+		// The miner sends a single call to terminate the deals for all its sectors.
+		allDeals := []abi.DealID{}
+		for _, ids := range dealIDs {
+			allDeals = append(allDeals, ids...)
+		}
+		rt.SetEpoch(rt.Epoch() + 10)
+		actor.reportConsensusFault(rt, reporter, rt.Epoch()-1, allDeals)
 
-	params := &miner.ReportConsensusFaultParams{
-		BlockHeader1:     nil,
-		BlockHeader2:     nil,
-		BlockHeaderExtra: nil,
-	}
+		info := actor.getInfo(rt)
+		assert.Equal(t, rt.Epoch()+miner.ConsensusFaultIneligibilityDuration, info.ConsensusFaultElapsed)
+		assert.True(t, miner.ConsensusFaultActive(info, rt.Epoch()))
+		assert.True(t, miner.ConsensusFaultActive(info, info.ConsensusFaultElapsed))
+		assert.False(t, miner.ConsensusFaultActive(info, info.ConsensusFaultElapsed+1))
+	})
 
-	// miner should send a single call to terminate the deals for all its sectors
-	allDeals := []abi.DealID{}
-	for _, ids := range dealIDs {
-		allDeals = append(allDeals, ids...)
-	}
-	actor.reportConsensusFault(rt, addr.TestAddress, params, allDeals)
+	t.Run("rejects repeated report of fault", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetEpoch(rt.Epoch() + 10)
+		faultEpoch := rt.Epoch() - 1
+		actor.reportConsensusFault(rt, reporter, faultEpoch, nil)
+		elapsed := actor.getInfo(rt).ConsensusFaultElapsed
+
+		// The same fault, and any fault committed during the ineligibility period, is rejected.
+		for _, epoch := range []abi.ChainEpoch{faultEpoch, elapsed} {
+			rt.SetEpoch(epoch + 1)
+			rt.SetCaller(reporter, builtin.AccountActorCodeID)
+			rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+			rt.ExpectVerifyConsensusFault(nil, nil, nil, &runtime.ConsensusFault{
+				Target: actor.receiver,
+				Epoch:  epoch,
+				Type:   runtime.ConsensusFaultDoubleForkMining,
+			}, nil)
+			rt.ExpectAbort(exitcode.ErrForbidden, func() {
+				rt.Call(actor.a.ReportConsensusFault, &miner.ReportConsensusFaultParams{})
+			})
+			rt.Verify()
+		}
+	})
 }
 
 func TestAddLockedFund(t *testing.T) {
@@ -1496,6 +2807,18 @@ func TestAddLockedFund(t *testing.T) {
 
 	})
 
+	t.Run("reward actor may not lock funds", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		amt := abi.NewTokenAmount(600_000)
+		rt.SetCaller(builtin.RewardActorAddr, builtin.RewardActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.worker, actor.owner)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.a.AddLockedFund, &amt)
+		})
+		rt.Verify()
+	})
 }
 
 type actorHarness struct {
@@ -1520,6 +2843,16 @@ type actorHarness struct {
 	baselinePower   abi.StoragePower
 }
 
+// Returns an estimate of the epoch reward as reported by the reward actor, steady at the harness's reward.
+func (h *actorHarness) rewardEstimate() smoothing.FilterEstimate {
+	return smoothing.NewEstimate(h.epochReward, big.Zero())
+}
+
+// Returns an estimate of network power as reported by the power actor, steady at the harness's power.
+func (h *actorHarness) networkQAPowerEstimate() smoothing.FilterEstimate {
+	return smoothing.NewEstimate(h.networkQAPower, big.Zero())
+}
+
 func newHarness(t testing.TB, provingPeriodOffset abi.ChainEpoch) *actorHarness {
 	sealProofType := abi.RegisteredSealProof_StackedDrg32GiBV1
 	sectorSize, err := sealProofType.SectorSize()
@@ -1751,20 +3084,6 @@ func (h *actorHarness) preCommitSector(rt *mock.Runtime, params *miner.SectorPre
 		}
 		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.VerifyDealsForActivation, &vdParams, big.Zero(), &vdReturn, exitcode.Ok)
 	}
-	{
-		eventPayload := miner.CronEventPayload{
-			EventType: miner.CronEventPreCommitExpiry,
-			Sectors:   bitfield.NewFromSet([]uint64{uint64(params.SectorNumber)}),
-		}
-		buf := bytes.Buffer{}
-		err := eventPayload.MarshalCBOR(&buf)
-		require.NoError(h.t, err)
-		cronParams := power.EnrollCronEventParams{
-			EventEpoch: rt.Epoch() + miner.MaxSealDuration[params.SealProof] + 1,
-			Payload:    buf.Bytes(),
-		}
-		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.EnrollCronEvent, &cronParams, big.Zero(), nil, exitcode.Ok)
-	}
 
 	rt.Call(h.a.PreCommitSector, params)
 	rt.Verify()
@@ -1938,66 +3257,27 @@ func (h *actorHarness) submitWindowPoSt(rt *mock.Runtime, deadline *miner.Deadli
 
 	expectQueryNetworkInfo(rt, h)
 
-	var registeredPoStProof, err = h.sealProofType.RegisteredWindowPoStProof()
-	require.NoError(h.t, err)
-
-	proofs := make([]abi.PoStProof, 1) // Number of proofs doesn't depend on partition count
-	for i := range proofs {
-		proofs[i].PoStProof = registeredPoStProof
-		proofs[i].ProofBytes = []byte(fmt.Sprintf("proof%d", i))
-	}
-	challengeRand := abi.SealRandomness([]byte{10, 11, 12, 13})
+	proofs := h.makePoStProofs()
 
-	allSkipped := map[abi.SectorNumber]struct{}{}
+	allSkipped := abi.NewBitField()
+	recovering := false
+	dl := h.getDeadline(rt, deadline.Index)
 	for _, p := range partitions {
-		_ = p.Skipped.ForEach(func(u uint64) error {
-			allSkipped[abi.SectorNumber(u)] = struct{}{}
-			return nil
-		})
-	}
-
-	// find the first non-faulty sector in poSt to replace all faulty sectors.
-	var goodInfo *miner.SectorOnChainInfo
-	for _, ci := range infos {
-		if _, contains := allSkipped[ci.SectorNumber]; !contains {
-			goodInfo = ci
-			break
-		}
-	}
-
-	// goodInfo == nil indicates all the sectors have been skipped and should PoSt verification should not occur
-	if goodInfo != nil {
-		var buf bytes.Buffer
-		err := rt.Receiver().MarshalCBOR(&buf)
+		var err error
+		allSkipped, err = bitfield.MergeBitFields(allSkipped, p.Skipped)
 		require.NoError(h.t, err)
 
-		rt.ExpectGetRandomness(crypto.DomainSeparationTag_WindowedPoStChallengeSeed, deadline.Challenge, buf.Bytes(), abi.Randomness(challengeRand))
-
-		actorId, err := addr.IDFromAddress(h.receiver)
+		partition := h.getPartition(rt, dl, p.Index)
+		recoveries, err := bitfield.SubtractBitField(partition.Recoveries, p.Skipped)
 		require.NoError(h.t, err)
+		noRecoveries, err := recoveries.IsEmpty()
+		require.NoError(h.t, err)
+		recovering = recovering || !noRecoveries
+	}
 
-		// if not all sectors are skipped
-		proofInfos := make([]abi.SectorInfo, len(infos))
-		for i, ci := range infos {
-			si := ci
-			_, contains := allSkipped[ci.SectorNumber]
-			if contains {
-				si = goodInfo
-			}
-			proofInfos[i] = abi.SectorInfo{
-				SealProof:    si.SealProof,
-				SectorNumber: si.SectorNumber,
-				SealedCID:    si.SealedCID,
-			}
-		}
-
-		vi := abi.WindowPoStVerifyInfo{
-			Randomness:        abi.PoStRandomness(challengeRand),
-			Proofs:            proofs,
-			ChallengedSectors: proofInfos,
-			Prover:            abi.ActorID(actorId),
-		}
-		rt.ExpectVerifyPoSt(vi, nil)
+	// The proof is verified immediately only if power is being recovered, otherwise it is accepted optimistically.
+	if recovering {
+		h.expectVerifyWindowPoSt(rt, deadline, infos, allSkipped, proofs, nil)
 	}
 	if poStCfg != nil {
 		// expect power update
@@ -2030,6 +3310,186 @@ func (h *actorHarness) submitWindowPoSt(rt *mock.Runtime, deadline *miner.Deadli
 	rt.Verify()
 }
 
+func (h *actorHarness) makePoStProofs() []abi.PoStProof {
+	registeredPoStProof, err := h.sealProofType.RegisteredWindowPoStProof()
+	require.NoError(h.t, err)
+
+	proofs := make([]abi.PoStProof, 1) // Number of proofs doesn't depend on partition count
+	for i := range proofs {
+		proofs[i].PoStProof = registeredPoStProof
+		proofs[i].ProofBytes = []byte(fmt.Sprintf("proof%d", i))
+	}
+	return proofs
+}
+
+// Expects verification of a Window PoSt over some sectors, with a known-good sector substituted for skipped ones.
+// No verification is expected if all sectors are skipped.
+func (h *actorHarness) expectVerifyWindowPoSt(rt *mock.Runtime, deadline *miner.DeadlineInfo, infos []*miner.SectorOnChainInfo,
+	skipped *bitfield.BitField, proofs []abi.PoStProof, result error) {
+	challengeRand := abi.SealRandomness([]byte{10, 11, 12, 13})
+
+	// find the first non-faulty sector in poSt to replace all faulty sectors.
+	var goodInfo *miner.SectorOnChainInfo
+	for _, ci := range infos {
+		contains, err := skipped.IsSet(uint64(ci.SectorNumber))
+		require.NoError(h.t, err)
+		if !contains {
+			goodInfo = ci
+			break
+		}
+	}
+
+	// goodInfo == nil indicates all the sectors have been skipped and should PoSt verification should not occur
+	if goodInfo == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	err := rt.Receiver().MarshalCBOR(&buf)
+	require.NoError(h.t, err)
+
+	rt.ExpectGetRandomness(crypto.DomainSeparationTag_WindowedPoStChallengeSeed, deadline.Challenge, buf.Bytes(), abi.Randomness(challengeRand))
+
+	actorId, err := addr.IDFromAddress(h.receiver)
+	require.NoError(h.t, err)
+
+	proofInfos := make([]abi.SectorInfo, len(infos))
+	for i, ci := range infos {
+		si := ci
+		contains, err := skipped.IsSet(uint64(ci.SectorNumber))
+		require.NoError(h.t, err)
+		if contains {
+			si = goodInfo
+		}
+		proofInfos[i] = abi.SectorInfo{
+			SealProof:    si.SealProof,
+			SectorNumber: si.SealedSectorNumber,
+			SealedCID:    si.SealedCID,
+		}
+	}
+
+	vi := abi.WindowPoStVerifyInfo{
+		Randomness:        abi.PoStRandomness(challengeRand),
+		Proofs:            proofs,
+		ChallengedSectors: proofInfos,
+		Prover:            abi.ActorID(actorId),
+	}
+	rt.ExpectVerifyPoSt(vi, result)
+}
+
+type poStDisputeResult struct {
+	expectedPowerDelta *power.UpdateClaimedPowerParams
+	expectedPenalty    abi.TokenAmount
+	expectedReward     abi.TokenAmount
+}
+
+// Disputes an optimistically accepted proof over some sectors.
+// A nil result expects the proof to verify, and so the dispute to fail.
+func (h *actorHarness) disputeWindowPoSt(rt *mock.Runtime, from addr.Address, deadline *miner.DeadlineInfo, proofIndex uint64,
+	infos []*miner.SectorOnChainInfo, result *poStDisputeResult) {
+	rt.SetCaller(from, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+
+	expectQueryNetworkInfo(rt, h)
+
+	params := miner.DisputeWindowedPoStParams{
+		Deadline:  deadline.Index,
+		PoStIndex: proofIndex,
+	}
+
+	if result == nil {
+		h.expectVerifyWindowPoSt(rt, deadline, infos, abi.NewBitField(), h.makePoStProofs(), nil)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(h.a.DisputeWindowedPoSt, &params)
+		})
+		rt.Verify()
+		return
+	}
+
+	h.expectVerifyWindowPoSt(rt, deadline, infos, abi.NewBitField(), h.makePoStProofs(), fmt.Errorf("invalid post"))
+	if result.expectedPowerDelta != nil {
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, result.expectedPowerDelta,
+			abi.NewTokenAmount(0), nil, exitcode.Ok)
+	}
+	if !result.expectedReward.IsZero() {
+		rt.ExpectSend(from, builtin.MethodSend, nil, result.expectedReward, nil, exitcode.Ok)
+	}
+	toBurn := big.Sub(result.expectedPenalty, result.expectedReward)
+	if !toBurn.IsZero() {
+		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, toBurn, nil, exitcode.Ok)
+	}
+	if !result.expectedPenalty.IsZero() {
+		pledgeDelta := result.expectedPenalty.Neg()
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta,
+			abi.NewTokenAmount(0), nil, exitcode.Ok)
+	}
+
+	rt.Call(h.a.DisputeWindowedPoSt, &params)
+	rt.Verify()
+}
+
+// Updates a single CC sector with deals, verifying the sector's new power and pledge.
+func (h *actorHarness) proveReplicaUpdate(rt *mock.Runtime, oldSector *miner.SectorOnChainInfo, update miner.ReplicaUpdate,
+	dealWeight market.VerifyDealsForActivationReturn) *miner.SectorOnChainInfo {
+	rt.SetCaller(h.worker, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(h.worker)
+	expectQueryNetworkInfo(rt, h)
+
+	rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.VerifyDealsForActivation,
+		&market.VerifyDealsForActivationParams{
+			DealIDs:      update.Deals,
+			SectorStart:  rt.Epoch(),
+			SectorExpiry: oldSector.Expiration,
+		}, big.Zero(), &dealWeight, exitcode.Ok)
+
+	commd := cbg.CborCid(update.NewUnsealedCID)
+	rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.ComputeDataCommitment,
+		&market.ComputeDataCommitmentParams{
+			DealIDs:    update.Deals,
+			SectorType: oldSector.SealProof,
+		}, big.Zero(), &commd, exitcode.Ok)
+
+	rt.ExpectVerifyReplicaUpdate(abi.ReplicaUpdateInfo{
+		UpdateProofType:      oldSector.SealProof,
+		OldSealedSectorCID:   oldSector.SealedCID,
+		NewSealedSectorCID:   update.NewSealedCID,
+		NewUnsealedSectorCID: update.NewUnsealedCID,
+		Proof:                update.ReplicaProof,
+	}, nil)
+
+	rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.ActivateDeals,
+		&market.ActivateDealsParams{DealIDs: update.Deals, SectorExpiry: oldSector.Expiration},
+		big.Zero(), nil, exitcode.Ok)
+
+	expected := *oldSector
+	expected.SealedCID = update.NewSealedCID
+	expected.DealIDs = update.Deals
+	expected.ReplicaUpdateEpoch = rt.Epoch()
+	expected.DealWeight = dealWeight.DealWeight
+	expected.VerifiedDealWeight = dealWeight.VerifiedDealWeight
+	expected.InitialPledge = big.Max(
+		miner.InitialPledgeForPower(miner.QAPowerForSector(h.sectorSize, &expected), h.networkQAPowerEstimate(), h.baselinePower,
+			h.networkPledge, h.rewardEstimate(), rt.TotalFilCircSupply()),
+		oldSector.InitialPledge,
+	)
+
+	powerDelta := power.UpdateClaimedPowerParams{
+		RawByteDelta:         big.Zero(),
+		QualityAdjustedDelta: big.Sub(miner.QAPowerForSector(h.sectorSize, &expected), miner.QAPowerForSector(h.sectorSize, oldSector)),
+	}
+	rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, &powerDelta,
+		big.Zero(), nil, exitcode.Ok)
+
+	pledgeDelta := big.Sub(expected.InitialPledge, oldSector.InitialPledge)
+	if !pledgeDelta.IsZero() {
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta,
+			big.Zero(), nil, exitcode.Ok)
+	}
+
+	rt.Call(h.a.ProveReplicaUpdates, &miner.ProveReplicaUpdatesParams{Updates: []miner.ReplicaUpdate{update}})
+	rt.Verify()
+	return &expected
+}
 func (h *actorHarness) computePartitions(rt *mock.Runtime, deadlines *miner.Deadlines, deadlineIdx uint64) ([]*miner.SectorOnChainInfo, []uint64) {
 	panic("todo")
 	// TODO minerstate
@@ -2190,57 +3650,164 @@ func (h *actorHarness) extendSector(rt *mock.Runtime, sector *miner.SectorOnChai
 	rt.Verify()
 }
 
-func (h *actorHarness) terminateSectors(rt *mock.Runtime, sectors *abi.BitField, expectedFee abi.TokenAmount) {
+func (h *actorHarness) terminateSectors(rt *mock.Runtime, sectors *abi.BitField, expectedFee abi.TokenAmount) *miner.TerminateSectorsReturn {
 	rt.SetCaller(h.worker, builtin.AccountActorCodeID)
 	rt.ExpectValidateCallerAddr(h.worker)
 
+	st := getState(rt)
+	declsByPartition := map[miner.PartitionKey]*abi.BitField{}
+	var keys []miner.PartitionKey
 	dealIDs := []abi.DealID{}
 	sectorInfos := []*miner.SectorOnChainInfo{}
+	pledge := big.Zero()
 	err := sectors.ForEach(func(secNum uint64) error {
 		sector := h.getSector(rt, abi.SectorNumber(secNum))
 		dealIDs = append(dealIDs, sector.DealIDs...)
-
+		pledge = big.Add(pledge, sector.InitialPledge)
 		sectorInfos = append(sectorInfos, sector)
+
+		dlIdx, pIdx, err := st.FindSector(rt.AdtStore(), sector.SectorNumber)
+		require.NoError(h.t, err)
+		key := miner.PartitionKey{Deadline: dlIdx, Partition: pIdx}
+		if _, ok := declsByPartition[key]; !ok {
+			declsByPartition[key] = abi.NewBitField()
+			keys = append(keys, key)
+		}
+		declsByPartition[key].Set(secNum)
 		return nil
 	})
 	require.NoError(h.t, err)
 
-	{
-		expectQueryNetworkInfo(rt, h)
+	params := &miner.TerminateSectorsParams{}
+	for _, key := range keys {
+		params.Terminations = append(params.Terminations, miner.TerminationDeclaration{
+			Deadline:  key.Deadline,
+			Partition: key.Partition,
+			Sectors:   declsByPartition[key],
+		})
 	}
 
-	{
-		// TODO minerstate
-		//rawPower, qaPower := miner.PowerForSectors(h.sectorSize, sectorInfos)
-		//rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, &power.UpdateClaimedPowerParams{
-		//	RawByteDelta:         rawPower.Neg(),
-		//	QualityAdjustedDelta: qaPower.Neg(),
-		//}, abi.NewTokenAmount(0), nil, exitcode.Ok)
+	expectQueryNetworkInfo(rt, h)
+	if big.Zero().LessThan(expectedFee) {
+		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedFee, nil, exitcode.Ok)
+		pledgeDelta := expectedFee.Neg()
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
+	}
+	if !pledge.IsZero() {
+		pledgeDelta := pledge.Neg()
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
+	}
+	if len(dealIDs) > 0 {
+		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.OnMinerSectorsTerminate, &market.OnMinerSectorsTerminateParams{
+			Epoch:   rt.Epoch(),
+			DealIDs: dealIDs,
+		}, abi.NewTokenAmount(0), nil, exitcode.Ok)
+	}
+
+	rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower,
+		h.claimParamsForSectors(sectorInfos, false), abi.NewTokenAmount(0), nil, exitcode.Ok)
+
+	ret := rt.Call(h.a.TerminateSectors, params).(*miner.TerminateSectorsReturn)
+	rt.Verify()
+	return ret
+}
+
+// Shuts down the miner, expecting all of the given sectors to be terminated and their penalties charged.
+func (h *actorHarness) shutdownMiner(rt *mock.Runtime, sectorInfos []*miner.SectorOnChainInfo, expectedFee abi.TokenAmount, deleted bool) {
+	rt.SetCaller(h.owner, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(h.owner)
+
+	dealIDs := []abi.DealID{}
+	pledge := big.Zero()
+	for _, sector := range sectorInfos {
+		dealIDs = append(dealIDs, sector.DealIDs...)
+		pledge = big.Add(pledge, sector.InitialPledge)
+	}
+
+	if len(sectorInfos) > 0 {
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower,
+			h.claimParamsForSectors(sectorInfos, false), abi.NewTokenAmount(0), nil, exitcode.Ok)
 	}
+	expectQueryNetworkInfo(rt, h)
 	if big.Zero().LessThan(expectedFee) {
 		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedFee, nil, exitcode.Ok)
 		pledgeDelta := expectedFee.Neg()
 		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
 	}
+	if !pledge.IsZero() {
+		pledgeDelta := pledge.Neg()
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
+	}
+	if len(dealIDs) > 0 {
+		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.OnMinerSectorsTerminate, &market.OnMinerSectorsTerminateParams{
+			Epoch:   rt.Epoch(),
+			DealIDs: dealIDs,
+		}, abi.NewTokenAmount(0), nil, exitcode.Ok)
+	}
+	if deleted {
+		h.expectDeleteMiner(rt, h.deadline(rt))
+		rt.ExpectDeleteActor(h.owner)
+	}
 
-	// TODO minerstate
-	//params := &miner.TerminateSectorsParams{Sectors: sectors}
-	//rt.Call(h.a.TerminateSectors, params)
-	//rt.Verify()
+	rt.Call(h.a.ShutdownMiner, nil)
+	rt.Verify()
+}
+
+// Expects the miner to remove its claim, along with its cron callback for a proving deadline.
+func (h *actorHarness) expectDeleteMiner(rt *mock.Runtime, dlInfo *miner.DeadlineInfo) {
+	rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.DeleteMiner, &power.DeleteMinerParams{
+		CronEpochs: []abi.ChainEpoch{dlInfo.Last()},
+	}, big.Zero(), nil, exitcode.Ok)
+}
+
+// Commits a single sector and submits a Window PoSt for it at its deadline, returning the sector, its deadline
+// and partition. The deadline is closed before returning.
+func (h *actorHarness) commitAndProveDeadline(rt *mock.Runtime) (*miner.SectorOnChainInfo, *miner.DeadlineInfo, uint64) {
+	h.constructAndVerify(rt)
+	sector := h.commitAndProveSectors(rt, 1, 100, nil)[0]
+
+	st := getState(rt)
+	dlIdx, pIdx, err := st.FindSector(rt.AdtStore(), sector.SectorNumber)
+	require.NoError(h.t, err)
+
+	dlinfo := h.deadline(rt)
+	for dlinfo.Index != dlIdx {
+		advanceDeadline(rt, h, &cronConfig{})
+		dlinfo = h.deadline(rt)
+	}
+
+	partitions := []miner.PoStPartition{
+		{Index: pIdx, Skipped: abi.NewBitField()},
+	}
+	h.submitWindowPoSt(rt, dlinfo, partitions, []*miner.SectorOnChainInfo{sector}, nil)
+	advanceDeadline(rt, h, &cronConfig{})
+	return sector, dlinfo, pIdx
+}
+
+func (h *actorHarness) maskSectorNumbers(rt *mock.Runtime, mask *abi.BitField) {
+	rt.SetCaller(h.owner, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(h.owner)
+	rt.Call(h.a.MaskSectorNumbers, &miner.MaskSectorNumbersParams{Mask: mask})
+	rt.Verify()
 }
 
-func (h *actorHarness) reportConsensusFault(rt *mock.Runtime, from addr.Address, params *miner.ReportConsensusFaultParams, dealIDs []abi.DealID) {
+func (h *actorHarness) reportConsensusFault(rt *mock.Runtime, from addr.Address, faultEpoch abi.ChainEpoch, dealIDs []abi.DealID) {
 	rt.SetCaller(from, builtin.AccountActorCodeID)
 	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
 
+	params := &miner.ReportConsensusFaultParams{
+		BlockHeader1:     nil,
+		BlockHeader2:     nil,
+		BlockHeaderExtra: nil,
+	}
 	rt.ExpectVerifyConsensusFault(params.BlockHeader1, params.BlockHeader2, params.BlockHeaderExtra, &runtime.ConsensusFault{
 		Target: h.receiver,
-		Epoch:  rt.Epoch() - 1,
+		Epoch:  faultEpoch,
 		Type:   runtime.ConsensusFaultDoubleForkMining,
 	}, nil)
 
 	// slash reward
-	reward := miner.RewardForConsensusSlashReport(1, rt.Balance())
+	reward := miner.RewardForConsensusSlashReport(rt.Epoch()-faultEpoch, rt.Balance())
 	rt.ExpectSend(from, builtin.MethodSend, nil, reward, nil, exitcode.Ok)
 
 	// power termination
@@ -2248,9 +3815,12 @@ func (h *actorHarness) reportConsensusFault(rt *mock.Runtime, from addr.Address,
 	rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.OnConsensusFault, &lockedFunds, abi.NewTokenAmount(0), nil, exitcode.Ok)
 
 	// expect every deal to be closed out
-	rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.OnMinerSectorsTerminate, &market.OnMinerSectorsTerminateParams{
-		DealIDs: dealIDs,
-	}, abi.NewTokenAmount(0), nil, exitcode.Ok)
+	if len(dealIDs) > 0 {
+		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.OnMinerSectorsTerminate, &market.OnMinerSectorsTerminateParams{
+			Epoch:   rt.Epoch(),
+			DealIDs: dealIDs,
+		}, abi.NewTokenAmount(0), nil, exitcode.Ok)
+	}
 
 	// expect actor to be deleted
 	rt.ExpectDeleteActor(builtin.BurntFundsActorAddr)
@@ -2261,7 +3831,7 @@ func (h *actorHarness) reportConsensusFault(rt *mock.Runtime, from addr.Address,
 
 func (h *actorHarness) addLockedFund(rt *mock.Runtime, amt abi.TokenAmount) {
 	rt.SetCaller(h.worker, builtin.AccountActorCodeID)
-	rt.ExpectValidateCallerAddr(h.worker, h.owner, builtin.RewardActorAddr)
+	rt.ExpectValidateCallerAddr(h.worker, h.owner)
 	// expect pledge update
 	rt.ExpectSend(
 		builtin.StoragePowerActorAddr,
@@ -2284,6 +3854,7 @@ type cronConfig struct {
 	undetectedFaultsPenalty    *abi.TokenAmount
 	expiredSectorsPowerDelta   *power.UpdateClaimedPowerParams
 	ongoingFaultsPenalty       *abi.TokenAmount
+	expiredPrecommitDeposit    *abi.TokenAmount
 }
 
 func (h *actorHarness) onDeadlineCron(rt *mock.Runtime, config *cronConfig) {
@@ -2291,16 +3862,18 @@ func (h *actorHarness) onDeadlineCron(rt *mock.Runtime, config *cronConfig) {
 
 	// Preamble
 	reward := reward.ThisEpochRewardReturn{
-		ThisEpochReward:        h.epochReward,
-		ThisEpochBaselinePower: h.baselinePower,
+		ThisEpochReward:         h.epochReward,
+		ThisEpochRewardSmoothed: h.rewardEstimate(),
+		ThisEpochBaselinePower:  h.baselinePower,
 	}
 	rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.ThisEpochReward, nil, big.Zero(), &reward, exitcode.Ok)
 	networkPower := big.NewIntUnsigned(1 << 50)
 	rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.CurrentTotalPower, nil, big.Zero(),
 		&power.CurrentTotalPowerReturn{
-			RawBytePower:     networkPower,
-			QualityAdjPower:  networkPower,
-			PledgeCollateral: h.networkPledge,
+			RawBytePower:            networkPower,
+			QualityAdjPower:         networkPower,
+			PledgeCollateral:        h.networkPledge,
+			QualityAdjPowerSmoothed: smoothing.NewEstimate(networkPower, big.Zero()),
 		},
 		exitcode.Ok)
 
@@ -2317,8 +3890,18 @@ func (h *actorHarness) onDeadlineCron(rt *mock.Runtime, config *cronConfig) {
 		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, config.expiredSectorsPowerDelta,
 			abi.NewTokenAmount(0), nil, exitcode.Ok)
 	}
+	// Penalties and expired pre-commit deposits are burnt together, but only penalties reduce pledge.
+	toBurn := big.Zero()
+	if config.ongoingFaultsPenalty != nil {
+		toBurn = big.Add(toBurn, *config.ongoingFaultsPenalty)
+	}
+	if config.expiredPrecommitDeposit != nil {
+		toBurn = big.Add(toBurn, *config.expiredPrecommitDeposit)
+	}
+	if toBurn.GreaterThan(big.Zero()) {
+		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, toBurn, nil, exitcode.Ok)
+	}
 	if config.ongoingFaultsPenalty != nil {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, *config.ongoingFaultsPenalty, nil, exitcode.Ok)
 		pledgeDelta := config.ongoingFaultsPenalty.Neg()
 		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
 	}
@@ -2340,18 +3923,38 @@ func (h *actorHarness) onDeadlineCron(rt *mock.Runtime, config *cronConfig) {
 	rt.Verify()
 }
 
-func (h *actorHarness) withdrawFunds(rt *mock.Runtime, amount abi.TokenAmount) {
-	rt.SetCaller(h.owner, builtin.AccountActorCodeID)
-	rt.ExpectValidateCallerAddr(h.owner)
+func (h *actorHarness) withdrawFunds(rt *mock.Runtime, from addr.Address, requested, expectedWithdrawn abi.TokenAmount) {
+	info := h.getInfo(rt)
+	rt.SetCaller(from, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(info.Owner, info.Beneficiary)
 
-	rt.ExpectSend(h.owner, builtin.MethodSend, nil, amount, nil, exitcode.Ok)
+	rt.ExpectSend(info.Beneficiary, builtin.MethodSend, nil, expectedWithdrawn, nil, exitcode.Ok)
 
 	rt.Call(h.a.WithdrawBalance, &miner.WithdrawBalanceParams{
-		AmountRequested: amount,
+		AmountRequested: requested,
 	})
 	rt.Verify()
 }
 
+func (h *actorHarness) changeBeneficiary(rt *mock.Runtime, from, nominee addr.Address, quota abi.TokenAmount, expiration abi.ChainEpoch) {
+	info := h.getInfo(rt)
+	rt.SetCaller(from, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(info.Owner, info.Beneficiary, nominee)
+	rt.Call(h.a.ChangeBeneficiary, &miner.ChangeBeneficiaryParams{
+		NewBeneficiary: nominee,
+		NewQuota:       quota,
+		NewExpiration:  expiration,
+	})
+	rt.Verify()
+}
+
+func (h *actorHarness) getBeneficiary(rt *mock.Runtime) *miner.GetBeneficiaryReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.a.GetBeneficiary, nil).(*miner.GetBeneficiaryReturn)
+	rt.Verify()
+	return ret
+}
+
 func (h *actorHarness) claimParamsForSectors(sectors []*miner.SectorOnChainInfo, addition bool) *power.UpdateClaimedPowerParams {
 	multiplier := big.NewInt(1)
 	if !addition {
@@ -2436,6 +4039,29 @@ func makeDeadlineCronEventParams(t testing.TB, epoch abi.ChainEpoch) *power.Enro
 	}
 }
 
+func makeEarlyTerminationCronEventParams(t testing.TB, epoch abi.ChainEpoch) *power.EnrollCronEventParams {
+	eventPayload := miner.CronEventPayload{EventType: miner.CronEventProcessEarlyTerminations}
+	buf := bytes.Buffer{}
+	err := eventPayload.MarshalCBOR(&buf)
+	require.NoError(t, err)
+	return &power.EnrollCronEventParams{
+		EventEpoch: epoch,
+		Payload:    buf.Bytes(),
+	}
+}
+
+func liveSectorCount(t testing.TB, rt *mock.Runtime) uint64 {
+	st := getState(rt)
+	deadlines, err := st.LoadDeadlines(rt.AdtStore())
+	require.NoError(t, err)
+	var count uint64
+	require.NoError(t, deadlines.ForEach(rt.AdtStore(), func(_ uint64, dl *miner.Deadline) error {
+		count += dl.LiveSectors
+		return nil
+	}))
+	return count
+}
+
 func makeProveCommit(sectorNo abi.SectorNumber) *miner.ProveCommitSectorParams {
 	return &miner.ProveCommitSectorParams{
 		SectorNumber: sectorNo,
@@ -2506,13 +4132,15 @@ func fixedHasher(target uint64) func([]byte) [32]byte {
 
 func expectQueryNetworkInfo(rt *mock.Runtime, h *actorHarness) {
 	currentPower := power.CurrentTotalPowerReturn{
-		RawBytePower:     h.networkRawPower,
-		QualityAdjPower:  h.networkQAPower,
-		PledgeCollateral: h.networkPledge,
+		RawBytePower:            h.networkRawPower,
+		QualityAdjPower:         h.networkQAPower,
+		PledgeCollateral:        h.networkPledge,
+		QualityAdjPowerSmoothed: h.networkQAPowerEstimate(),
 	}
 	currentReward := reward.ThisEpochRewardReturn{
-		ThisEpochReward:        h.epochReward,
-		ThisEpochBaselinePower: h.baselinePower,
+		ThisEpochReward:         h.epochReward,
+		ThisEpochRewardSmoothed: h.rewardEstimate(),
+		ThisEpochBaselinePower:  h.baselinePower,
 	}
 
 	rt.ExpectSend(
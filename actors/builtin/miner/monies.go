@@ -4,6 +4,7 @@ import (
 	"github.com/filecoin-project/specs-actors/actors/abi"
 	"github.com/filecoin-project/specs-actors/actors/abi/big"
 	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 // IP = IPBase(precommit time) + AdditionalIP(precommit time)
@@ -27,8 +28,12 @@ var UndeclaredFaultFactorDenom = big.NewInt(1)
 // This is the BR(t) value of the given sector for the current epoch.
 // It is the expected reward this sector would pay out over a one day period.
 // BR(t) = CurrEpochReward(t) * SectorQualityAdjustedPower * EpochsInDay / TotalNetworkQualityAdjustedPower(t)
-func ExpectedDayRewardForPower(epochTargetReward abi.TokenAmount, networkQAPower abi.StoragePower, qaSectorPower abi.StoragePower) abi.TokenAmount {
-	if networkQAPower.IsZero() {
+// The epoch reward and network power are smoothed estimates, so that a single epoch's swing in either
+// does not move the value.
+func ExpectedDayRewardForPower(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
+	epochTargetReward := big.Max(rewardEstimate.Estimate(), big.Zero())
+	networkQAPower := networkQAPowerEstimate.Estimate()
+	if networkQAPower.LessThanEqual(big.Zero()) {
 		return epochTargetReward
 	}
 	expectedRewardForProvingPeriod := big.Mul(big.NewInt(builtin.EpochsInDay), epochTargetReward)
@@ -38,29 +43,29 @@ func ExpectedDayRewardForPower(epochTargetReward abi.TokenAmount, networkQAPower
 // This is the FF(t) penalty for a sector expected to be in the fault state either because the fault was declared or because
 // it has been previously detected by the network.
 // FF(t) = DeclaredFaultFactor * BR(t)
-func PledgePenaltyForDeclaredFault(epochReward abi.TokenAmount, networkQAPower abi.StoragePower, qaSectorPower abi.StoragePower) abi.TokenAmount {
+func PledgePenaltyForDeclaredFault(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
 	return big.Div(
-		big.Mul(DeclaredFaultFactorNum, ExpectedDayRewardForPower(epochReward, networkQAPower, qaSectorPower)),
+		big.Mul(DeclaredFaultFactorNum, ExpectedDayRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower)),
 		DeclaredFaultFactorDenom)
 }
 
 // This is the SP(t) penalty for a newly faulty sector that has not been declared.
 // SP(t) = UndeclaredFaultFactor * BR(t)
-func PledgePenaltyForUndeclaredFault(epochReward abi.TokenAmount, networkQAPower abi.StoragePower, qaSectorPower abi.StoragePower) abi.TokenAmount {
+func PledgePenaltyForUndeclaredFault(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
 	return big.Div(
-		big.Mul(UndeclaredFaultFactorNum, ExpectedDayRewardForPower(epochReward, networkQAPower, qaSectorPower)),
+		big.Mul(UndeclaredFaultFactorNum, ExpectedDayRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower)),
 		UndeclaredFaultFactorDenom)
 }
 
 // Penalty to locked pledge collateral for the termination of a sector before scheduled expiry.
 // SectorAge is the time between the sector's activation and termination.
-func PledgePenaltyForTermination(initialPledge abi.TokenAmount, sectorAge abi.ChainEpoch, epochTargetReward abi.TokenAmount, networkQAPower, qaSectorPower abi.StoragePower) abi.TokenAmount {
+func PledgePenaltyForTermination(initialPledge abi.TokenAmount, sectorAge abi.ChainEpoch, rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
 	// max(SP(t), IP + BR(StartEpoch)*min(SectorAgeInDays, 180))
 	// where BR(StartEpoch)=IP/InitialPledgeFactor
 	// and sectorAgeInDays = sectorAge / EpochsInDay
 	cappedSectorAge := big.NewInt(int64(minEpoch(sectorAge, 180*builtin.EpochsInDay)))
 	return big.Max(
-		PledgePenaltyForUndeclaredFault(epochTargetReward, networkQAPower, qaSectorPower),
+		PledgePenaltyForUndeclaredFault(rewardEstimate, networkQAPowerEstimate, qaSectorPower),
 		big.Add(
 			initialPledge,
 			big.Div(
@@ -68,13 +73,30 @@ func PledgePenaltyForTermination(initialPledge abi.TokenAmount, sectorAge abi.Ch
 				big.Mul(InitialPledgeFactor, big.NewInt(builtin.EpochsInDay)))))
 }
 
+// Fixed penalty charged to a miner for a Window PoSt that is successfully disputed, in addition to the
+// undeclared fault penalty for the power that was falsely claimed.
+var BasePenaltyForDisputedWindowPoSt = big.Mul(big.NewInt(20), abi.TokenPrecision) // PARAM_FINISH
+
+// Reward paid to the party that successfully disputes a Window PoSt.
+// The reward is paid out of the penalty collected from the miner.
+var BaseRewardForDisputedWindowPoSt = big.Mul(big.NewInt(4), abi.TokenPrecision) // PARAM_FINISH
+
+// Fraction of each block reward that is locked, vesting according to LockedRewardVestingSpec.
+var LockedRewardFactorNum = big.NewInt(75)    // PARAM_FINISH
+var LockedRewardFactorDenom = big.NewInt(100) // PARAM_FINISH
+
+// The amount of a block reward that is locked rather than immediately available to the miner.
+func LockedRewardFromReward(reward abi.TokenAmount) abi.TokenAmount {
+	return big.Div(big.Mul(reward, LockedRewardFactorNum), LockedRewardFactorDenom)
+}
+
 // Computes the pledge requirement for committing new quality-adjusted power to the network, given the current
-// total power, total pledge commitment, epoch block reward, and circulating token supply.
+// total power and epoch block reward estimates, total pledge commitment, and circulating token supply.
 // In plain language, the pledge requirement is a multiple of the block reward expected to be earned by the
 // newly-committed power, holding the per-epoch block reward constant (though in reality it will change over time).
-func InitialPledgeForPower(qaPower abi.StoragePower, networkQAPower, baselinePower abi.StoragePower, networkTotalPledge abi.TokenAmount, epochTargetReward abi.TokenAmount, networkCirculatingSupply abi.TokenAmount) abi.TokenAmount {
-
-	ipBase := big.Mul(InitialPledgeFactor, ExpectedDayRewardForPower(epochTargetReward, networkQAPower, qaPower))
+func InitialPledgeForPower(qaPower abi.StoragePower, networkQAPowerEstimate smoothing.FilterEstimate, baselinePower abi.StoragePower, networkTotalPledge abi.TokenAmount, rewardEstimate smoothing.FilterEstimate, networkCirculatingSupply abi.TokenAmount) abi.TokenAmount {
+	networkQAPower := networkQAPowerEstimate.Estimate()
+	ipBase := big.Mul(InitialPledgeFactor, ExpectedDayRewardForPower(rewardEstimate, networkQAPowerEstimate, qaPower))
 
 	lockTargetNum := big.Mul(LockTargetFactorNum, networkCirculatingSupply)
 	lockTargetDenom := LockTargetFactorDenom
@@ -9,13 +9,14 @@ import (
 	"github.com/filecoin-project/specs-actors/actors/abi"
 	"github.com/filecoin-project/specs-actors/actors/abi/big"
 	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 // Test termination fee
 func TestPledgePenaltyForTermination(t *testing.T) {
-	epochTargetReward := abi.NewTokenAmount(1 << 50)
+	epochTargetReward := smoothing.NewEstimate(abi.NewTokenAmount(1<<50), big.Zero())
 	qaSectorPower := abi.NewStoragePower(1 << 36)
-	networkQAPower := abi.NewStoragePower(1 << 50)
+	networkQAPower := smoothing.NewEstimate(abi.NewStoragePower(1<<50), big.Zero())
 	undeclaredPenalty := miner.PledgePenaltyForUndeclaredFault(epochTargetReward, networkQAPower, qaSectorPower)
 
 	t.Run("when undeclared fault fee exceeds expected reward, returns undeclaraed fault fee", func(t *testing.T) {
@@ -85,6 +85,20 @@ func (p *Partition) ActiveSectors() (*abi.BitField, error) {
 	return active, err
 }
 
+// Proving sectors are those expected to be proven at the partition's next Window PoSt:
+// the active sectors, and the faulty sectors declared to be recovering.
+func (p *Partition) ProvingSectors() (*abi.BitField, error) {
+	active, err := p.ActiveSectors()
+	if err != nil {
+		return nil, err
+	}
+	proving, err := bitfield.MergeBitFields(active, p.Recoveries)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to compute proving sectors: %w", err)
+	}
+	return proving, nil
+}
+
 // Active power is power of non-faulty sectors.
 func (p *Partition) ActivePower() PowerPair {
 	return p.LivePower.Sub(p.FaultyPower)
@@ -290,6 +304,76 @@ func (p *Partition) ReplaceSectors(store adt.Store, oldSectors, newSectors []*Se
 	return powerDelta, pledgeDelta, nil
 }
 
+// Assigns the partition's live sectors new, consecutive numbers starting at firstSectorNo, in order of their
+// current numbers, and removes its terminated sectors.
+// The partition must have no early terminations awaiting processing.
+// Returns the mapping from old to new sector numbers, and the numbers of the terminated sectors removed.
+// Power and pledge are unchanged.
+func (p *Partition) RenumberSectors(store adt.Store, firstSectorNo abi.SectorNumber, quant QuantSpec) (map[uint64]uint64, *abi.BitField, error) {
+	earlyTerminated, err := adt.AsArray(store, p.EarlyTerminated)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to load early terminations: %w", err)
+	}
+	if earlyTerminated.Length() > 0 {
+		return nil, nil, xerrors.Errorf("cannot renumber sectors with early terminations pending")
+	}
+
+	live, err := p.LiveSectors()
+	if err != nil {
+		return nil, nil, err
+	}
+	renumbered := make(map[uint64]uint64)
+	nextSectorNo := uint64(firstSectorNo)
+	if err = live.ForEach(func(sectorNo uint64) error {
+		renumbered[sectorNo] = nextSectorNo
+		nextSectorNo++
+		return nil
+	}); err != nil {
+		return nil, nil, xerrors.Errorf("failed to iterate live sectors: %w", err)
+	}
+	expirations, err := LoadExpirationQueue(store, p.ExpirationsEpochs, quant)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to load sector expirations: %w", err)
+	}
+	if err = expirations.RenumberSectors(renumbered); err != nil {
+		return nil, nil, xerrors.Errorf("failed to renumber sector expirations: %w", err)
+	}
+	if p.ExpirationsEpochs, err = expirations.Root(); err != nil {
+		return nil, nil, xerrors.Errorf("failed to save sector expirations: %w", err)
+	}
+
+	// Update partition metadata.
+	if p.Sectors, err = renumberBitField(live, renumbered); err != nil {
+		return nil, nil, xerrors.Errorf("failed to renumber sectors: %w", err)
+	}
+	if p.Faults, err = renumberBitField(p.Faults, renumbered); err != nil {
+		return nil, nil, xerrors.Errorf("failed to renumber faults: %w", err)
+	}
+	if p.Recoveries, err = renumberBitField(p.Recoveries, renumbered); err != nil {
+		return nil, nil, xerrors.Errorf("failed to renumber recoveries: %w", err)
+	}
+	removed := p.Terminated
+	p.Terminated = abi.NewBitField()
+	// No change to power.
+	return renumbered, removed, nil
+}
+
+// Maps each sector number in a bitfield to its new number. Every sector number in the bitfield must be mapped.
+func renumberBitField(sectorNos *abi.BitField, renumbered map[uint64]uint64) (*abi.BitField, error) {
+	var newSectorNos []uint64
+	if err := sectorNos.ForEach(func(sectorNo uint64) error {
+		newSectorNo, ok := renumbered[sectorNo]
+		if !ok {
+			return xerrors.Errorf("no new number for sector %d", sectorNo)
+		}
+		newSectorNos = append(newSectorNos, newSectorNo)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return bitfield.NewFromSet(newSectorNos), nil
+}
+
 // Record the epoch of any sectors expiring early, for termination fee calculation later.
 func (p *Partition) recordEarlyTermination(store adt.Store, epoch abi.ChainEpoch, sectors *bitfield.BitField) error {
 	etQueue, err := LoadBitfieldQueue(store, p.EarlyTerminated, NoQuantization)
@@ -95,6 +95,11 @@ func TestPartitions(t *testing.T) {
 		require.NoError(t, err)
 
 		assertPartitionState(t, store, partition, quantSpec, sectorSize, sectors, bf(1, 2, 3, 4, 5, 6), bf(4, 5, 6), bf(4, 5), bf())
+
+		// recovering sectors are proven along with active ones
+		proving, err := partition.ProvingSectors()
+		require.NoError(t, err)
+		assertBitfieldEquals(t, proving, 1, 2, 3, 4, 5)
 	})
 
 	t.Run("remove recoveries", func(t *testing.T) {
@@ -286,6 +291,54 @@ func TestPartitions(t *testing.T) {
 			Equals(t, queue)
 	})
 
+	t.Run("renumber sectors", func(t *testing.T) {
+		rt, store, partition := setup(t)
+
+		// fault sector 4, 5 and 6, and mark 4 as a recovery
+		faultSet := bf(4, 5, 6)
+		_, err := partition.AddFaults(store, faultSet, selectSectors(t, sectors, faultSet), abi.ChainEpoch(7), sectorSize, quantSpec)
+		require.NoError(t, err)
+		err = partition.AddRecoveries(bf(4), miner.PowerForSectors(sectorSize, selectSectors(t, sectors, bf(4))))
+		require.NoError(t, err)
+
+		// terminate 1 and 3, and process the terminations
+		_, err = partition.TerminateSectors(store, abi.ChainEpoch(3), selectSectors(t, sectors, bf(1, 3)), sectorSize, quantSpec)
+		require.NoError(t, err)
+		_, hasMore, err := partition.PopEarlyTerminations(store, 10)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+
+		renumbered, removed, err := partition.RenumberSectors(store, 100, quantSpec)
+		require.NoError(t, err)
+		assert.Equal(t, map[uint64]uint64{2: 100, 4: 101, 5: 102, 6: 103}, renumbered)
+		assertBitfieldEquals(t, removed, 1, 3)
+
+		var renumberedSectors []*miner.SectorOnChainInfo
+		for _, sector := range selectSectors(t, sectors, bf(2, 4, 5, 6)) {
+			renumberedSector := *sector
+			renumberedSector.SectorNumber = abi.SectorNumber(renumbered[uint64(sector.SectorNumber)])
+			renumberedSectors = append(renumberedSectors, &renumberedSector)
+		}
+
+		// terminated sectors are dropped and the rest renumbered in order
+		assertPartitionState(t, store, partition, quantSpec, sectorSize, renumberedSectors, bf(100, 101, 102, 103), bf(101, 102, 103), bf(101), bf())
+
+		assertPartitionExpirationQueue(t, rt, partition, quantSpec, []expectExpirationGroup{
+			{expiration: 5, sectors: bf(100)},
+			{expiration: 9, sectors: bf(101, 102, 103)},
+		})
+	})
+
+	t.Run("renumber sectors errors while early terminations are pending", func(t *testing.T) {
+		_, store, partition := setup(t)
+
+		_, err := partition.TerminateSectors(store, abi.ChainEpoch(3), selectSectors(t, sectors, bf(1)), sectorSize, quantSpec)
+		require.NoError(t, err)
+
+		_, _, err = partition.RenumberSectors(store, 100, quantSpec)
+		assert.Error(t, err)
+	})
+
 	t.Run("pop expiring sectors", func(t *testing.T) {
 		rt, store, partition := setup(t)
 
@@ -10,6 +10,7 @@ import (
 	big "github.com/filecoin-project/specs-actors/actors/abi/big"
 	builtin "github.com/filecoin-project/specs-actors/actors/builtin"
 	. "github.com/filecoin-project/specs-actors/actors/util"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 // The period over which all a miner's active sectors will be challenged.
@@ -55,6 +56,9 @@ func loadPartitionsSectorsMax(partitionSectorCount uint64) uint64 {
 // The maximum number of new sectors that may be staged by a miner during a single proving period.
 const NewSectorsPerPeriodMax = 128 << 10
 
+// The maximum number of sectors that may be updated with a single ProveReplicaUpdates message.
+const ProveReplicaUpdatesMaxSize = 25 // PARAM_FINISH
+
 // Epochs after which chain state is final.
 const ChainFinality = abi.ChainEpoch(900)
 
@@ -102,12 +106,21 @@ const FaultDeclarationCutoff = WPoStChallengeLookback + 50
 // The maximum age of a fault before the sector is terminated.
 var FaultMaxAge = WPoStProvingPeriod * 14
 
+// Period after a deadline's challenge window closes during which a Window PoSt accepted without verification
+// may be disputed. This must be shorter than the interval between successive instances of the same deadline,
+// so that a snapshot is never replaced while it is still open to dispute.
+const WPoStDisputeWindow = 2 * ChainFinality // PARAM_FINISH
+
 // Staging period for a miner worker key change.
 // Finality is a harsh delay for a miner who has lost their worker key, as the miner will miss Window PoSts until
 // it can be changed. It's the only safe value, though. We may implement a mitigation mechanism such as a second
 // key or allowing the owner account to submit PoSts while a key change is pending.
 const WorkerKeyChangeDelay = ChainFinality
 
+// Number of epochs after a consensus fault is reported during which the miner may not win elections.
+// Further faults committed during this period are not penalized again.
+const ConsensusFaultIneligibilityDuration = ChainFinality // PARAM_FINISH
+
 // Maximum number of epochs past the current epoch a sector may be set to expire.
 // The actual maximum extension will be the minimum of CurrEpoch + MaximumSectorExpirationExtension
 // and sector.ActivationEpoch+sealProof.SectorMaximumLifetime()
@@ -152,13 +165,18 @@ func QAPowerForWeight(size abi.SectorSize, duration abi.ChainEpoch, dealWeight,
 
 // Returns the quality-adjusted power for a sector.
 func QAPowerForSector(size abi.SectorSize, sector *SectorOnChainInfo) abi.StoragePower {
-	duration := sector.Expiration - sector.Activation
+	// Deal weights of an updated sector are integrated over its remaining lifetime from the update.
+	start := sector.Activation
+	if sector.ReplicaUpdateEpoch > start {
+		start = sector.ReplicaUpdateEpoch
+	}
+	duration := sector.Expiration - start
 	return QAPowerForWeight(size, duration, sector.DealWeight, sector.VerifiedDealWeight)
 }
 
 // Deposit per sector required at pre-commitment, refunded after the commitment is proven (else burned).
-func precommitDeposit(qaSectorPower abi.StoragePower, networkQAPower abi.StoragePower, baselinePower abi.StoragePower, networkTotalPledge, epochTargetReward, circulatingSupply abi.TokenAmount) abi.TokenAmount {
-	return InitialPledgeForPower(qaSectorPower, networkQAPower, baselinePower, networkTotalPledge, epochTargetReward, circulatingSupply)
+func PreCommitDepositForPower(qaSectorPower abi.StoragePower, networkQAPowerEstimate smoothing.FilterEstimate, baselinePower abi.StoragePower, networkTotalPledge abi.TokenAmount, rewardEstimate smoothing.FilterEstimate, circulatingSupply abi.TokenAmount) abi.TokenAmount {
+	return InitialPledgeForPower(qaSectorPower, networkQAPowerEstimate, baselinePower, networkTotalPledge, rewardEstimate, circulatingSupply)
 }
 
 // Determine maximum number of deal miner's sector can hold
@@ -204,6 +222,17 @@ var RewardVestingSpec = VestSpec{
 	Quantization: 12 * builtin.EpochsInHour,                 // PARAM_FINISH
 }
 
+// Schedule on which the locked portion of each block reward vests.
+// Unlike RewardVestingSpec, which applies to funds a miner chooses to lock, there is no initial delay.
+// Locking most of every reward already keeps up to 180 days of recent rewards at stake against a quick exit,
+// so a delay would only withhold funds from miners that stay, without adding to that incentive.
+var LockedRewardVestingSpec = VestSpec{
+	InitialDelay: abi.ChainEpoch(0),                         // PARAM_FINISH
+	VestPeriod:   abi.ChainEpoch(180 * builtin.EpochsInDay), // PARAM_FINISH
+	StepDuration: abi.ChainEpoch(1 * builtin.EpochsInDay),   // PARAM_FINISH
+	Quantization: 12 * builtin.EpochsInHour,                 // PARAM_FINISH
+}
+
 func RewardForConsensusSlashReport(elapsedEpoch abi.ChainEpoch, collateral abi.TokenAmount) abi.TokenAmount {
 	// PARAM_FINISH
 	// var growthRate = SLASHER_SHARE_GROWTH_RATE_NUM / SLASHER_SHARE_GROWTH_RATE_DENOM
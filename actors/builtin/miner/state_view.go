@@ -0,0 +1,109 @@
+package miner
+
+import (
+	cid "github.com/ipfs/go-cid"
+	xerrors "golang.org/x/xerrors"
+
+	abi "github.com/filecoin-project/specs-actors/actors/abi"
+	adt "github.com/filecoin-project/specs-actors/actors/util/adt"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
+)
+
+// A read-only view of a miner actor's state, for answering queries outside of actor execution.
+// The view has no dependency on a runtime; it reads state from a store as of some state root.
+type StateView struct {
+	store adt.Store
+	st    State
+}
+
+// The state of a single sector, as reported by a StateView.
+type SectorSummary struct {
+	Info      SectorOnChainInfo
+	Deadline  uint64 // Index of the deadline to which the sector is assigned
+	Partition uint64 // Index of the partition, within the deadline, holding the sector
+	Status    SectorStatus
+	QAPower   abi.StoragePower // Quality-adjusted power of the sector while active
+}
+
+// Loads a view of the miner state at some root.
+func LoadStateView(store adt.Store, root cid.Cid) (*StateView, error) {
+	view := StateView{store: store}
+	if err := store.Get(store.Context(), root, &view.st); err != nil {
+		return nil, xerrors.Errorf("failed to load miner state %v: %w", root, err)
+	}
+	return &view, nil
+}
+
+// Returns the miner's owner, worker and other static information.
+func (v *StateView) Info() (*MinerInfo, error) {
+	return v.st.GetInfo(v.store)
+}
+
+// Returns the proving period deadline that is current as of some epoch.
+func (v *StateView) CurrentDeadline(currEpoch abi.ChainEpoch) *DeadlineInfo {
+	return v.st.DeadlineInfo(currEpoch)
+}
+
+// Returns the next challenge window for a deadline that has not elapsed as of some epoch.
+// This is the window in which a Window PoSt for the deadline's partitions will next be due.
+func (v *StateView) NextPoSt(dlIdx uint64, currEpoch abi.ChainEpoch) (*DeadlineInfo, error) {
+	if dlIdx >= WPoStPeriodDeadlines {
+		return nil, xerrors.Errorf("invalid deadline %d", dlIdx)
+	}
+	return NewDeadlineInfo(v.st.ProvingPeriodStart, dlIdx, currEpoch).NextNotElapsed(), nil
+}
+
+// Returns the next count deadline windows that have not elapsed as of some epoch, with the partitions and sectors
+// to be proven in each.
+func (v *StateView) ProvingSchedule(currEpoch abi.ChainEpoch, count int) ([]ScheduledDeadline, error) {
+	deadlines, err := v.st.LoadDeadlines(v.store)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load deadlines: %w", err)
+	}
+	return ProvingSchedule(v.store, v.st.ProvingPeriodStart, v.st.CurrentDeadline, deadlines, currEpoch, count)
+}
+
+// Returns up to limit sectors with numbers in [from, until), the cursor from which to load the next page,
+// and whether the range is exhausted.
+func (v *StateView) Sectors(from, until abi.SectorNumber, limit uint64) ([]*SectorOnChainInfo, abi.SectorNumber, bool, error) {
+	return v.st.LoadSectorsPage(v.store, from, until, limit)
+}
+
+// Returns the location, status and on-chain information of a sector.
+// Returns false if the sector is not in the sector set, e.g. if it has never been proven
+// or has been terminated and removed.
+func (v *StateView) Sector(sectorNo abi.SectorNumber) (*SectorSummary, bool, error) {
+	sector, found, err := v.st.GetSector(v.store, sectorNo)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load sector %d: %w", sectorNo, err)
+	} else if !found {
+		return nil, false, nil
+	}
+
+	dlIdx, pIdx, err := v.st.FindSector(v.store, sectorNo)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to find sector %d: %w", sectorNo, err)
+	}
+	status, err := v.st.SectorStatus(v.store, dlIdx, pIdx, sectorNo)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to check status of sector %d: %w", sectorNo, err)
+	}
+	info, err := v.st.GetInfo(v.store)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &SectorSummary{
+		Info:      *sector,
+		Deadline:  dlIdx,
+		Partition: pIdx,
+		Status:    status,
+		QAPower:   QAPowerForSector(info.SectorSize, sector),
+	}, true, nil
+}
+
+// Returns the block reward the sector is expected to earn per day while active, given the smoothed estimates
+// of the epoch block reward and total network quality-adjusted power.
+func (s *SectorSummary) ExpectedDayReward(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate) abi.TokenAmount {
+	return ExpectedDayRewardForPower(rewardEstimate, networkQAPowerEstimate, s.QAPower)
+}
@@ -13,7 +13,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{142}
+var lengthBufState = []byte{148}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -66,6 +66,22 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.ThisEpochQAPowerSmoothed (smoothing.FilterEstimate) (struct)
+	if err := t.ThisEpochQAPowerSmoothed.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.QAPowerSmoothedEpoch (abi.ChainEpoch) (int64)
+	if t.QAPowerSmoothedEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.QAPowerSmoothedEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.QAPowerSmoothedEpoch-1)); err != nil {
+			return err
+		}
+	}
+
 	// t.MinerCount (int64) (int64)
 	if t.MinerCount >= 0 {
 		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.MinerCount)); err != nil {
@@ -123,6 +139,53 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		}
 	}
 
+	// t.ProofValidationQueue (cid.Cid) (struct)
+
+	if t.ProofValidationQueue == nil {
+		if _, err := w.Write(cbg.CborNull); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteCidBuf(scratch, w, *t.ProofValidationQueue); err != nil {
+			return xerrors.Errorf("failed to write cid field t.ProofValidationQueue: %w", err)
+		}
+	}
+
+	// t.CronEventBacklog (int64) (int64)
+	if t.CronEventBacklog >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.CronEventBacklog)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.CronEventBacklog-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.ProofValidationBacklog (int64) (int64)
+	if t.ProofValidationBacklog >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ProofValidationBacklog)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ProofValidationBacklog-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.SealProofPolicies ([]power.SealProofPolicy) (slice)
+	if len(t.SealProofPolicies) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.SealProofPolicies was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.SealProofPolicies))); err != nil {
+		return err
+	}
+	for _, v := range t.SealProofPolicies {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -140,7 +203,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 14 {
+	if extra != 20 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -216,6 +279,40 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		}
 
 	}
+	// t.ThisEpochQAPowerSmoothed (smoothing.FilterEstimate) (struct)
+
+	{
+
+		if err := t.ThisEpochQAPowerSmoothed.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ThisEpochQAPowerSmoothed: %w", err)
+		}
+
+	}
+	// t.QAPowerSmoothedEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.QAPowerSmoothedEpoch = abi.ChainEpoch(extraI)
+	}
 	// t.MinerCount (int64) (int64)
 	{
 		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
@@ -330,43 +427,401 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			}
 		} else {
 
-			c, err := cbg.ReadCid(br)
-			if err != nil {
-				return xerrors.Errorf("failed to read cid field t.ProofValidationBatch: %w", err)
-			}
+			c, err := cbg.ReadCid(br)
+			if err != nil {
+				return xerrors.Errorf("failed to read cid field t.ProofValidationBatch: %w", err)
+			}
+
+			t.ProofValidationBatch = &c
+		}
+
+	}
+	// t.ProofValidationQueue (cid.Cid) (struct)
+
+	{
+
+		pb, err := br.PeekByte()
+		if err != nil {
+			return err
+		}
+		if pb == cbg.CborNull[0] {
+			var nbuf [1]byte
+			if _, err := br.Read(nbuf[:]); err != nil {
+				return err
+			}
+		} else {
+
+			c, err := cbg.ReadCid(br)
+			if err != nil {
+				return xerrors.Errorf("failed to read cid field t.ProofValidationQueue: %w", err)
+			}
+
+			t.ProofValidationQueue = &c
+		}
+
+	}
+	// t.CronEventBacklog (int64) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.CronEventBacklog = int64(extraI)
+	}
+	// t.ProofValidationBacklog (int64) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ProofValidationBacklog = int64(extraI)
+	}
+	// t.SealProofPolicies ([]power.SealProofPolicy) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.SealProofPolicies: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.SealProofPolicies = make([]SealProofPolicy, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v SealProofPolicy
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.SealProofPolicies[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufClaim = []byte{131}
+
+func (t *Claim) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufClaim); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.SealProofType (abi.RegisteredSealProof) (int64)
+	if t.SealProofType >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SealProofType)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.SealProofType-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.RawBytePower (big.Int) (struct)
+	if err := t.RawBytePower.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.QualityAdjPower (big.Int) (struct)
+	if err := t.QualityAdjPower.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *Claim) UnmarshalCBOR(r io.Reader) error {
+	*t = Claim{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.SealProofType (abi.RegisteredSealProof) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.SealProofType = abi.RegisteredSealProof(extraI)
+	}
+	// t.RawBytePower (big.Int) (struct)
+
+	{
+
+		if err := t.RawBytePower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RawBytePower: %w", err)
+		}
+
+	}
+	// t.QualityAdjPower (big.Int) (struct)
+
+	{
+
+		if err := t.QualityAdjPower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.QualityAdjPower: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufCronEvent = []byte{130}
+
+func (t *CronEvent) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufCronEvent); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.MinerAddr (address.Address) (struct)
+	if err := t.MinerAddr.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.CallbackPayload ([]uint8) (slice)
+	if len(t.CallbackPayload) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.CallbackPayload was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.CallbackPayload))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.CallbackPayload); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *CronEvent) UnmarshalCBOR(r io.Reader) error {
+	*t = CronEvent{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.MinerAddr (address.Address) (struct)
+
+	{
+
+		if err := t.MinerAddr.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.MinerAddr: %w", err)
+		}
+
+	}
+	// t.CallbackPayload ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.CallbackPayload: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+	t.CallbackPayload = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.CallbackPayload); err != nil {
+		return err
+	}
+	return nil
+}
+
+var lengthBufDeferredProof = []byte{130}
+
+func (t *DeferredProof) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDeferredProof); err != nil {
+		return err
+	}
+
+	// t.Miner (address.Address) (struct)
+	if err := t.Miner.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Info (abi.SealVerifyInfo) (struct)
+	if err := t.Info.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *DeferredProof) UnmarshalCBOR(r io.Reader) error {
+	*t = DeferredProof{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Miner (address.Address) (struct)
+
+	{
+
+		if err := t.Miner.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Miner: %w", err)
+		}
+
+	}
+	// t.Info (abi.SealVerifyInfo) (struct)
+
+	{
 
-			t.ProofValidationBatch = &c
+		if err := t.Info.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Info: %w", err)
 		}
 
 	}
 	return nil
 }
 
-var lengthBufClaim = []byte{130}
+var lengthBufSealProofPolicy = []byte{131}
 
-func (t *Claim) MarshalCBOR(w io.Writer) error {
+func (t *SealProofPolicy) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufClaim); err != nil {
+	if _, err := w.Write(lengthBufSealProofPolicy); err != nil {
 		return err
 	}
 
-	// t.RawBytePower (big.Int) (struct)
-	if err := t.RawBytePower.MarshalCBOR(w); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.SealProof (abi.RegisteredSealProof) (int64)
+	if t.SealProof >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SealProof)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.SealProof-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.ConsensusMinerMinPower (big.Int) (struct)
+	if err := t.ConsensusMinerMinPower.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.QualityAdjPower (big.Int) (struct)
-	if err := t.QualityAdjPower.MarshalCBOR(w); err != nil {
+	// t.AllowNewMiners (bool) (bool)
+	if err := cbg.WriteBool(w, t.AllowNewMiners); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *Claim) UnmarshalCBOR(r io.Reader) error {
-	*t = Claim{}
+func (t *SealProofPolicy) UnmarshalCBOR(r io.Reader) error {
+	*t = SealProofPolicy{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -379,66 +834,95 @@ func (t *Claim) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.RawBytePower (big.Int) (struct)
-
+	// t.SealProof (abi.RegisteredSealProof) (int64)
 	{
-
-		if err := t.RawBytePower.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.RawBytePower: %w", err)
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
 		}
 
+		t.SealProof = abi.RegisteredSealProof(extraI)
 	}
-	// t.QualityAdjPower (big.Int) (struct)
+	// t.ConsensusMinerMinPower (big.Int) (struct)
 
 	{
 
-		if err := t.QualityAdjPower.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.QualityAdjPower: %w", err)
+		if err := t.ConsensusMinerMinPower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ConsensusMinerMinPower: %w", err)
 		}
 
 	}
+	// t.AllowNewMiners (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.AllowNewMiners = false
+	case 21:
+		t.AllowNewMiners = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
 	return nil
 }
 
-var lengthBufCronEvent = []byte{130}
+var lengthBufConstructorParams = []byte{129}
 
-func (t *CronEvent) MarshalCBOR(w io.Writer) error {
+func (t *ConstructorParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufCronEvent); err != nil {
+	if _, err := w.Write(lengthBufConstructorParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.MinerAddr (address.Address) (struct)
-	if err := t.MinerAddr.MarshalCBOR(w); err != nil {
-		return err
-	}
-
-	// t.CallbackPayload ([]uint8) (slice)
-	if len(t.CallbackPayload) > cbg.ByteArrayMaxLen {
-		return xerrors.Errorf("Byte array in field t.CallbackPayload was too long")
+	// t.SealProofPolicies ([]power.SealProofPolicy) (slice)
+	if len(t.SealProofPolicies) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.SealProofPolicies was too long")
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.CallbackPayload))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.SealProofPolicies))); err != nil {
 		return err
 	}
-
-	if _, err := w.Write(t.CallbackPayload); err != nil {
-		return err
+	for _, v := range t.SealProofPolicies {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (t *CronEvent) UnmarshalCBOR(r io.Reader) error {
-	*t = CronEvent{}
+func (t *ConstructorParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ConstructorParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -451,36 +935,39 @@ func (t *CronEvent) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 1 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.MinerAddr (address.Address) (struct)
-
-	{
-
-		if err := t.MinerAddr.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.MinerAddr: %w", err)
-		}
-
-	}
-	// t.CallbackPayload ([]uint8) (slice)
+	// t.SealProofPolicies ([]power.SealProofPolicy) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
 		return err
 	}
 
-	if extra > cbg.ByteArrayMaxLen {
-		return fmt.Errorf("t.CallbackPayload: byte array too large (%d)", extra)
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.SealProofPolicies: array too large (%d)", extra)
 	}
-	if maj != cbg.MajByteString {
-		return fmt.Errorf("expected byte array")
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
 	}
-	t.CallbackPayload = make([]byte, extra)
-	if _, err := io.ReadFull(br, t.CallbackPayload); err != nil {
-		return err
+
+	if extra > 0 {
+		t.SealProofPolicies = make([]SealProofPolicy, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v SealProofPolicy
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.SealProofPolicies[i] = v
 	}
+
 	return nil
 }
 
@@ -781,6 +1268,108 @@ func (t *EnrollCronEventParams) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
+var lengthBufDeleteMinerParams = []byte{129}
+
+func (t *DeleteMinerParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDeleteMinerParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.CronEpochs ([]abi.ChainEpoch) (slice)
+	if len(t.CronEpochs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.CronEpochs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.CronEpochs))); err != nil {
+		return err
+	}
+	for _, v := range t.CronEpochs {
+		if v >= 0 {
+			if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(v)); err != nil {
+				return err
+			}
+		} else {
+			if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-v-1)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *DeleteMinerParams) UnmarshalCBOR(r io.Reader) error {
+	*t = DeleteMinerParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.CronEpochs ([]abi.ChainEpoch) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.CronEpochs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.CronEpochs = make([]abi.ChainEpoch, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+		{
+			maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+			var extraI int64
+			if err != nil {
+				return err
+			}
+			switch maj {
+			case cbg.MajUnsignedInt:
+				extraI = int64(extra)
+				if extraI < 0 {
+					return fmt.Errorf("int64 positive overflow")
+				}
+			case cbg.MajNegativeInt:
+				extraI = int64(extra)
+				if extraI < 0 {
+					return fmt.Errorf("int64 negative oveflow")
+				}
+				extraI = -1 - extraI
+			default:
+				return fmt.Errorf("wrong type for int64 field: %d", maj)
+			}
+
+			t.CronEpochs[i] = abi.ChainEpoch(extraI)
+		}
+	}
+
+	return nil
+}
+
 var lengthBufUpdateClaimedPowerParams = []byte{130}
 
 func (t *UpdateClaimedPowerParams) MarshalCBOR(w io.Writer) error {
@@ -905,7 +1494,7 @@ func (t *CreateMinerReturn) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufCurrentTotalPowerReturn = []byte{131}
+var lengthBufCurrentTotalPowerReturn = []byte{132}
 
 func (t *CurrentTotalPowerReturn) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -930,6 +1519,11 @@ func (t *CurrentTotalPowerReturn) MarshalCBOR(w io.Writer) error {
 	if err := t.PledgeCollateral.MarshalCBOR(w); err != nil {
 		return err
 	}
+
+	// t.QualityAdjPowerSmoothed (smoothing.FilterEstimate) (struct)
+	if err := t.QualityAdjPowerSmoothed.MarshalCBOR(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -947,7 +1541,7 @@ func (t *CurrentTotalPowerReturn) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 3 {
+	if extra != 4 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -978,6 +1572,99 @@ func (t *CurrentTotalPowerReturn) UnmarshalCBOR(r io.Reader) error {
 		}
 
 	}
+	// t.QualityAdjPowerSmoothed (smoothing.FilterEstimate) (struct)
+
+	{
+
+		if err := t.QualityAdjPowerSmoothed.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.QualityAdjPowerSmoothed: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufMinerPowerReturn = []byte{131}
+
+func (t *MinerPowerReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufMinerPowerReturn); err != nil {
+		return err
+	}
+
+	// t.RawBytePower (big.Int) (struct)
+	if err := t.RawBytePower.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.QualityAdjPower (big.Int) (struct)
+	if err := t.QualityAdjPower.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.MeetsConsensusMinimum (bool) (bool)
+	if err := cbg.WriteBool(w, t.MeetsConsensusMinimum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *MinerPowerReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = MinerPowerReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.RawBytePower (big.Int) (struct)
+
+	{
+
+		if err := t.RawBytePower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RawBytePower: %w", err)
+		}
+
+	}
+	// t.QualityAdjPower (big.Int) (struct)
+
+	{
+
+		if err := t.QualityAdjPower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.QualityAdjPower: %w", err)
+		}
+
+	}
+	// t.MeetsConsensusMinimum (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.MeetsConsensusMinimum = false
+	case 21:
+		t.MeetsConsensusMinimum = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
 	return nil
 }
 
@@ -7,8 +7,38 @@ import (
 // Minimum number of registered miners for the minimum miner size limit to effectively limit consensus power.
 const ConsensusMinerMinMiners = 3
 
-// Minimum power of an individual miner to meet the threshold for leader election.
+// Minimum power of an individual miner to meet the threshold for leader election, for each seal proof type
+// in the default seal proof policies.
 var ConsensusMinerMinPower = abi.NewStoragePower(1 << 40) // PARAM_FINISH
 
 // Maximum number of prove commits a miner can submit in one epoch
 const MaxMinerProveCommitsPerEpoch = 8000
+
+// Maximum number of deferred cron event callbacks invoked in one epoch.
+// Further events are deferred to the following epoch.
+var MaxCronEventsPerEpoch = uint64(1000) // PARAM_FINISH
+
+// Maximum number of queued seal proofs verified in one epoch.
+// Further proofs are deferred to the following epoch.
+var MaxProofVerificationsPerEpoch = uint64(10_000) // PARAM_FINISH
+
+// Seal proof policies installed at construction if none are provided at genesis.
+// Each seal proof type is allowed for new miners, with the same consensus minimum power.
+func DefaultSealProofPolicies() []SealProofPolicy {
+	proofs := []abi.RegisteredSealProof{
+		abi.RegisteredSealProof_StackedDrg2KiBV1,
+		abi.RegisteredSealProof_StackedDrg8MiBV1,
+		abi.RegisteredSealProof_StackedDrg512MiBV1,
+		abi.RegisteredSealProof_StackedDrg32GiBV1,
+		abi.RegisteredSealProof_StackedDrg64GiBV1,
+	}
+	policies := make([]SealProofPolicy, len(proofs))
+	for i, proof := range proofs {
+		policies[i] = SealProofPolicy{
+			SealProof:              proof,
+			ConsensusMinerMinPower: ConsensusMinerMinPower,
+			AllowNewMiners:         true,
+		}
+	}
+	return policies
+}
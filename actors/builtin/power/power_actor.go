@@ -16,6 +16,7 @@ import (
 	exitcode "github.com/filecoin-project/specs-actors/actors/runtime/exitcode"
 	. "github.com/filecoin-project/specs-actors/actors/util"
 	adt "github.com/filecoin-project/specs-actors/actors/util/adt"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 type Runtime = vmr.Runtime
@@ -45,6 +46,8 @@ func (a Actor) Exports() []interface{} {
 		7:                         a.OnConsensusFault,
 		8:                         a.SubmitPoRepForBulkVerify,
 		9:                         a.CurrentTotalPower,
+		10:                        a.DeleteMiner,
+		11:                        a.MinerPower,
 	}
 }
 
@@ -71,9 +74,31 @@ type SectorStorageWeightDesc struct {
 // Actor methods
 ////////////////////////////////////////////////////////////////////////////////
 
-func (a Actor) Constructor(rt Runtime, _ *adt.EmptyValue) *adt.EmptyValue {
+type ConstructorParams struct {
+	// Policies for the seal proof types with which miners may be created.
+	// If empty, the DefaultSealProofPolicies are installed.
+	SealProofPolicies []SealProofPolicy
+}
+
+func (a Actor) Constructor(rt Runtime, params *ConstructorParams) *adt.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
 
+	policies := params.SealProofPolicies
+	if len(policies) == 0 {
+		policies = DefaultSealProofPolicies()
+	}
+	seen := map[abi.RegisteredSealProof]bool{}
+	for _, policy := range policies {
+		if seen[policy.SealProof] {
+			rt.Abortf(exitcode.ErrIllegalArgument, "duplicate policy for seal proof type %d", policy.SealProof)
+		}
+		seen[policy.SealProof] = true
+		if policy.ConsensusMinerMinPower.LessThan(big.Zero()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "negative consensus minimum power %v for seal proof type %d",
+				policy.ConsensusMinerMinPower, policy.SealProof)
+		}
+	}
+
 	emptyMap, err := adt.MakeEmptyMap(adt.AsStore(rt)).Root()
 	if err != nil {
 		rt.Abortf(exitcode.ErrIllegalState, "failed to create storage power state: %v", err)
@@ -83,7 +108,7 @@ func (a Actor) Constructor(rt Runtime, _ *adt.EmptyValue) *adt.EmptyValue {
 		rt.Abortf(exitcode.ErrIllegalState, "failed to get empty multimap cid")
 	}
 
-	st := ConstructState(emptyMap, emptyMMapCid)
+	st := ConstructState(emptyMap, emptyMMapCid, policies)
 	rt.State().Create(st)
 	return nil
 }
@@ -104,6 +129,13 @@ type CreateMinerReturn struct {
 func (a Actor) CreateMiner(rt Runtime, params *CreateMinerParams) *CreateMinerReturn {
 	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
 
+	var st State
+	rt.State().Readonly(&st)
+	policy, ok := st.GetSealProofPolicy(params.SealProofType)
+	if !ok || !policy.AllowNewMiners {
+		rt.Abortf(exitcode.ErrIllegalArgument, "seal proof type %d not allowed for new miners", params.SealProofType)
+	}
+
 	ctorParams := MinerConstructorParams{
 		OwnerAddr:     params.Owner,
 		WorkerAddr:    params.Worker,
@@ -132,12 +164,11 @@ func (a Actor) CreateMiner(rt Runtime, params *CreateMinerParams) *CreateMinerRe
 		rt.Abortf(exitcode.ErrIllegalState, "unmarshaling exec return value: %v", err)
 	}
 
-	var st State
 	rt.State().Transaction(&st, func() interface{} {
 		claims, err := adt.AsMap(adt.AsStore(rt), st.Claims)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load claims")
 
-		err = setClaim(claims, addresses.IDAddress, &Claim{abi.NewStoragePower(0), abi.NewStoragePower(0)})
+		err = setClaim(claims, addresses.IDAddress, &Claim{params.SealProofType, abi.NewStoragePower(0), abi.NewStoragePower(0)})
 		if err != nil {
 			rt.Abortf(exitcode.ErrIllegalState, "failed to put power in claimed table while creating miner: %v", err)
 		}
@@ -237,6 +268,15 @@ func (a Actor) OnEpochTickEnd(rt Runtime, _ *adt.EmptyValue) *adt.EmptyValue {
 		st.ThisEpochPledgeCollateral = st.TotalPledgeCollateral
 		st.ThisEpochQualityAdjPower = qaPower
 		st.ThisEpochRawBytePower = rawBytePower
+		if !st.QAPowerObserved() {
+			// The estimate is seeded from the first power observed, rather than filtered up from zero.
+			st.ThisEpochQAPowerSmoothed = smoothing.NewEstimate(qaPower, big.Zero())
+		} else {
+			// This method is not invoked for null rounds, so the estimate is extrapolated across any since the last update.
+			delta := rt.CurrEpoch() - st.QAPowerSmoothedEpoch
+			st.ThisEpochQAPowerSmoothed = smoothing.NextEstimate(st.ThisEpochQAPowerSmoothed, qaPower, delta)
+		}
+		st.QAPowerSmoothedEpoch = rt.CurrEpoch()
 		return nil
 	})
 
@@ -343,9 +383,10 @@ func (a Actor) SubmitPoRepForBulkVerify(rt Runtime, sealInfo *abi.SealVerifyInfo
 }
 
 type CurrentTotalPowerReturn struct {
-	RawBytePower     abi.StoragePower
-	QualityAdjPower  abi.StoragePower
-	PledgeCollateral abi.TokenAmount
+	RawBytePower            abi.StoragePower
+	QualityAdjPower         abi.StoragePower
+	PledgeCollateral        abi.TokenAmount
+	QualityAdjPowerSmoothed smoothing.FilterEstimate
 }
 
 // Returns the total power and pledge recorded by the power actor.
@@ -358,16 +399,94 @@ func (a Actor) CurrentTotalPower(rt Runtime, _ *adt.EmptyValue) *CurrentTotalPow
 	rt.State().Readonly(&st)
 
 	return &CurrentTotalPowerReturn{
-		RawBytePower:     st.ThisEpochRawBytePower,
-		QualityAdjPower:  st.ThisEpochQualityAdjPower,
-		PledgeCollateral: st.ThisEpochPledgeCollateral,
+		RawBytePower:            st.ThisEpochRawBytePower,
+		QualityAdjPower:         st.ThisEpochQualityAdjPower,
+		PledgeCollateral:        st.ThisEpochPledgeCollateral,
+		QualityAdjPowerSmoothed: st.ThisEpochQAPowerSmoothed,
+	}
+}
+
+type MinerPowerReturn struct {
+	RawBytePower          abi.StoragePower
+	QualityAdjPower       abi.StoragePower
+	MeetsConsensusMinimum bool
+}
+
+// Returns a miner's claimed power and whether it meets the consensus minimum.
+// Eligibility to win elections further depends on the miner not having committed a recent consensus fault,
+// which is recorded by the miner actor.
+func (a Actor) MinerPower(rt Runtime, minerAddr *addr.Address) *MinerPowerReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.State().Readonly(&st)
+
+	claim, found, err := st.GetClaim(adt.AsStore(rt), *minerAddr)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get claim for miner %v", minerAddr)
+	if !found {
+		rt.Abortf(exitcode.ErrNotFound, "no claim for miner %v", minerAddr)
+	}
+
+	meetsMinimum, err := st.claimMeetsConsensusMinimum(claim)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check consensus minimum for miner %v", minerAddr)
+
+	return &MinerPowerReturn{
+		RawBytePower:          claim.RawBytePower,
+		QualityAdjPower:       claim.QualityAdjPower,
+		MeetsConsensusMinimum: meetsMinimum,
 	}
 }
 
+type DeleteMinerParams struct {
+	CronEpochs []abi.ChainEpoch // Epochs at which the miner may have cron events enrolled.
+}
+
+// Removes the calling miner's claim from the power table, and its cron events at the epochs it names, ahead of
+// the miner deleting itself.
+// The claim must have no remaining power.
+func (a Actor) DeleteMiner(rt Runtime, params *DeleteMinerParams) *adt.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)
+	minerAddr := rt.Message().Caller()
+
+	var st State
+	rt.State().Transaction(&st, func() interface{} {
+		claims, err := adt.AsMap(adt.AsStore(rt), st.Claims)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load claims")
+
+		claim, found, err := getClaim(claims, minerAddr)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get claim for miner %v", minerAddr)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "no claim for miner %v", minerAddr)
+		}
+		if !claim.RawBytePower.IsZero() || !claim.QualityAdjPower.IsZero() {
+			rt.Abortf(exitcode.ErrForbidden, "miner %v still claims power (%v, %v)", minerAddr, claim.RawBytePower, claim.QualityAdjPower)
+		}
+
+		err = claims.Delete(AddrKey(minerAddr))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete claim for miner %v", minerAddr)
+		st.MinerCount -= 1
+
+		st.Claims, err = claims.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush claims")
+
+		events, err := adt.AsMultimap(adt.AsStore(rt), st.CronEventQueue)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load cron events")
+		err = removeMinerCronEvents(events, minerAddr, params.CronEpochs)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to remove cron events for miner %v", minerAddr)
+		st.CronEventQueue, err = events.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush events")
+		return nil
+	})
+	return nil
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // Method utility functions
 ////////////////////////////////////////////////////////////////////////////////
 
+// Verifies up to MaxProofVerificationsPerEpoch queued seal proofs. Proofs deferred from earlier epochs are verified
+// first, in the order in which they were deferred, followed by the batch in the order of the batch's miners and of
+// each miner's proofs. Proofs beyond the limit are appended to the deferred queue, so that every proof is verified
+// ahead of any submitted after it was deferred.
 func (a Actor) processBatchProofVerifies(rt Runtime) error {
 	var st State
 
@@ -376,40 +495,69 @@ func (a Actor) processBatchProofVerifies(rt Runtime) error {
 
 	rt.State().Transaction(&st, func() interface{} {
 		store := adt.AsStore(rt)
-		if st.ProofValidationBatch == nil {
-			return nil
+		budget := MaxProofVerificationsPerEpoch
+		verify := func(m address.Address, info abi.SealVerifyInfo) {
+			if _, ok := verifies[m]; !ok {
+				miners = append(miners, m)
+			}
+			verifies[m] = append(verifies[m], info)
+			budget--
 		}
-		mmap, err := adt.AsMultimap(store, *st.ProofValidationBatch)
-		if err != nil {
-			rt.Abortf(exitcode.ErrIllegalState, "failed to load proofs validation batch: %s", err)
+
+		// Proofs beyond the limit, in the order in which they are to be verified in later epochs.
+		deferred := adt.MakeEmptyArray(store)
+
+		if st.ProofValidationQueue != nil {
+			queue, err := adt.AsArray(store, *st.ProofValidationQueue)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deferred proofs queue")
+
+			var proof DeferredProof
+			err = queue.ForEach(&proof, func(i int64) error {
+				if budget == 0 {
+					return deferred.AppendContinuous(&proof)
+				}
+				verify(proof.Miner, proof.Info)
+				return nil
+			})
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to iterate deferred proofs queue")
 		}
 
-		err = mmap.ForAll(func(k string, arr *adt.Array) error {
-			a, err := address.NewFromBytes([]byte(k))
-			if err != nil {
-				return xerrors.Errorf("failed to parse address key: %w", err)
-			}
+		if st.ProofValidationBatch != nil {
+			mmap, err := adt.AsMultimap(store, *st.ProofValidationBatch)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proofs validation batch")
 
-			miners = append(miners, a)
+			err = mmap.ForAll(func(k string, arr *adt.Array) error {
+				m, err := address.NewFromBytes([]byte(k))
+				if err != nil {
+					return xerrors.Errorf("failed to parse address key: %w", err)
+				}
 
-			var infos []abi.SealVerifyInfo
-			var svi abi.SealVerifyInfo
-			err = arr.ForEach(&svi, func(i int64) error {
-				infos = append(infos, svi)
+				var info abi.SealVerifyInfo
+				err = arr.ForEach(&info, func(i int64) error {
+					if budget == 0 {
+						return deferred.AppendContinuous(&DeferredProof{Miner: m, Info: info})
+					}
+					verify(m, info)
+					return nil
+				})
+				if err != nil {
+					return xerrors.Errorf("failed to iterate over proof verify array for miner %s: %w", m, err)
+				}
 				return nil
 			})
-			if err != nil {
-				return xerrors.Errorf("failed to iterate over proof verify array for miner %s: %w", a, err)
-			}
-			verifies[a] = infos
-			return nil
-		})
-		if err != nil {
-			rt.Abortf(exitcode.ErrIllegalState, "failed to iterate proof batch: %s", err)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to iterate proof batch")
 		}
 
 		st.ProofValidationBatch = nil
-
+		st.ProofValidationBacklog = int64(deferred.Length())
+		if st.ProofValidationBacklog == 0 {
+			st.ProofValidationQueue = nil
+		} else {
+			root, err := deferred.Root()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush deferred proofs queue")
+			st.ProofValidationQueue = &root
+			rt.Log(vmr.INFO, "deferred verification of %d proofs to next epoch", st.ProofValidationBacklog)
+		}
 		return nil
 	})
 
@@ -454,6 +602,8 @@ func (a Actor) processBatchProofVerifies(rt Runtime) error {
 	return nil
 }
 
+// Invokes up to MaxCronEventsPerEpoch cron events due at or before the current epoch, in order of epoch and then
+// of enrollment. Events beyond the limit remain queued, in order, for the next epoch.
 func (a Actor) processDeferredCronEvents(rt Runtime) error {
 	rtEpoch := rt.CurrEpoch()
 
@@ -463,23 +613,53 @@ func (a Actor) processDeferredCronEvents(rt Runtime) error {
 		events, err := adt.AsMultimap(adt.AsStore(rt), st.CronEventQueue)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load cron events")
 
+		budget := MaxCronEventsPerEpoch
+		firstCronEpoch := rtEpoch + 1
+		st.CronEventBacklog = 0
 		for epoch := st.FirstCronEpoch; epoch <= rtEpoch; epoch++ {
+			if budget == 0 {
+				// Count the deferred events without loading them.
+				arr, found, err := events.Get(epochKey(epoch))
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load cron events at %v", epoch)
+				if found && arr.Length() > 0 {
+					st.CronEventBacklog += int64(arr.Length())
+					if epoch < firstCronEpoch {
+						firstCronEpoch = epoch
+					}
+				}
+				continue
+			}
+
 			epochEvents, err := loadCronEvents(events, epoch)
-			if err != nil {
-				return errors.Wrapf(err, "failed to load cron events at %v", epoch)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load cron events at %v", epoch)
+			if len(epochEvents) == 0 {
+				continue
 			}
 
+			var deferred []CronEvent
+			if uint64(len(epochEvents)) > budget {
+				deferred = epochEvents[budget:]
+				epochEvents = epochEvents[:budget]
+			}
+			budget -= uint64(len(epochEvents))
 			cronEvents = append(cronEvents, epochEvents...)
 
-			if len(epochEvents) > 0 {
-				err = events.RemoveAll(epochKey(epoch))
-				if err != nil {
-					return errors.Wrapf(err, "failed to clear cron events at %v", epoch)
-				}
+			err = events.RemoveAll(epochKey(epoch))
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to clear cron events at %v", epoch)
+			for i := range deferred {
+				err = events.Add(epochKey(epoch), &deferred[i])
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to defer cron event at %v", epoch)
+			}
+			if len(deferred) > 0 {
+				st.CronEventBacklog += int64(len(deferred))
+				firstCronEpoch = epoch
 			}
 		}
 
-		st.FirstCronEpoch = rtEpoch + 1
+		st.FirstCronEpoch = firstCronEpoch
+		if st.CronEventBacklog > 0 {
+			rt.Log(vmr.INFO, "deferred %d cron events to next epoch", st.CronEventBacklog)
+		}
 
 		st.CronEventQueue, err = events.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush events")
@@ -3,6 +3,7 @@ package power
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	addr "github.com/filecoin-project/go-address"
 	cid "github.com/ipfs/go-cid"
@@ -13,6 +14,7 @@ import (
 	big "github.com/filecoin-project/specs-actors/actors/abi/big"
 	. "github.com/filecoin-project/specs-actors/actors/util"
 	adt "github.com/filecoin-project/specs-actors/actors/util/adt"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 type State struct {
@@ -29,6 +31,11 @@ type State struct {
 	ThisEpochRawBytePower     abi.StoragePower
 	ThisEpochQualityAdjPower  abi.StoragePower
 	ThisEpochPledgeCollateral abi.TokenAmount
+	// Smoothed estimate of total quality-adjusted power, updated along with the values above.
+	// The estimate is zero until power is first observed, from which it is then seeded.
+	ThisEpochQAPowerSmoothed smoothing.FilterEstimate
+	// Epoch at which the smoothed estimate was last updated, from which the next update extrapolates.
+	QAPowerSmoothedEpoch abi.ChainEpoch
 
 	MinerCount int64
 	// Number of miners having proven the minimum consensus power.
@@ -45,9 +52,33 @@ type State struct {
 	Claims cid.Cid // Map, HAMT[address]Claim
 
 	ProofValidationBatch *cid.Cid
+
+	// Proofs deferred from earlier epochs' batches, in the order in which they are to be verified.
+	// These are verified ahead of the batch and don't count against a miner's per-epoch submission limit.
+	ProofValidationQueue *cid.Cid // Array, AMT[]DeferredProof
+
+	// Numbers of cron events and proof verifications deferred to the next epoch by the last cron tick,
+	// having exceeded the limits on work per epoch.
+	CronEventBacklog       int64
+	ProofValidationBacklog int64
+
+	// Policies for each seal proof type with which miners may be created, set at genesis.
+	SealProofPolicies []SealProofPolicy
+}
+
+// The policy applied to miners using some seal proof type.
+type SealProofPolicy struct {
+	SealProof abi.RegisteredSealProof
+	// Minimum power of an individual miner using the proof type to meet the threshold for leader election.
+	ConsensusMinerMinPower abi.StoragePower
+	// Whether new miners may be created with the proof type. Existing miners are unaffected.
+	AllowNewMiners bool
 }
 
 type Claim struct {
+	// Seal proof type of the miner, which determines the consensus minimum power applied to its claim.
+	SealProofType abi.RegisteredSealProof
+
 	// Sum of raw byte power for a miner's sectors.
 	RawBytePower abi.StoragePower
 
@@ -55,6 +86,12 @@ type Claim struct {
 	QualityAdjPower abi.StoragePower
 }
 
+// A seal proof deferred from an earlier epoch's batch, awaiting verification.
+type DeferredProof struct {
+	Miner addr.Address
+	Info  abi.SealVerifyInfo
+}
+
 type CronEvent struct {
 	MinerAddr       addr.Address
 	CallbackPayload []byte
@@ -62,7 +99,7 @@ type CronEvent struct {
 
 type AddrKey = adt.AddrKey
 
-func ConstructState(emptyMapCid, emptyMMapCid cid.Cid) *State {
+func ConstructState(emptyMapCid, emptyMMapCid cid.Cid, sealProofPolicies []SealProofPolicy) *State {
 	return &State{
 		TotalRawBytePower:         abi.NewStoragePower(0),
 		TotalBytesCommitted:       abi.NewStoragePower(0),
@@ -72,36 +109,65 @@ func ConstructState(emptyMapCid, emptyMMapCid cid.Cid) *State {
 		ThisEpochRawBytePower:     abi.NewStoragePower(0),
 		ThisEpochQualityAdjPower:  abi.NewStoragePower(0),
 		ThisEpochPledgeCollateral: abi.NewTokenAmount(0),
+		ThisEpochQAPowerSmoothed:  smoothing.NewEstimate(big.Zero(), big.Zero()),
+		QAPowerSmoothedEpoch:      0,
 		FirstCronEpoch:            0,
 		CronEventQueue:            emptyMapCid,
 		Claims:                    emptyMapCid,
 		MinerCount:                0,
 		MinerAboveMinPowerCount:   0,
+		SealProofPolicies:         sealProofPolicies,
+	}
+}
+
+// Returns whether the smoothed power estimate has observed any power. Until it has, the estimate is zero.
+func (st *State) QAPowerObserved() bool {
+	return !st.ThisEpochQAPowerSmoothed.PositionEstimate.IsZero() || !st.ThisEpochQAPowerSmoothed.VelocityEstimate.IsZero()
+}
+
+// Returns the policy for a seal proof type, or false if the type has no policy.
+func (st *State) GetSealProofPolicy(sealProof abi.RegisteredSealProof) (*SealProofPolicy, bool) {
+	for i := range st.SealProofPolicies {
+		if st.SealProofPolicies[i].SealProof == sealProof {
+			return &st.SealProofPolicies[i], true
+		}
+	}
+	return nil, false
+}
+
+// Returns the minimum power for a miner using a seal proof type to meet the threshold for leader election.
+func (st *State) consensusMinerMinPower(sealProof abi.RegisteredSealProof) (abi.StoragePower, error) {
+	policy, ok := st.GetSealProofPolicy(sealProof)
+	if !ok {
+		return abi.NewStoragePower(0), xerrors.Errorf("no policy for seal proof type %d", sealProof)
 	}
+	return policy.ConsensusMinerMinPower, nil
 }
 
 // MinerNominalPowerMeetsConsensusMinimum is used to validate Election PoSt
 // winners outside the chain state. If the miner has over a threshold of power
 // the miner meets the minimum.  If the network is a below a threshold of
 // miners and has power > zero the miner meets the minimum.
-func (st *State) MinerNominalPowerMeetsConsensusMinimum(s adt.Store, miner addr.Address) (bool, error) { //nolint:deadcode,unused
-	claims, err := adt.AsMap(s, st.Claims)
-	if err != nil {
-		return false, xerrors.Errorf("failed to load claims: %w", err)
-	}
-
-	claim, ok, err := getClaim(claims, miner)
+func (st *State) MinerNominalPowerMeetsConsensusMinimum(s adt.Store, miner addr.Address) (bool, error) {
+	claim, ok, err := st.GetClaim(s, miner)
 	if err != nil {
 		return false, err
 	}
 	if !ok {
 		return false, errors.Errorf("no claim for actor %v", miner)
 	}
+	return st.claimMeetsConsensusMinimum(claim)
+}
 
+func (st *State) claimMeetsConsensusMinimum(claim *Claim) (bool, error) {
 	minerNominalPower := claim.QualityAdjPower
+	minPower, err := st.consensusMinerMinPower(claim.SealProofType)
+	if err != nil {
+		return false, err
+	}
 
 	// if miner is larger than min power requirement, we're set
-	if minerNominalPower.GreaterThanEqual(ConsensusMinerMinPower) {
+	if minerNominalPower.GreaterThanEqual(minPower) {
 		return true, nil
 	}
 
@@ -114,6 +180,97 @@ func (st *State) MinerNominalPowerMeetsConsensusMinimum(s adt.Store, miner addr.
 	return minerNominalPower.GreaterThanEqual(abi.NewStoragePower(0)), nil
 }
 
+// Returns a miner's claimed power, or false if the miner has no claim.
+func (st *State) GetClaim(s adt.Store, miner addr.Address) (*Claim, bool, error) {
+	claims, err := adt.AsMap(s, st.Claims)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load claims: %w", err)
+	}
+	return getClaim(claims, miner)
+}
+
+// A miner's power, as listed by ListMinersMeetingConsensusMinimum.
+type MinerPower struct {
+	Miner addr.Address
+	Claim Claim
+	// The miner's fraction of total quality-adjusted power, in Q.128 format.
+	QAPowerFraction big.Int
+}
+
+// Lists the miners with non-zero power meeting the consensus minimum, along with their fractions of the
+// quality-adjusted power recorded for this epoch.
+// Claims are visited in the order of adt.Map.ForEachAfter, resuming after the cursor miner's key whether or not
+// it still has a claim, or from the first claim if the cursor is undefined. Up to limit miners are returned.
+// Also returns the cursor from which to list the next page, being the last claim visited,
+// and whether all claims have been visited.
+func (st *State) ListMinersMeetingConsensusMinimum(s adt.Store, cursor addr.Address, limit uint64) ([]MinerPower, addr.Address, bool, error) {
+	if limit == 0 {
+		return nil, cursor, false, xerrors.Errorf("page limit must be positive")
+	}
+	claims, err := adt.AsMap(s, st.Claims)
+	if err != nil {
+		return nil, cursor, false, xerrors.Errorf("failed to load claims: %w", err)
+	}
+
+	var after adt.Keyer
+	if cursor != addr.Undef {
+		after = AddrKey(cursor)
+	}
+	next := cursor
+	var miners []MinerPower
+	var claim Claim
+	err = claims.ForEachAfter(after, &claim, func(key string) error {
+		if uint64(len(miners)) == limit {
+			return errHaltIteration
+		}
+		miner, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return xerrors.Errorf("invalid claim address key: %w", err)
+		}
+		next = miner
+		// A miner with no power cannot win an election, even while it nominally meets the minimum.
+		if claim.QualityAdjPower.IsZero() {
+			return nil
+		}
+		meets, err := st.claimMeetsConsensusMinimum(&claim)
+		if err != nil {
+			return err
+		} else if !meets {
+			return nil
+		}
+		fraction := big.Zero()
+		if st.ThisEpochQualityAdjPower.GreaterThan(big.Zero()) {
+			fraction = big.Lsh(claim.QualityAdjPower, fractionPrecision) // Q.0 => Q.128
+			fraction = big.Div(fraction, st.ThisEpochQualityAdjPower)    // Q.128 / Q.0 => Q.128
+		}
+		miners = append(miners, MinerPower{Miner: miner, Claim: claim, QAPowerFraction: fraction})
+		return nil
+	})
+	if err == errHaltIteration {
+		return miners, next, false, nil
+	} else if err != nil {
+		return nil, cursor, false, xerrors.Errorf("failed to iterate claims: %w", err)
+	}
+	return miners, next, true, nil
+}
+
+// Precision of power fractions, which are in Q.128 format.
+const fractionPrecision = 128
+
+// Sentinel error used to halt map iteration, never returned to callers.
+var errHaltIteration = errors.New("halt iteration")
+
+// MinerEligibleForElection returns whether a miner may win an election at some epoch.
+// The end of the miner's consensus fault ineligibility period is recorded by the miner actor
+// (MinerInfo.ConsensusFaultElapsed) and must be provided by the caller.
+// A miner is eligible if it is not serving a consensus fault ineligibility period and its power meets the consensus minimum.
+func (st *State) MinerEligibleForElection(s adt.Store, miner addr.Address, consensusFaultElapsed, currEpoch abi.ChainEpoch) (bool, error) {
+	if currEpoch <= consensusFaultElapsed {
+		return false, nil
+	}
+	return st.MinerNominalPowerMeetsConsensusMinimum(s, miner)
+}
+
 // Parameters may be negative to subtract.
 func (st *State) AddToClaim(s adt.Store, miner addr.Address, power abi.StoragePower, qapower abi.StoragePower) error {
 	claims, err := adt.AsMap(s, st.Claims)
@@ -147,12 +304,17 @@ func (st *State) addToClaim(claims *adt.Map, miner addr.Address, power abi.Stora
 	st.TotalBytesCommitted = big.Add(st.TotalBytesCommitted, power)
 
 	newClaim := Claim{
+		SealProofType:   oldClaim.SealProofType,
 		RawBytePower:    big.Add(oldClaim.RawBytePower, power),
 		QualityAdjPower: big.Add(oldClaim.QualityAdjPower, qapower),
 	}
 
-	prevBelow := oldClaim.QualityAdjPower.LessThan(ConsensusMinerMinPower)
-	stillBelow := newClaim.QualityAdjPower.LessThan(ConsensusMinerMinPower)
+	minPower, err := st.consensusMinerMinPower(oldClaim.SealProofType)
+	if err != nil {
+		return xerrors.Errorf("failed to get consensus minimum for claim of %v: %w", miner, err)
+	}
+	prevBelow := oldClaim.QualityAdjPower.LessThan(minPower)
+	stillBelow := newClaim.QualityAdjPower.LessThan(minPower)
 
 	if prevBelow && !stillBelow {
 		// just passed min miner size
@@ -206,6 +368,42 @@ func (st *State) appendCronEvent(events *adt.Multimap, epoch abi.ChainEpoch, eve
 	return nil
 }
 
+// Removes a miner's cron events at some epochs from the queue, retaining the order of other miners' events.
+func removeMinerCronEvents(events *adt.Multimap, miner addr.Address, epochs []abi.ChainEpoch) error {
+	sorted := make([]abi.ChainEpoch, len(epochs))
+	copy(sorted, epochs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	for i, epoch := range sorted {
+		if i > 0 && epoch == sorted[i-1] {
+			continue
+		}
+		epochEvents, err := loadCronEvents(events, epoch)
+		if err != nil {
+			return xerrors.Errorf("failed to load cron events at %d: %w", epoch, err)
+		}
+		var others []CronEvent
+		for _, ev := range epochEvents {
+			if ev.MinerAddr != miner {
+				others = append(others, ev)
+			}
+		}
+		if len(others) == len(epochEvents) {
+			continue
+		}
+
+		if err := events.RemoveAll(epochKey(epoch)); err != nil {
+			return xerrors.Errorf("failed to clear cron events at %d: %w", epoch, err)
+		}
+		for j := range others {
+			if err := events.Add(epochKey(epoch), &others[j]); err != nil {
+				return xerrors.Errorf("failed to restore cron event at %d: %w", epoch, err)
+			}
+		}
+	}
+	return nil
+}
+
 func loadCronEvents(mmap *adt.Multimap, epoch abi.ChainEpoch) ([]CronEvent, error) {
 	var events []CronEvent
 	var ev CronEvent
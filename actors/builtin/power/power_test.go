@@ -22,6 +22,7 @@ import (
 	vmr "github.com/filecoin-project/specs-actors/actors/runtime"
 	exitcode "github.com/filecoin-project/specs-actors/actors/runtime/exitcode"
 	adt "github.com/filecoin-project/specs-actors/actors/util/adt"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 	mock "github.com/filecoin-project/specs-actors/support/mock"
 	tutil "github.com/filecoin-project/specs-actors/support/testing"
 )
@@ -66,12 +67,110 @@ func TestConstruction(t *testing.T) {
 		found, err_ := claim.Get(asKey(keys[0]), &actualClaim)
 		require.NoError(t, err_)
 		assert.True(t, found)
-		assert.Equal(t, power.Claim{big.Zero(), big.Zero()}, actualClaim) // miner has not proven anything
+		assert.Equal(t, power.Claim{abi.RegisteredSealProof_StackedDrg2KiBV1, big.Zero(), big.Zero()}, actualClaim) // miner has not proven anything
 
 		verifyEmptyMap(t, rt, st.CronEventQueue)
 	})
 }
 
+func TestSealProofPolicies(t *testing.T) {
+	actor := newHarness(t)
+	owner := tutil.NewIDAddr(t, 101)
+	miner1 := tutil.NewIDAddr(t, 111)
+	miner2 := tutil.NewIDAddr(t, 112)
+	miner3 := tutil.NewIDAddr(t, 113)
+	miner4 := tutil.NewIDAddr(t, 114)
+
+	smallMinPower := abi.NewStoragePower(1 << 20)
+	largeMinPower := abi.NewStoragePower(10 << 40)
+	policies := []power.SealProofPolicy{
+		{SealProof: abi.RegisteredSealProof_StackedDrg2KiBV1, ConsensusMinerMinPower: smallMinPower, AllowNewMiners: false},
+		{SealProof: abi.RegisteredSealProof_StackedDrg8MiBV1, ConsensusMinerMinPower: smallMinPower, AllowNewMiners: true},
+		{SealProof: abi.RegisteredSealProof_StackedDrg32GiBV1, ConsensusMinerMinPower: largeMinPower, AllowNewMiners: true},
+	}
+
+	builder := mock.NewBuilder(context.Background(), builtin.StoragePowerActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	createMiner := func(rt *mock.Runtime, miner addr.Address, sealProof abi.RegisteredSealProof) {
+		actor.createMiner(rt, owner, owner, miner, tutil.NewActorAddr(t, miner.String()), abi.PeerID("miner"), nil, sealProof, big.Zero())
+	}
+
+	t.Run("installs default policies", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		st := getState(rt)
+		policy, found := st.GetSealProofPolicy(abi.RegisteredSealProof_StackedDrg64GiBV1)
+		require.True(t, found)
+		assert.Equal(t, power.ConsensusMinerMinPower, policy.ConsensusMinerMinPower)
+		assert.True(t, policy.AllowNewMiners)
+	})
+
+	t.Run("rejects invalid policies", func(t *testing.T) {
+		rt := builder.Build(t)
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.Constructor, &power.ConstructorParams{SealProofPolicies: append(policies, policies[0])})
+		})
+
+		rt = builder.Build(t)
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.Constructor, &power.ConstructorParams{SealProofPolicies: []power.SealProofPolicy{
+				{SealProof: abi.RegisteredSealProof_StackedDrg32GiBV1, ConsensusMinerMinPower: big.NewInt(-1), AllowNewMiners: true},
+			}})
+		})
+	})
+
+	t.Run("creates miners only with allowed proof types", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructWithPolicies(rt, policies)
+
+		createMiner(rt, miner1, abi.RegisteredSealProof_StackedDrg32GiBV1)
+		assert.Equal(t, abi.RegisteredSealProof_StackedDrg32GiBV1, actor.getClaim(rt, miner1).SealProofType)
+
+		for _, sealProof := range []abi.RegisteredSealProof{
+			abi.RegisteredSealProof_StackedDrg2KiBV1,  // deprecated
+			abi.RegisteredSealProof_StackedDrg64GiBV1, // no policy
+		} {
+			rt.SetCaller(owner, builtin.AccountActorCodeID)
+			rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
+			rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+				rt.Call(actor.CreateMiner, &power.CreateMinerParams{Owner: owner, Worker: owner, SealProofType: sealProof})
+			})
+		}
+		assert.Equal(t, int64(1), getState(rt).MinerCount)
+	})
+
+	t.Run("applies consensus minimum for each miner's proof type", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructWithPolicies(rt, policies)
+
+		createMiner(rt, miner1, abi.RegisteredSealProof_StackedDrg8MiBV1)
+		createMiner(rt, miner2, abi.RegisteredSealProof_StackedDrg8MiBV1)
+		createMiner(rt, miner3, abi.RegisteredSealProof_StackedDrg8MiBV1)
+		createMiner(rt, miner4, abi.RegisteredSealProof_StackedDrg32GiBV1)
+
+		// Small miners reach their minimum, so consensus minimums apply.
+		actor.updateClaimedPower(rt, miner1, smallMinPower, smallMinPower)
+		actor.updateClaimedPower(rt, miner2, smallMinPower, smallMinPower)
+		actor.updateClaimedPower(rt, miner3, smallMinPower, smallMinPower)
+		// The same power doesn't meet the minimum for the large proof type.
+		actor.updateClaimedPower(rt, miner4, smallMinPower, smallMinPower)
+
+		st := getState(rt)
+		assert.Equal(t, int64(3), st.MinerAboveMinPowerCount)
+		actor.expectTotalPowerEager(rt, big.Mul(smallMinPower, big.NewInt(3)), big.Mul(smallMinPower, big.NewInt(3)))
+		assert.True(t, actor.minerPower(rt, miner1).MeetsConsensusMinimum)
+		assert.False(t, actor.minerPower(rt, miner4).MeetsConsensusMinimum)
+
+		actor.updateClaimedPower(rt, miner4, largeMinPower, largeMinPower)
+		assert.Equal(t, int64(4), getState(rt).MinerAboveMinPowerCount)
+		assert.True(t, actor.minerPower(rt, miner4).MeetsConsensusMinimum)
+	})
+}
+
 func TestPowerAndPledgeAccounting(t *testing.T) {
 	actor := newHarness(t)
 	owner := tutil.NewIDAddr(t, 101)
@@ -257,6 +356,316 @@ func TestPowerAndPledgeAccounting(t *testing.T) {
 	})
 }
 
+func TestElectionEligibility(t *testing.T) {
+	actor := newHarness(t)
+	owner := tutil.NewIDAddr(t, 101)
+	miner1 := tutil.NewIDAddr(t, 111)
+	miner2 := tutil.NewIDAddr(t, 112)
+	miner3 := tutil.NewIDAddr(t, 113)
+	miner4 := tutil.NewIDAddr(t, 114)
+
+	powerUnit := power.ConsensusMinerMinPower
+	smallPowerUnit := big.NewInt(1_000_000)
+	// Subtests implicitly rely on ConsensusMinerMinMiners = 3
+	require.Equal(t, 3, power.ConsensusMinerMinMiners)
+
+	builder := mock.NewBuilder(context.Background(), builtin.StoragePowerActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	eligible := func(rt *mock.Runtime, miner addr.Address, consensusFaultElapsed abi.ChainEpoch) bool {
+		st := getState(rt)
+		ok, err := st.MinerEligibleForElection(rt.AdtStore(), miner, consensusFaultElapsed, rt.Epoch())
+		require.NoError(t, err)
+		return ok
+	}
+
+	t.Run("small miner is eligible only while too few miners meet the minimum", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.createMinerBasic(rt, owner, owner, miner2)
+		actor.createMinerBasic(rt, owner, owner, miner3)
+		actor.createMinerBasic(rt, owner, owner, miner4)
+
+		actor.updateClaimedPower(rt, miner4, smallPowerUnit, smallPowerUnit)
+		assert.True(t, eligible(rt, miner4, -1))
+
+		actor.updateClaimedPower(rt, miner1, powerUnit, powerUnit)
+		actor.updateClaimedPower(rt, miner2, powerUnit, powerUnit)
+		actor.updateClaimedPower(rt, miner3, powerUnit, powerUnit)
+		assert.True(t, eligible(rt, miner1, -1))
+		assert.False(t, eligible(rt, miner4, -1))
+	})
+
+	t.Run("miner is ineligible until its consensus fault elapses", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.updateClaimedPower(rt, miner1, powerUnit, powerUnit)
+
+		rt.SetEpoch(100)
+		assert.False(t, eligible(rt, miner1, 100))
+		assert.True(t, eligible(rt, miner1, 99))
+	})
+
+	t.Run("miner without a claim is an error", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		st := getState(rt)
+		_, err := st.MinerEligibleForElection(rt.AdtStore(), miner1, -1, rt.Epoch())
+		assert.Error(t, err)
+	})
+}
+
+func TestMinerPowerQueries(t *testing.T) {
+	actor := newHarness(t)
+	owner := tutil.NewIDAddr(t, 101)
+	miner1 := tutil.NewIDAddr(t, 111)
+	miner2 := tutil.NewIDAddr(t, 112)
+	miner3 := tutil.NewIDAddr(t, 113)
+	miner4 := tutil.NewIDAddr(t, 114)
+
+	powerUnit := power.ConsensusMinerMinPower
+	smallPowerUnit := big.NewInt(1_000_000)
+
+	builder := mock.NewBuilder(context.Background(), builtin.StoragePowerActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	// Lists all miners meeting the minimum, a page at a time.
+	listAll := func(rt *mock.Runtime, limit uint64) []power.MinerPower {
+		st := getState(rt)
+		var all []power.MinerPower
+		cursor, done := addr.Undef, false
+		for !done {
+			var page []power.MinerPower
+			var err error
+			page, cursor, done, err = st.ListMinersMeetingConsensusMinimum(rt.AdtStore(), cursor, limit)
+			require.NoError(t, err)
+			require.True(t, uint64(len(page)) <= limit)
+			all = append(all, page...)
+		}
+		return all
+	}
+
+	t.Run("returns claim and consensus minimum", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.updateClaimedPower(rt, miner1, smallPowerUnit, big.Mul(smallPowerUnit, big.NewInt(2)))
+
+		ret := actor.minerPower(rt, miner1)
+		assert.Equal(t, smallPowerUnit, ret.RawBytePower)
+		assert.Equal(t, big.Mul(smallPowerUnit, big.NewInt(2)), ret.QualityAdjPower)
+		assert.True(t, ret.MeetsConsensusMinimum)
+
+		st := getState(rt)
+		claim, found, err := st.GetClaim(rt.AdtStore(), miner1)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, ret.QualityAdjPower, claim.QualityAdjPower)
+	})
+
+	t.Run("rejects miner without a claim", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbort(exitcode.ErrNotFound, func() {
+			rt.Call(actor.MinerPower, &miner1)
+		})
+
+		st := getState(rt)
+		_, found, err := st.GetClaim(rt.AdtStore(), miner1)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("lists miners meeting minimum with power fractions", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		for _, miner := range []addr.Address{miner1, miner2, miner3, miner4} {
+			actor.createMinerBasic(rt, owner, owner, miner)
+		}
+
+		// While too few miners meet the minimum, all miners with power are listed.
+		actor.updateClaimedPower(rt, miner1, smallPowerUnit, smallPowerUnit)
+		actor.updateClaimedPower(rt, miner2, smallPowerUnit, smallPowerUnit)
+		actor.onEpochTickEnd(rt, big.Mul(smallPowerUnit, big.NewInt(2)))
+		listed := listAll(rt, 1)
+		require.Len(t, listed, 2)
+		for _, mp := range listed {
+			assert.Equal(t, big.Lsh(big.NewInt(1), 127), mp.QAPowerFraction) // one half
+		}
+
+		// Once enough miners meet the minimum, small miners are excluded.
+		actor.updateClaimedPower(rt, miner2, powerUnit, powerUnit)
+		actor.updateClaimedPower(rt, miner3, powerUnit, powerUnit)
+		actor.updateClaimedPower(rt, miner4, powerUnit, big.Mul(powerUnit, big.NewInt(2)))
+		assert.False(t, actor.minerPower(rt, miner1).MeetsConsensusMinimum)
+
+		// Fractions are of the power recorded for this epoch, which is updated only by cron.
+		assertFractions := func() {
+			thisEpochQAPower := getState(rt).ThisEpochQualityAdjPower
+			expectedFraction := func(miner addr.Address) big.Int {
+				return big.Div(big.Lsh(actor.getClaim(rt, miner).QualityAdjPower, 128), thisEpochQAPower)
+			}
+			for _, limit := range []uint64{1, 2, 10} {
+				listed = listAll(rt, limit)
+				fractions := map[addr.Address]big.Int{}
+				for _, mp := range listed {
+					fractions[mp.Miner] = mp.QAPowerFraction
+				}
+				assert.Equal(t, map[addr.Address]big.Int{
+					miner2: expectedFraction(miner2),
+					miner3: expectedFraction(miner3),
+					miner4: expectedFraction(miner4),
+				}, fractions)
+			}
+		}
+		assertFractions()
+		rawPower, qaPower := power.CurrentTotalPower(getState(rt))
+		actor.onEpochTickEnd(rt, rawPower)
+		assert.Equal(t, qaPower, getState(rt).ThisEpochQualityAdjPower)
+		assertFractions()
+
+		st := getState(rt)
+		_, _, _, err := st.ListMinersMeetingConsensusMinimum(rt.AdtStore(), addr.Undef, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("resumes after cursor miner deleted between pages", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		miners := []addr.Address{miner1, miner2, miner3, miner4}
+		for _, miner := range miners {
+			actor.createMinerBasic(rt, owner, owner, miner)
+			actor.updateClaimedPower(rt, miner, powerUnit, powerUnit)
+		}
+		actor.onEpochTickEnd(rt, big.Mul(powerUnit, big.NewInt(4)))
+
+		first, cursor, done, err := getState(rt).ListMinersMeetingConsensusMinimum(rt.AdtStore(), addr.Undef, 2)
+		require.NoError(t, err)
+		require.False(t, done)
+		require.Len(t, first, 2)
+		require.Equal(t, first[1].Miner, cursor)
+
+		// The cursor miner's claim is removed before the next page is listed.
+		actor.updateClaimedPower(rt, cursor, powerUnit.Neg(), powerUnit.Neg())
+		actor.deleteMiner(rt, cursor)
+
+		rest, _, done, err := getState(rt).ListMinersMeetingConsensusMinimum(rt.AdtStore(), cursor, 10)
+		require.NoError(t, err)
+		assert.True(t, done)
+		require.Len(t, rest, 2)
+
+		listed := map[addr.Address]bool{}
+		for _, mp := range append(first, rest...) {
+			assert.False(t, listed[mp.Miner], "miner %v listed twice", mp.Miner)
+			listed[mp.Miner] = true
+		}
+		for _, miner := range miners {
+			assert.True(t, listed[miner], "miner %v not listed", miner)
+		}
+	})
+}
+
+func TestDeleteMiner(t *testing.T) {
+	actor := newHarness(t)
+	owner := tutil.NewIDAddr(t, 101)
+	miner1 := tutil.NewIDAddr(t, 111)
+	miner2 := tutil.NewIDAddr(t, 112)
+
+	builder := mock.NewBuilder(context.Background(), builtin.StoragePowerActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	t.Run("removes claim without power", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.createMinerBasic(rt, owner, owner, miner2)
+
+		// power that has since been removed doesn't prevent deletion
+		actor.updateClaimedPower(rt, miner1, abi.NewStoragePower(100), abi.NewStoragePower(100))
+		actor.updateClaimedPower(rt, miner1, abi.NewStoragePower(-100), abi.NewStoragePower(-100))
+
+		actor.deleteMiner(rt, miner1)
+
+		st := getState(rt)
+		assert.Equal(t, int64(1), st.MinerCount)
+		claims, err := adt.AsMap(rt.AdtStore(), st.Claims)
+		require.NoError(t, err)
+		found, err := claims.Get(power.AddrKey(miner1), &power.Claim{})
+		require.NoError(t, err)
+		assert.False(t, found)
+		actor.getClaim(rt, miner2)
+	})
+
+	t.Run("removes pending cron events", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.createMinerBasic(rt, owner, owner, miner2)
+
+		actor.enrollCronEvent(rt, miner1, 4, []byte{0x1})
+		actor.enrollCronEvent(rt, miner2, 4, []byte{0x2})
+		actor.enrollCronEvent(rt, miner1, 6, []byte{0x3})
+		actor.enrollCronEvent(rt, miner1, 8, []byte{0x4})
+
+		// Only events at the epochs the miner names are removed.
+		actor.deleteMiner(rt, miner1, 6, 4, 6)
+
+		st := getState(rt)
+		events, err := adt.AsMultimap(rt.AdtStore(), st.CronEventQueue)
+		require.NoError(t, err)
+		var remaining []power.CronEvent
+		var ev power.CronEvent
+		err = events.ForEach(adt.IntKey(4), &ev, func(i int64) error {
+			remaining = append(remaining, ev)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []power.CronEvent{{MinerAddr: miner2, CallbackPayload: []byte{0x2}}}, remaining)
+		_, found, err := events.Get(adt.IntKey(6))
+		require.NoError(t, err)
+		assert.False(t, found)
+		_, found, err = events.Get(adt.IntKey(8))
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		// only the remaining miner's event is delivered
+		rt.SetEpoch(6)
+		expectedPower := big.Zero()
+		rt.ExpectValidateCallerAddr(builtin.CronActorAddr)
+		rt.ExpectSend(miner2, builtin.MethodsMiner.OnDeferredCronEvent, vmr.CBORBytes([]byte{0x2}), big.Zero(), nil, exitcode.Ok)
+		rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.UpdateNetworkKPI, &expectedPower, big.Zero(), nil, exitcode.Ok)
+		rt.SetCaller(builtin.CronActorAddr, builtin.CronActorCodeID)
+		rt.Call(actor.Actor.OnEpochTickEnd, nil)
+		rt.Verify()
+	})
+
+	t.Run("rejects miner with power", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.updateClaimedPower(rt, miner1, abi.NewStoragePower(100), abi.NewStoragePower(100))
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.deleteMiner(rt, miner1)
+		})
+	})
+
+	t.Run("rejects miner without a claim", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.ExpectAbort(exitcode.ErrNotFound, func() {
+			actor.deleteMiner(rt, miner1)
+		})
+	})
+}
+
 func TestCron(t *testing.T) {
 	actor := newHarness(t)
 	miner1 := tutil.NewIDAddr(t, 101)
@@ -278,6 +687,67 @@ func TestCron(t *testing.T) {
 		rt.Verify()
 	})
 
+	t.Run("updates smoothed power estimate", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+
+		// The estimate remains unseeded while there is no power.
+		actor.onEpochTickEnd(rt, big.Zero())
+		assert.False(t, getState(rt).QAPowerObserved())
+
+		// The first power observed seeds the estimate.
+		rawPow := power.ConsensusMinerMinPower
+		qaPow := big.Mul(rawPow, big.NewInt(2))
+		actor.updateClaimedPower(rt, miner1, rawPow, qaPow)
+		actor.onEpochTickEnd(rt, rawPow)
+		st := getState(rt)
+		assert.True(t, st.QAPowerObserved())
+		assert.Equal(t, qaPow, st.ThisEpochQAPowerSmoothed.Estimate())
+
+		// Subsequently, the estimate moves towards new power, but only part way.
+		actor.updateClaimedPower(rt, miner1, rawPow, qaPow)
+		actor.onEpochTickEnd(rt, big.Mul(rawPow, big.NewInt(2)))
+		st = getState(rt)
+		estimate := st.ThisEpochQAPowerSmoothed.Estimate()
+		assert.True(t, estimate.GreaterThan(qaPow))
+		assert.True(t, estimate.LessThan(big.Mul(qaPow, big.NewInt(2))))
+		assert.Equal(t, st.ThisEpochQAPowerSmoothed, actor.currentPowerTotal(rt).QualityAdjPowerSmoothed)
+		assert.Equal(t, rt.Epoch(), st.QAPowerSmoothedEpoch)
+
+		// After null rounds, the estimate is extrapolated across the skipped epochs.
+		prev := st.ThisEpochQAPowerSmoothed
+		rt.SetEpoch(rt.Epoch() + 4)
+		actor.onEpochTickEnd(rt, big.Mul(rawPow, big.NewInt(2)))
+		st = getState(rt)
+		assert.Equal(t, smoothing.NextEstimate(prev, big.Mul(qaPow, big.NewInt(2)), 5), st.ThisEpochQAPowerSmoothed)
+		assert.Equal(t, rt.Epoch(), st.QAPowerSmoothedEpoch)
+	})
+
+	t.Run("pledge after genesis reflects the observed power", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+
+		networkPow := big.Mul(power.ConsensusMinerMinPower, big.NewInt(10))
+		actor.updateClaimedPower(rt, miner1, networkPow, networkPow)
+		actor.onEpochTickEnd(rt, networkPow)
+
+		// A sector's pledge is the same as if the network's power had been known exactly.
+		sectorPow := abi.NewStoragePower(32 << 30)
+		rewardEstimate := smoothing.NewEstimate(big.Mul(big.NewInt(20), abi.TokenPrecision), big.Zero())
+		circulatingSupply := big.Mul(big.NewInt(1e9), abi.TokenPrecision)
+		pledge := func(powerEstimate smoothing.FilterEstimate) abi.TokenAmount {
+			return mineract.InitialPledgeForPower(sectorPow, powerEstimate, networkPow, big.Zero(), rewardEstimate, circulatingSupply)
+		}
+		st := getState(rt)
+		assert.Equal(t, pledge(smoothing.NewEstimate(networkPow, big.Zero())), pledge(st.ThisEpochQAPowerSmoothed))
+
+		// Filtering up from zero would have inflated the pledge.
+		unseeded := smoothing.NextEstimate(smoothing.NewEstimate(big.Zero(), big.Zero()), networkPow, 1)
+		assert.True(t, pledge(unseeded).GreaterThan(pledge(st.ThisEpochQAPowerSmoothed)))
+	})
+
 	t.Run("event scheduled in null round called next round", func(t *testing.T) {
 		rt := builder.Build(t)
 		actor.constructAndVerify(rt)
@@ -352,6 +822,49 @@ func TestCron(t *testing.T) {
 		})
 	})
 
+	t.Run("defers cron events beyond per-epoch limit", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		defer func(limit uint64) { power.MaxCronEventsPerEpoch = limit }(power.MaxCronEventsPerEpoch)
+		power.MaxCronEventsPerEpoch = 2
+
+		rt.SetEpoch(1)
+		actor.enrollCronEvent(rt, miner1, 2, []byte{0x1})
+		actor.enrollCronEvent(rt, miner2, 2, []byte{0x2})
+		actor.enrollCronEvent(rt, miner1, 2, []byte{0x3})
+		actor.enrollCronEvent(rt, miner2, 3, []byte{0x4})
+
+		expectedPower := big.NewInt(0)
+		expectTick := func(payloads ...[]byte) {
+			rt.ExpectValidateCallerAddr(builtin.CronActorAddr)
+			for _, payload := range payloads {
+				miner := miner1
+				if payload[0]%2 == 0 {
+					miner = miner2
+				}
+				rt.ExpectSend(miner, builtin.MethodsMiner.OnDeferredCronEvent, vmr.CBORBytes(payload), big.Zero(), nil, exitcode.Ok)
+			}
+			rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.UpdateNetworkKPI, &expectedPower, big.Zero(), nil, exitcode.Ok)
+			rt.SetCaller(builtin.CronActorAddr, builtin.CronActorCodeID)
+			rt.Call(actor.Actor.OnEpochTickEnd, nil)
+			rt.Verify()
+		}
+
+		// Only the first two events due are processed, the rest deferred in order.
+		rt.SetEpoch(3)
+		expectTick([]byte{0x1}, []byte{0x2})
+		st := getState(rt)
+		assert.Equal(t, int64(2), st.CronEventBacklog)
+		assert.Equal(t, abi.ChainEpoch(2), st.FirstCronEpoch)
+
+		rt.SetEpoch(4)
+		expectTick([]byte{0x3}, []byte{0x4})
+		st = getState(rt)
+		assert.Equal(t, int64(0), st.CronEventBacklog)
+		assert.Equal(t, abi.ChainEpoch(5), st.FirstCronEpoch)
+	})
+
 	t.Run("handles failed call", func(t *testing.T) {
 		rt := builder.Build(t)
 		actor.constructAndVerify(rt)
@@ -430,6 +943,106 @@ func TestSubmitPoRepForBulkVerify(t *testing.T) {
 		assert.Equal(t, commR, storedSealInfo.SealedCID)
 	})
 
+	t.Run("defers proofs beyond per-epoch limit", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		defer func(limit uint64) { power.MaxProofVerificationsPerEpoch = limit }(power.MaxProofVerificationsPerEpoch)
+		power.MaxProofVerificationsPerEpoch = 2
+
+		for i := 1; i <= 3; i++ {
+			actor.submitPoRepForBulkVerify(rt, miner, &abi.SealVerifyInfo{
+				SectorID:    abi.SectorID{Number: abi.SectorNumber(i)},
+				SealedCID:   tutil.MakeCID(fmt.Sprintf("commR-%d", i), &mineract.SealedCIDPrefix),
+				UnsealedCID: tutil.MakeCID(fmt.Sprintf("commD-%d", i), &market.PieceCIDPrefix),
+			})
+		}
+
+		expectedPower := big.NewInt(0)
+		expectTick := func(sectors ...abi.SectorNumber) {
+			rt.ExpectValidateCallerAddr(builtin.CronActorAddr)
+			rt.ExpectSend(miner, builtin.MethodsMiner.ConfirmSectorProofsValid, &builtin.ConfirmSectorProofsParams{Sectors: sectors}, big.Zero(), nil, exitcode.Ok)
+			rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.UpdateNetworkKPI, &expectedPower, big.Zero(), nil, exitcode.Ok)
+			rt.SetCaller(builtin.CronActorAddr, builtin.CronActorCodeID)
+			rt.Call(actor.Actor.OnEpochTickEnd, nil)
+			rt.Verify()
+		}
+
+		rt.SetEpoch(1)
+		expectTick(1, 2)
+		st := getState(rt)
+		assert.Equal(t, int64(1), st.ProofValidationBacklog)
+		assert.Nil(t, st.ProofValidationBatch)
+		require.NotNil(t, st.ProofValidationQueue)
+
+		rt.SetEpoch(2)
+		expectTick(3)
+		st = getState(rt)
+		assert.Equal(t, int64(0), st.ProofValidationBacklog)
+		assert.Nil(t, st.ProofValidationBatch)
+		assert.Nil(t, st.ProofValidationQueue)
+	})
+
+	t.Run("verifies deferred proofs of all miners before new proofs", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		miner2 := tutil.NewIDAddr(t, 102)
+
+		defer func(limit uint64) { power.MaxProofVerificationsPerEpoch = limit }(power.MaxProofVerificationsPerEpoch)
+		power.MaxProofVerificationsPerEpoch = 2
+
+		submit := func(m addr.Address, sectors ...abi.SectorNumber) {
+			for _, n := range sectors {
+				actor.submitPoRepForBulkVerify(rt, m, &abi.SealVerifyInfo{
+					SectorID:    abi.SectorID{Number: n},
+					SealedCID:   tutil.MakeCID(fmt.Sprintf("commR-%s-%d", m, n), &mineract.SealedCIDPrefix),
+					UnsealedCID: tutil.MakeCID(fmt.Sprintf("commD-%s-%d", m, n), &market.PieceCIDPrefix),
+				})
+			}
+		}
+		type confirmation struct {
+			miner   addr.Address
+			sectors []abi.SectorNumber
+		}
+		expectedPower := big.NewInt(0)
+		expectTick := func(confirmations ...confirmation) {
+			rt.SetEpoch(rt.Epoch() + 1)
+			rt.ExpectValidateCallerAddr(builtin.CronActorAddr)
+			for _, c := range confirmations {
+				rt.ExpectSend(c.miner, builtin.MethodsMiner.ConfirmSectorProofsValid, &builtin.ConfirmSectorProofsParams{Sectors: c.sectors}, big.Zero(), nil, exitcode.Ok)
+			}
+			rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.UpdateNetworkKPI, &expectedPower, big.Zero(), nil, exitcode.Ok)
+			rt.SetCaller(builtin.CronActorAddr, builtin.CronActorCodeID)
+			rt.Call(actor.Actor.OnEpochTickEnd, nil)
+			rt.Verify()
+		}
+
+		submit(miner, 1, 2)
+		submit(miner2, 1, 2)
+
+		// The batch's first miner is verified, and the other miner's proofs deferred.
+		first, second := miner, miner2
+		if batchMiners(t, rt)[0] != miner {
+			first, second = miner2, miner
+		}
+		expectTick(confirmation{first, []abi.SectorNumber{1, 2}})
+		assert.Equal(t, int64(2), getState(rt).ProofValidationBacklog)
+
+		submit(first, 3, 4)
+		submit(second, 3)
+
+		// The deferred proofs are verified ahead of the new batch, whatever the miners' order in the batch.
+		expectTick(confirmation{second, []abi.SectorNumber{1, 2}})
+		assert.Equal(t, int64(3), getState(rt).ProofValidationBacklog)
+
+		// The proofs deferred from the second batch follow, in the batch's order.
+		expectTick(confirmation{first, []abi.SectorNumber{3, 4}})
+		expectTick(confirmation{second, []abi.SectorNumber{3}})
+		st := getState(rt)
+		assert.Equal(t, int64(0), st.ProofValidationBacklog)
+		assert.Nil(t, st.ProofValidationQueue)
+	})
+
 	t.Run("aborts when too many poreps", func(t *testing.T) {
 		rt := builder.Build(t)
 		actor.constructAndVerify(rt)
@@ -452,9 +1065,42 @@ func TestSubmitPoRepForBulkVerify(t *testing.T) {
 
 		// Gas only charged for successful submissions
 		rt.ExpectGasCharged(power.GasOnSubmitVerifySeal * power.MaxMinerProveCommitsPerEpoch)
+
+		// Proofs deferred to the next epoch don't count against the limit.
+		defer func(limit uint64) { power.MaxProofVerificationsPerEpoch = limit }(power.MaxProofVerificationsPerEpoch)
+		power.MaxProofVerificationsPerEpoch = 1
+		expectedPower := big.NewInt(0)
+		rt.SetEpoch(1)
+		rt.ExpectValidateCallerAddr(builtin.CronActorAddr)
+		rt.ExpectSend(miner, builtin.MethodsMiner.ConfirmSectorProofsValid, &builtin.ConfirmSectorProofsParams{Sectors: []abi.SectorNumber{0}}, big.Zero(), nil, exitcode.Ok)
+		rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.UpdateNetworkKPI, &expectedPower, big.Zero(), nil, exitcode.Ok)
+		rt.SetCaller(builtin.CronActorAddr, builtin.CronActorCodeID)
+		rt.Call(actor.Actor.OnEpochTickEnd, nil)
+		rt.Verify()
+		assert.Equal(t, int64(power.MaxMinerProveCommitsPerEpoch-1), getState(rt).ProofValidationBacklog)
+
+		for i := 0; i < power.MaxMinerProveCommitsPerEpoch; i++ {
+			actor.submitPoRepForBulkVerify(rt, miner, sealInfo(i))
+		}
 	})
 }
 
+// Returns the miners with proofs in the batch, in the batch's order.
+func batchMiners(t *testing.T, rt *mock.Runtime) []addr.Address {
+	st := getState(rt)
+	require.NotNil(t, st.ProofValidationBatch)
+	mmap, err := adt.AsMultimap(rt.AdtStore(), *st.ProofValidationBatch)
+	require.NoError(t, err)
+	var miners []addr.Address
+	err = mmap.ForAll(func(k string, _ *adt.Array) error {
+		m, err := addr.NewFromBytes([]byte(k))
+		miners = append(miners, m)
+		return err
+	})
+	require.NoError(t, err)
+	return miners
+}
+
 //
 // Misc. Utility Functions
 //
@@ -487,8 +1133,12 @@ func newHarness(t *testing.T) *spActorHarness {
 }
 
 func (h *spActorHarness) constructAndVerify(rt *mock.Runtime) {
+	h.constructWithPolicies(rt, nil)
+}
+
+func (h *spActorHarness) constructWithPolicies(rt *mock.Runtime, policies []power.SealProofPolicy) {
 	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
-	ret := rt.Call(h.Actor.Constructor, nil)
+	ret := rt.Call(h.Actor.Constructor, &power.ConstructorParams{SealProofPolicies: policies})
 	assert.Nil(h.t, ret)
 	rt.Verify()
 
@@ -506,6 +1156,10 @@ func (h *spActorHarness) constructAndVerify(rt *mock.Runtime) {
 	assert.Equal(h.t, abi.ChainEpoch(0), st.FirstCronEpoch)
 	assert.Equal(h.t, int64(0), st.MinerCount)
 	assert.Equal(h.t, int64(0), st.MinerAboveMinPowerCount)
+	if len(policies) == 0 {
+		policies = power.DefaultSealProofPolicies()
+	}
+	assert.Equal(h.t, policies, st.SealProofPolicies)
 
 	verifyEmptyMap(h.t, rt, st.Claims)
 	verifyEmptyMap(h.t, rt, st.CronEventQueue)
@@ -574,6 +1228,13 @@ func (h *spActorHarness) updateClaimedPower(rt *mock.Runtime, miner addr.Address
 	rt.Verify()
 }
 
+func (h *spActorHarness) minerPower(rt *mock.Runtime, miner addr.Address) *power.MinerPowerReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.MinerPower, &miner).(*power.MinerPowerReturn)
+	rt.Verify()
+	return ret
+}
+
 func (h *spActorHarness) updatePledgeTotal(rt *mock.Runtime, miner addr.Address, delta abi.TokenAmount) {
 	rt.SetCaller(miner, builtin.StorageMinerActorCodeID)
 	rt.ExpectValidateCallerType(builtin.StorageMinerActorCodeID)
@@ -581,6 +1242,13 @@ func (h *spActorHarness) updatePledgeTotal(rt *mock.Runtime, miner addr.Address,
 	rt.Verify()
 }
 
+func (h *spActorHarness) deleteMiner(rt *mock.Runtime, miner addr.Address, cronEpochs ...abi.ChainEpoch) {
+	rt.SetCaller(miner, builtin.StorageMinerActorCodeID)
+	rt.ExpectValidateCallerType(builtin.StorageMinerActorCodeID)
+	rt.Call(h.DeleteMiner, &power.DeleteMinerParams{CronEpochs: cronEpochs})
+	rt.Verify()
+}
+
 func (h *spActorHarness) currentPowerTotal(rt *mock.Runtime) *power.CurrentTotalPowerReturn {
 	rt.ExpectValidateCallerAny()
 	ret := rt.Call(h.CurrentTotalPower, nil).(*power.CurrentTotalPowerReturn)
@@ -598,6 +1266,15 @@ func (h *spActorHarness) enrollCronEvent(rt *mock.Runtime, miner addr.Address, e
 	rt.Verify()
 }
 
+func (h *spActorHarness) onEpochTickEnd(rt *mock.Runtime, expectedRawPower abi.StoragePower) {
+	rt.SetEpoch(rt.Epoch() + 1)
+	rt.ExpectValidateCallerAddr(builtin.CronActorAddr)
+	rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.UpdateNetworkKPI, &expectedRawPower, big.Zero(), nil, exitcode.Ok)
+	rt.SetCaller(builtin.CronActorAddr, builtin.CronActorCodeID)
+	rt.Call(h.Actor.OnEpochTickEnd, nil)
+	rt.Verify()
+}
+
 func (h *spActorHarness) onConsensusFault(rt *mock.Runtime, minerAddr addr.Address, pledgeAmount *abi.TokenAmount) {
 	rt.ExpectValidateCallerType(builtin.StorageMinerActorCodeID)
 	rt.SetCaller(minerAddr, builtin.StorageMinerActorCodeID)
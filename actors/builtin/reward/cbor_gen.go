@@ -13,7 +13,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{134}
+var lengthBufState = []byte{141}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -52,11 +52,26 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.ThisEpochRewardSmoothed (smoothing.FilterEstimate) (struct)
+	if err := t.ThisEpochRewardSmoothed.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.ThisEpochSimpleReward (big.Int) (struct)
+	if err := t.ThisEpochSimpleReward.MarshalCBOR(w); err != nil {
+		return err
+	}
+
 	// t.ThisEpochBaselinePower (big.Int) (struct)
 	if err := t.ThisEpochBaselinePower.MarshalCBOR(w); err != nil {
 		return err
 	}
 
+	// t.EffectiveBaselinePower (big.Int) (struct)
+	if err := t.EffectiveBaselinePower.MarshalCBOR(w); err != nil {
+		return err
+	}
+
 	// t.Epoch (abi.ChainEpoch) (int64)
 	if t.Epoch >= 0 {
 		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
@@ -67,6 +82,26 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 			return err
 		}
 	}
+
+	// t.TotalMinted (big.Int) (struct)
+	if err := t.TotalMinted.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TotalSimpleMinted (big.Int) (struct)
+	if err := t.TotalSimpleMinted.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TotalBaselineMinted (big.Int) (struct)
+	if err := t.TotalBaselineMinted.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TotalPenalties (big.Int) (struct)
+	if err := t.TotalPenalties.MarshalCBOR(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -84,7 +119,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 6 {
+	if extra != 13 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -139,6 +174,24 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.ThisEpochReward: %w", err)
 		}
 
+	}
+	// t.ThisEpochRewardSmoothed (smoothing.FilterEstimate) (struct)
+
+	{
+
+		if err := t.ThisEpochRewardSmoothed.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ThisEpochRewardSmoothed: %w", err)
+		}
+
+	}
+	// t.ThisEpochSimpleReward (big.Int) (struct)
+
+	{
+
+		if err := t.ThisEpochSimpleReward.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ThisEpochSimpleReward: %w", err)
+		}
+
 	}
 	// t.ThisEpochBaselinePower (big.Int) (struct)
 
@@ -148,6 +201,15 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.ThisEpochBaselinePower: %w", err)
 		}
 
+	}
+	// t.EffectiveBaselinePower (big.Int) (struct)
+
+	{
+
+		if err := t.EffectiveBaselinePower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.EffectiveBaselinePower: %w", err)
+		}
+
 	}
 	// t.Epoch (abi.ChainEpoch) (int64)
 	{
@@ -174,6 +236,42 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.Epoch = abi.ChainEpoch(extraI)
 	}
+	// t.TotalMinted (big.Int) (struct)
+
+	{
+
+		if err := t.TotalMinted.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalMinted: %w", err)
+		}
+
+	}
+	// t.TotalSimpleMinted (big.Int) (struct)
+
+	{
+
+		if err := t.TotalSimpleMinted.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalSimpleMinted: %w", err)
+		}
+
+	}
+	// t.TotalBaselineMinted (big.Int) (struct)
+
+	{
+
+		if err := t.TotalBaselineMinted.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalBaselineMinted: %w", err)
+		}
+
+	}
+	// t.TotalPenalties (big.Int) (struct)
+
+	{
+
+		if err := t.TotalPenalties.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalPenalties: %w", err)
+		}
+
+	}
 	return nil
 }
 
@@ -291,7 +389,7 @@ func (t *AwardBlockRewardParams) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufThisEpochRewardReturn = []byte{130}
+var lengthBufThisEpochRewardReturn = []byte{131}
 
 func (t *ThisEpochRewardReturn) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -307,6 +405,11 @@ func (t *ThisEpochRewardReturn) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.ThisEpochRewardSmoothed (smoothing.FilterEstimate) (struct)
+	if err := t.ThisEpochRewardSmoothed.MarshalCBOR(w); err != nil {
+		return err
+	}
+
 	// t.ThisEpochBaselinePower (big.Int) (struct)
 	if err := t.ThisEpochBaselinePower.MarshalCBOR(w); err != nil {
 		return err
@@ -328,7 +431,7 @@ func (t *ThisEpochRewardReturn) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -340,6 +443,15 @@ func (t *ThisEpochRewardReturn) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.ThisEpochReward: %w", err)
 		}
 
+	}
+	// t.ThisEpochRewardSmoothed (smoothing.FilterEstimate) (struct)
+
+	{
+
+		if err := t.ThisEpochRewardSmoothed.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ThisEpochRewardSmoothed: %w", err)
+		}
+
 	}
 	// t.ThisEpochBaselinePower (big.Int) (struct)
 
@@ -352,3 +464,93 @@ func (t *ThisEpochRewardReturn) UnmarshalCBOR(r io.Reader) error {
 	}
 	return nil
 }
+
+var lengthBufMintingStatsReturn = []byte{132}
+
+func (t *MintingStatsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufMintingStatsReturn); err != nil {
+		return err
+	}
+
+	// t.TotalMinted (big.Int) (struct)
+	if err := t.TotalMinted.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TotalSimpleMinted (big.Int) (struct)
+	if err := t.TotalSimpleMinted.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TotalBaselineMinted (big.Int) (struct)
+	if err := t.TotalBaselineMinted.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TotalPenalties (big.Int) (struct)
+	if err := t.TotalPenalties.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *MintingStatsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = MintingStatsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.TotalMinted (big.Int) (struct)
+
+	{
+
+		if err := t.TotalMinted.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalMinted: %w", err)
+		}
+
+	}
+	// t.TotalSimpleMinted (big.Int) (struct)
+
+	{
+
+		if err := t.TotalSimpleMinted.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalSimpleMinted: %w", err)
+		}
+
+	}
+	// t.TotalBaselineMinted (big.Int) (struct)
+
+	{
+
+		if err := t.TotalBaselineMinted.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalBaselineMinted: %w", err)
+		}
+
+	}
+	// t.TotalPenalties (big.Int) (struct)
+
+	{
+
+		if err := t.TotalPenalties.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalPenalties: %w", err)
+		}
+
+	}
+	return nil
+}
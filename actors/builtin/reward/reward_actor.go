@@ -10,6 +10,7 @@ import (
 	exitcode "github.com/filecoin-project/specs-actors/actors/runtime/exitcode"
 	. "github.com/filecoin-project/specs-actors/actors/util"
 	adt "github.com/filecoin-project/specs-actors/actors/util/adt"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 type Actor struct{}
@@ -20,6 +21,7 @@ func (a Actor) Exports() []interface{} {
 		2:                         a.AwardBlockReward,
 		3:                         a.ThisEpochReward,
 		4:                         a.UpdateNetworkKPI,
+		5:                         a.MintingStats,
 	}
 }
 
@@ -52,8 +54,8 @@ type AwardBlockRewardParams struct {
 // - the epoch block reward, computed and paid from the reward actor's balance,
 // - the block gas reward, expected to be transferred to the reward actor with this invocation.
 //
-// The reward is reduced before the residual is credited to the block producer, by:
-// - a penalty amount, provided as a parameter, which is burnt,
+// The whole reward is sent to the block producer's miner actor, along with a penalty amount, provided as a
+// parameter, which the miner pays from its reward and balance.
 func (a Actor) AwardBlockReward(rt vmr.Runtime, params *AwardBlockRewardParams) *adt.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
 	AssertMsg(rt.CurrentBalance().GreaterThanEqual(params.GasReward),
@@ -64,46 +66,46 @@ func (a Actor) AwardBlockReward(rt vmr.Runtime, params *AwardBlockRewardParams)
 		rt.Abortf(exitcode.ErrIllegalState, "failed to resolve given owner address")
 	}
 
-	priorBalance := rt.CurrentBalance()
-
-	penalty := abi.NewTokenAmount(0)
+	totalReward := big.Zero()
 	var st State
-	rt.State().Readonly(&st)
-
-	blockReward := big.Mul(st.ThisEpochReward, big.NewInt(params.WinCount))
-	blockReward = big.Div(blockReward, big.NewInt(builtin.ExpectedLeadersPerEpoch))
-
-	totalReward := big.Add(blockReward, params.GasReward)
-
-	if totalReward.GreaterThan(rt.CurrentBalance()) {
-		rt.Log(vmr.WARN, "reward actor balance %d below totalReward expected %d, paying out rest of balance", rt.CurrentBalance(), totalReward)
-		totalReward = rt.CurrentBalance()
-	}
-
-	// Cap the penalty at the total reward value.
-	penalty = big.Min(params.Penalty, totalReward)
+	rt.State().Transaction(&st, func() interface{} {
+		blockReward := big.Mul(st.ThisEpochReward, big.NewInt(params.WinCount))
+		blockReward = big.Div(blockReward, big.NewInt(builtin.ExpectedLeadersPerEpoch))
 
-	// Reduce the payable reward by the penalty.
-	rewardPayable := big.Sub(totalReward, penalty)
+		totalReward = big.Add(blockReward, params.GasReward)
 
-	AssertMsg(big.Add(rewardPayable, penalty).LessThanEqual(priorBalance),
-		"reward payable %v + penalty %v exceeds balance %v", rewardPayable, penalty, priorBalance)
+		if totalReward.GreaterThan(rt.CurrentBalance()) {
+			rt.Log(vmr.WARN, "reward actor balance %d below totalReward expected %d, paying out rest of balance", rt.CurrentBalance(), totalReward)
+			totalReward = rt.CurrentBalance()
+		}
 
-	_, code := rt.Send(minerAddr, builtin.MethodsMiner.AddLockedFund, &rewardPayable, rewardPayable)
-	builtin.RequireSuccess(rt, code, "failed to send reward to miner: %s", minerAddr)
+		// The gas reward is paid in full, so any shortfall reduces the block reward paid.
+		st.RecordBlockReward(big.Max(big.Sub(totalReward, params.GasReward), big.Zero()), params.WinCount)
+		return nil
+	})
 
-	// Burn the penalty amount.
-	if penalty.GreaterThan(abi.NewTokenAmount(0)) {
-		_, code = rt.Send(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, penalty)
-		builtin.RequireSuccess(rt, code, "failed to send penalty to burnt funds actor")
+	// The miner locks part of the reward, and pays the penalty from its reward and balance.
+	rewardParams := builtin.ApplyRewardParams{
+		Reward:  totalReward,
+		Penalty: params.Penalty,
 	}
+	ret, code := rt.Send(minerAddr, builtin.MethodsMiner.ApplyRewards, &rewardParams, totalReward)
+	builtin.RequireSuccess(rt, code, "failed to send reward to miner: %s", minerAddr)
+	var applied builtin.ApplyRewardsReturn
+	err := ret.Into(&applied)
+	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to unmarshal apply rewards return value")
 
+	rt.State().Transaction(&st, func() interface{} {
+		st.recordPenaltiesBurnt(applied.DebtRepaid)
+		return nil
+	})
 	return nil
 }
 
 type ThisEpochRewardReturn struct {
-	ThisEpochReward        abi.TokenAmount
-	ThisEpochBaselinePower abi.StoragePower
+	ThisEpochReward         abi.TokenAmount
+	ThisEpochRewardSmoothed smoothing.FilterEstimate
+	ThisEpochBaselinePower  abi.StoragePower
 }
 
 // The award value used for the current epoch, updated at the end of an epoch
@@ -115,8 +117,31 @@ func (a Actor) ThisEpochReward(rt vmr.Runtime, _ *adt.EmptyValue) *ThisEpochRewa
 	var st State
 	rt.State().Readonly(&st)
 	return &ThisEpochRewardReturn{
-		ThisEpochReward:        st.ThisEpochReward,
-		ThisEpochBaselinePower: st.ThisEpochBaselinePower,
+		ThisEpochReward:         st.ThisEpochReward,
+		ThisEpochRewardSmoothed: st.ThisEpochRewardSmoothed,
+		ThisEpochBaselinePower:  st.ThisEpochBaselinePower,
+	}
+}
+
+type MintingStatsReturn struct {
+	TotalMinted         abi.TokenAmount
+	TotalSimpleMinted   abi.TokenAmount
+	TotalBaselineMinted abi.TokenAmount
+	TotalPenalties      abi.TokenAmount
+}
+
+// The cumulative block rewards minted to date, split between simple and baseline minting,
+// and the cumulative penalties charged to block producers along with them.
+func (a Actor) MintingStats(rt vmr.Runtime, _ *adt.EmptyValue) *MintingStatsReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	var st State
+	rt.State().Readonly(&st)
+	return &MintingStatsReturn{
+		TotalMinted:         st.TotalMinted,
+		TotalSimpleMinted:   st.TotalSimpleMinted,
+		TotalBaselineMinted: st.TotalBaselineMinted,
+		TotalPenalties:      st.TotalPenalties,
 	}
 }
 
@@ -131,14 +156,7 @@ func (a Actor) UpdateNetworkKPI(rt vmr.Runtime, currRealizedPower *abi.StoragePo
 
 	var st State
 	rt.State().Transaction(&st, func() interface{} {
-		// if there were null runs catch up the computation until
-		// st.Epoch == rt.CurrEpoch()
-		for st.Epoch < rt.CurrEpoch() {
-			// Update to next epoch to process null rounds
-			st.updateToNextEpoch(*currRealizedPower)
-		}
-
-		st.updateToNextEpochWithReward(*currRealizedPower)
+		st.UpdateNetworkKPI(rt.CurrEpoch(), *currRealizedPower)
 		return nil
 	})
 	return nil
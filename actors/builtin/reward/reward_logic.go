@@ -3,10 +3,52 @@ package reward
 import (
 	abi "github.com/filecoin-project/specs-actors/actors/abi"
 	big "github.com/filecoin-project/specs-actors/actors/abi/big"
+	math "github.com/filecoin-project/specs-actors/actors/util/math"
 )
 
-var BaselinePowerAt = func(epoch abi.ChainEpoch) abi.StoragePower {
-	return big.NewInt(1 << 40) // PARAM_FINISH
+// Reward computations are carried out in Q.128 fixed point format.
+const precision = math.Precision128
+
+// The baseline power grows exponentially from an initial value at genesis:
+// BaselinePowerAt(t) = BaselineInitialValue * e^(BaselineExponent * t)
+// These parameters may be set by networks other than mainnet (e.g. testnets) prior to genesis.
+var (
+	// Baseline power at genesis, in bytes.
+	BaselineInitialValue = big.NewInt(1 << 40) // PARAM_FINISH
+
+	// Growth of the baseline per epoch, in Q.128 format.
+	// BaselineExponent = ln(1 + annualGrowthRate) / EpochsInYear
+	// The default grows the baseline by 100% each year:
+	// BaselineExponent = floor(ln(2) / (31556925 / blockDelay(25)) * 2^128)
+	BaselineExponent = big.MustFromString("186857372213478732590345972210659") // PARAM_FINISH
+)
+
+// ln2 = floor(ln(2) * 2^128)
+var ln2 = big.MustFromString("235865763225513294137944142764154484399")
+
+// Computes the baseline power at an epoch in closed form.
+// This is the single definition of the baseline, from which the reward state computes its baseline each epoch.
+func BaselinePowerAt(epoch abi.ChainEpoch) abi.StoragePower {
+	exponent := big.Mul(BaselineExponent, big.NewInt(int64(epoch))) // Q.128 * Q.0 => Q.128
+	return baselinePowerForExponent(exponent)
+}
+
+// Computes BaselineInitialValue * e^x in Q.128 format.
+// Since math.ExpNeg is most precise for small arguments, x is split into an integer multiple of ln(2)
+// and a remainder in [0, ln(2)), so e^x = 2^k * e^(x - k*ln(2)). The multiple k is negative for negative x.
+func baselinePowerForExponent(x big.Int) abi.StoragePower {
+	k := big.Div(x, ln2)                                       // Q.128 / Q.128 => Q.0, rounded down
+	rem := big.Sub(x, big.Mul(k, ln2))                         // Q.128
+	one := big.Lsh(big.NewInt(1), 2*precision)                 // Q.256
+	growth := big.Div(one, big.Int{Int: math.ExpNeg(rem.Int)}) // Q.256 / Q.128 => Q.128
+
+	power := big.Mul(BaselineInitialValue, growth) // Q.0 * Q.128 => Q.128
+	if k.Sign() >= 0 {
+		power = big.Lsh(power, uint(k.Uint64())) // Q.128
+	} else {
+		power = big.Rsh(power, uint(k.Neg().Uint64())) // Q.128
+	}
+	return big.Rsh(power, precision) // Q.128 => Q.0
 }
 
 // These numbers are placeholders, but should be in units of attoFIL, 10^-18 FIL
@@ -17,17 +59,17 @@ var BaselineTotal = big.Mul(big.NewInt(900e6), big.NewInt(1e18)) // 900M for tes
 // The effectiveNetworkTime is defined by CumsumBaselinePower(theta) == CumsumRealizedPower
 // As baseline power is defined over integers and the RewardTheta is required to be fractional,
 // we perform linear interpolation between CumsumBaseline(⌊theta⌋) and CumsumBaseline(⌈theta⌉).
-// The effectiveNetworkTime argument is ceiling of theta.
+// The effectiveNetworkTime argument is ceiling of theta, and effectiveBaselinePower the baseline power at that epoch.
 // The result is a fractional effectiveNetworkTime (theta) in Q.128 format.
-func computeRTheta(effectiveNetworkTime abi.ChainEpoch, cumsumRealized, cumsumBaseline big.Int) big.Int {
+func computeRTheta(effectiveNetworkTime abi.ChainEpoch, effectiveBaselinePower, cumsumRealized, cumsumBaseline big.Int) big.Int {
 	var rewardTheta big.Int
 	if effectiveNetworkTime != 0 {
 		rewardTheta = big.NewInt(int64(effectiveNetworkTime)) // Q.0
 		rewardTheta = big.Lsh(rewardTheta, precision)         // Q.0 => Q.128
 		diff := big.Sub(cumsumBaseline, cumsumRealized)
-		diff = big.Lsh(diff, precision)                             // Q.0 => Q.128
-		diff = big.Div(diff, BaselinePowerAt(effectiveNetworkTime)) // Q.128 / Q.0 => Q.128
-		rewardTheta = big.Sub(rewardTheta, diff)                    // Q.128
+		diff = big.Lsh(diff, precision)              // Q.0 => Q.128
+		diff = big.Div(diff, effectiveBaselinePower) // Q.128 / Q.0 => Q.128
+		rewardTheta = big.Sub(rewardTheta, diff)     // Q.128
 	} else {
 		// special case for initialization
 		rewardTheta = big.Zero()
@@ -49,11 +91,7 @@ var (
 // Computes a reward for all expected leaders when effective network time changes from prevTheta to currTheta
 // Inputs are in Q.128 format
 func computeReward(epoch abi.ChainEpoch, prevTheta, currTheta big.Int) abi.TokenAmount {
-	simpleReward := big.Mul(SimpleTotal, expLamSubOne)    //Q.0 * Q.128 =>  Q.128
-	epochLam := big.Mul(big.NewInt(int64(epoch)), lambda) // Q.0 * Q.128 => Q.128
-
-	simpleReward = big.Mul(simpleReward, big.Int{Int: expneg(epochLam.Int)}) // Q.128 * Q.128 => Q.256
-	simpleReward = big.Rsh(simpleReward, precision)                          // Q.256 >> 128 => Q.128
+	simpleReward := computeSimpleReward(epoch) // Q.128
 
 	baselineReward := big.Sub(computeBaselineSupply(currTheta), computeBaselineSupply(prevTheta)) // Q.128
 
@@ -62,13 +100,23 @@ func computeReward(epoch abi.ChainEpoch, prevTheta, currTheta big.Int) abi.Token
 	return big.Rsh(reward, precision) // Q.128 => Q.0
 }
 
+// Computes the simple minting reward for all expected leaders at an epoch.
+// Return is in Q.128 format
+func computeSimpleReward(epoch abi.ChainEpoch) big.Int {
+	simpleReward := big.Mul(SimpleTotal, expLamSubOne)    //Q.0 * Q.128 =>  Q.128
+	epochLam := big.Mul(big.NewInt(int64(epoch)), lambda) // Q.0 * Q.128 => Q.128
+
+	simpleReward = big.Mul(simpleReward, big.Int{Int: math.ExpNeg(epochLam.Int)}) // Q.128 * Q.128 => Q.256
+	return big.Rsh(simpleReward, precision)                                       // Q.256 >> 128 => Q.128
+}
+
 // Computes baseline supply based on theta in Q.128 format.
 // Return is in Q.128 format
 func computeBaselineSupply(theta big.Int) big.Int {
 	thetaLam := big.Mul(theta, lambda)      // Q.128 * Q.128 => Q.256
 	thetaLam = big.Rsh(thetaLam, precision) // Q.256 >> 128 => Q.128
 
-	eTL := big.Int{Int: expneg(thetaLam.Int)} // Q.128
+	eTL := big.Int{Int: math.ExpNeg(thetaLam.Int)} // Q.128
 
 	one := big.NewInt(1)
 	one = big.Lsh(one, precision) // Q.0 => Q.128
@@ -3,11 +3,13 @@ package reward
 import (
 	"bytes"
 	"fmt"
+	"math"
 	gbig "math/big"
 	"testing"
 
 	abi "github.com/filecoin-project/specs-actors/actors/abi"
 	big "github.com/filecoin-project/specs-actors/actors/abi/big"
+	builtin "github.com/filecoin-project/specs-actors/actors/builtin"
 	"github.com/stretchr/testify/assert"
 	"github.com/xorcare/golden"
 )
@@ -20,25 +22,88 @@ func q128ToF(x big.Int) float64 {
 }
 
 func TestComputeRTeta(t *testing.T) {
-	var oldBaselinePowerAt func(abi.ChainEpoch) abi.StoragePower
-
-	BaselinePowerAt, oldBaselinePowerAt = func(epoch abi.ChainEpoch) abi.StoragePower {
+	baselinePowerAt := func(epoch abi.ChainEpoch) abi.StoragePower {
 		return big.Mul(big.NewInt(int64(epoch+1)), big.NewInt(2048))
-	}, BaselinePowerAt
-	defer func() {
-		BaselinePowerAt = oldBaselinePowerAt
-	}()
+	}
 
-	assert.Equal(t, 0.5, q128ToF(computeRTheta(1, big.NewInt(2048+2*2048*0.5), big.NewInt(2048+2*2048))))
-	assert.Equal(t, 0.25, q128ToF(computeRTheta(1, big.NewInt(2048+2*2048*0.25), big.NewInt(2048+2*2048))))
+	assert.Equal(t, 0.5, q128ToF(computeRTheta(1, baselinePowerAt(1), big.NewInt(2048+2*2048*0.5), big.NewInt(2048+2*2048))))
+	assert.Equal(t, 0.25, q128ToF(computeRTheta(1, baselinePowerAt(1), big.NewInt(2048+2*2048*0.25), big.NewInt(2048+2*2048))))
 
 	cumsum15 := big.NewInt(0)
 	for i := abi.ChainEpoch(0); i < 16; i++ {
-		cumsum15 = big.Add(cumsum15, BaselinePowerAt(i))
+		cumsum15 = big.Add(cumsum15, baselinePowerAt(i))
 	}
-	assert.Equal(t, 15.25, q128ToF(computeRTheta(16,
-		big.Add(cumsum15, big.Div(BaselinePowerAt(16), big.NewInt(4))),
-		big.Add(cumsum15, BaselinePowerAt(16)))))
+	assert.Equal(t, 15.25, q128ToF(computeRTheta(16, baselinePowerAt(16),
+		big.Add(cumsum15, big.Div(baselinePowerAt(16), big.NewInt(4))),
+		big.Add(cumsum15, baselinePowerAt(16)))))
+}
+
+func TestBaselinePower(t *testing.T) {
+	t.Run("starts at initial value", func(t *testing.T) {
+		assert.Equal(t, BaselineInitialValue, BaselinePowerAt(0))
+	})
+
+	t.Run("grows at annual rate", func(t *testing.T) {
+		// Default parameters double the baseline each year, subject to Q.128 precision.
+		year := abi.ChainEpoch(builtin.EpochsInYear)
+		for years := int64(1); years <= 10; years++ {
+			expected := q128ToF(big.Lsh(BaselineInitialValue, precision+uint(years)))
+			actual := q128ToF(big.Lsh(BaselinePowerAt(abi.ChainEpoch(years)*year), precision))
+			assert.InEpsilon(t, expected, actual, 1e-12)
+		}
+		assert.True(t, BaselinePowerAt(year/2).GreaterThan(BaselinePowerAt(year/2-1)))
+	})
+
+	t.Run("uses configured parameters", func(t *testing.T) {
+		oldInitial, oldExponent := BaselineInitialValue, BaselineExponent
+		defer func() {
+			BaselineInitialValue, BaselineExponent = oldInitial, oldExponent
+		}()
+
+		// Grow by a factor of e every 1000 epochs.
+		BaselineInitialValue = big.NewInt(1 << 50)
+		BaselineExponent = big.Div(big.Lsh(big.NewInt(1), precision), big.NewInt(1000))
+		assert.Equal(t, big.NewInt(1<<50), BaselinePowerAt(0))
+		assert.InEpsilon(t, math.E*(1<<50), q128ToF(big.Lsh(BaselinePowerAt(1000), precision)), 1e-12)
+		assert.InEpsilon(t, math.Exp(10)*(1<<50), q128ToF(big.Lsh(BaselinePowerAt(10000), precision)), 1e-12)
+	})
+
+	t.Run("shrinks before genesis", func(t *testing.T) {
+		// A negative exponent halves the baseline for each multiple of ln(2), rather than failing.
+		year := abi.ChainEpoch(builtin.EpochsInYear)
+		for years := int64(1); years <= 10; years++ {
+			expected := q128ToF(big.Rsh(big.Lsh(BaselineInitialValue, precision), uint(years)))
+			actual := q128ToF(big.Lsh(BaselinePowerAt(-abi.ChainEpoch(years)*year), precision))
+			assert.InEpsilon(t, expected, actual, 1e-12)
+		}
+		assert.True(t, BaselinePowerAt(-1).LessThan(BaselineInitialValue))
+		assert.True(t, BaselinePowerAt(-2).LessThan(BaselinePowerAt(-1)))
+	})
+
+	t.Run("state tracks closed form", func(t *testing.T) {
+		st := ConstructState(big.Lsh(BaselineInitialValue, 1))
+		checkBaseline := func() {
+			assert.Equal(t, BaselinePowerAt(st.Epoch), st.ThisEpochBaselinePower)
+			assert.Equal(t, BaselinePowerAt(st.EffectiveNetworkTime), st.EffectiveBaselinePower)
+		}
+		checkBaseline()
+
+		// Realized power above the baseline advances effective network time with the epoch,
+		// then below the baseline lets it fall behind, with and without null rounds.
+		for i := 0; i < 2000; i++ {
+			power := big.Lsh(BaselineInitialValue, 1)
+			if i >= 1000 {
+				power = big.Div(BaselineInitialValue, big.NewInt(3))
+			}
+			if i%7 == 0 {
+				st.updateToNextEpoch(power)
+			} else {
+				st.updateToNextEpochWithReward(power)
+			}
+			checkBaseline()
+		}
+		assert.True(t, st.EffectiveNetworkTime < st.Epoch)
+	})
 }
 
 func TestBaselineReward(t *testing.T) {
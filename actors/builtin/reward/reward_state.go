@@ -3,6 +3,8 @@ package reward
 import (
 	abi "github.com/filecoin-project/specs-actors/actors/abi"
 	big "github.com/filecoin-project/specs-actors/actors/abi/big"
+	builtin "github.com/filecoin-project/specs-actors/actors/builtin"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 // A quantity of space * time (in byte-epochs) representing power committed to the network for some duration.
@@ -27,11 +29,33 @@ type State struct {
 	// This value is recomputed every non-null epoch and used in the next non-null epoch.
 	ThisEpochReward abi.TokenAmount
 
+	// Smoothed estimate of ThisEpochReward, updated each time the reward is recomputed.
+	ThisEpochRewardSmoothed smoothing.FilterEstimate
+
+	// The part of ThisEpochReward due to simple minting, the remainder being due to baseline minting.
+	ThisEpochSimpleReward abi.TokenAmount
+
 	// The baseline power the network is targeting at st.Epoch
 	ThisEpochBaselinePower abi.StoragePower
 
+	// The baseline power at EffectiveNetworkTime
+	EffectiveBaselinePower abi.StoragePower
+
 	// Epoch tracks for which epoch the Reward was computed
 	Epoch abi.ChainEpoch
+
+	// Cumulative block rewards paid to block producers, being the total FIL minted.
+	// Gas rewards are not included, as they are paid from fees rather than minted.
+	TotalMinted abi.TokenAmount
+
+	// The parts of TotalMinted due to simple and baseline minting.
+	TotalSimpleMinted   abi.TokenAmount
+	TotalBaselineMinted abi.TokenAmount
+
+	// Cumulative penalties burnt by block producers on receiving their rewards.
+	// This is the fee debt each miner repaid, including the block's penalty and any earlier debt, so may differ
+	// from the penalties charged: a penalty the miner cannot pay immediately remains as fee debt.
+	TotalPenalties abi.TokenAmount
 }
 
 func ConstructState(currRealizedPower abi.StoragePower) *State {
@@ -41,36 +65,87 @@ func ConstructState(currRealizedPower abi.StoragePower) *State {
 		EffectiveNetworkTime: 0,
 
 		ThisEpochReward:        big.Zero(),
-		ThisEpochBaselinePower: big.Zero(),
+		ThisEpochSimpleReward:  big.Zero(),
+		ThisEpochBaselinePower: BaselineInitialValue,
+		EffectiveBaselinePower: BaselineInitialValue,
 		Epoch:                  -1,
+
+		TotalMinted:         big.Zero(),
+		TotalSimpleMinted:   big.Zero(),
+		TotalBaselineMinted: big.Zero(),
+		TotalPenalties:      big.Zero(),
 	}
 
 	st.updateToNextEpochWithReward(currRealizedPower)
+	st.ThisEpochRewardSmoothed = smoothing.NewEstimate(st.ThisEpochReward, big.Zero())
 
 	return st
 }
 
+// Updates the state at the end of a non-null epoch with the network's realized power,
+// computing the reward for the next epoch.
+// The state is first caught up through any null rounds since the last update, at the same realized power.
+// This is the computation performed by the actor's UpdateNetworkKPI method, exposed for simulation.
+func (st *State) UpdateNetworkKPI(currEpoch abi.ChainEpoch, currRealizedPower abi.StoragePower) {
+	prevEpoch := st.Epoch
+	// if there were null runs catch up the computation until
+	// st.Epoch == currEpoch
+	for st.Epoch < currEpoch {
+		// Update to next epoch to process null rounds
+		st.updateToNextEpoch(currRealizedPower)
+	}
+
+	st.updateToNextEpochWithReward(currRealizedPower)
+	st.updateSmoothedEstimates(st.Epoch - prevEpoch)
+}
+
 // Takes in current realized power and updates internal state
 // Used for update of internal state during null rounds
 func (st *State) updateToNextEpoch(currRealizedPower abi.StoragePower) {
 	st.Epoch++
+	st.ThisEpochBaselinePower = BaselinePowerAt(st.Epoch)
 
-	cappedRealizedPower := big.Min(BaselinePowerAt(st.Epoch), currRealizedPower)
+	cappedRealizedPower := big.Min(st.ThisEpochBaselinePower, currRealizedPower)
 	st.CumsumRealized = big.Add(st.CumsumRealized, cappedRealizedPower)
 
 	for st.CumsumRealized.GreaterThan(st.CumsumBaseline) {
 		st.EffectiveNetworkTime++
-		st.CumsumBaseline = big.Add(st.CumsumBaseline, BaselinePowerAt(st.EffectiveNetworkTime))
+		st.EffectiveBaselinePower = BaselinePowerAt(st.EffectiveNetworkTime)
+		st.CumsumBaseline = big.Add(st.CumsumBaseline, st.EffectiveBaselinePower)
 	}
 }
 
 // Takes in a current realized power for a reward epoch and computes
 // and updates reward state to track reward for the next epoch
 func (st *State) updateToNextEpochWithReward(currRealizedPower abi.StoragePower) {
-	prevRewardTheta := computeRTheta(st.EffectiveNetworkTime, st.CumsumRealized, st.CumsumBaseline)
+	prevRewardTheta := computeRTheta(st.EffectiveNetworkTime, st.EffectiveBaselinePower, st.CumsumRealized, st.CumsumBaseline)
 	st.updateToNextEpoch(currRealizedPower)
-	currRewardTheta := computeRTheta(st.EffectiveNetworkTime, st.CumsumRealized, st.CumsumBaseline)
+	currRewardTheta := computeRTheta(st.EffectiveNetworkTime, st.EffectiveBaselinePower, st.CumsumRealized, st.CumsumBaseline)
 
 	st.ThisEpochReward = computeReward(st.Epoch, prevRewardTheta, currRewardTheta)
-	st.ThisEpochBaselinePower = BaselinePowerAt(st.Epoch)
+	st.ThisEpochSimpleReward = big.Rsh(computeSimpleReward(st.Epoch), precision) // Q.128 => Q.0
+}
+
+// Records a block reward paid out of ThisEpochReward.
+// The simple part of the reward is attributed in proportion to the block's share of the epoch reward,
+// and the remainder to baseline minting.
+// This is the accounting performed by the actor's AwardBlockReward method, exposed for simulation.
+func (st *State) RecordBlockReward(paid abi.TokenAmount, winCount int64) {
+	simplePaid := big.Mul(st.ThisEpochSimpleReward, big.NewInt(winCount))
+	simplePaid = big.Div(simplePaid, big.NewInt(builtin.ExpectedLeadersPerEpoch))
+	simplePaid = big.Min(simplePaid, paid)
+
+	st.TotalMinted = big.Add(st.TotalMinted, paid)
+	st.TotalSimpleMinted = big.Add(st.TotalSimpleMinted, simplePaid)
+	st.TotalBaselineMinted = big.Add(st.TotalBaselineMinted, big.Sub(paid, simplePaid))
+}
+
+// Records the penalties and other fee debt burnt by a block producer on receiving its reward.
+func (st *State) recordPenaltiesBurnt(burnt abi.TokenAmount) {
+	st.TotalPenalties = big.Add(st.TotalPenalties, burnt)
+}
+
+// Updates the smoothed reward estimate with this epoch's reward, some number of epochs after the previous update.
+func (st *State) updateSmoothedEstimates(epochDelta abi.ChainEpoch) {
+	st.ThisEpochRewardSmoothed = smoothing.NextEstimate(st.ThisEpochRewardSmoothed, st.ThisEpochReward, epochDelta)
 }
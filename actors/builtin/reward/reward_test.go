@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	address "github.com/filecoin-project/go-address"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/filecoin-project/specs-actors/actors/abi"
@@ -31,6 +32,8 @@ func TestConstructor(t *testing.T) {
 		assert.Equal(t, abi.ChainEpoch(0), st.Epoch)
 		assert.Equal(t, abi.NewStoragePower(0), st.CumsumRealized)
 		assert.Equal(t, big.MustFromString("9152074749760199658"), st.ThisEpochReward)
+		assert.Equal(t, st.ThisEpochReward, st.ThisEpochRewardSmoothed.Estimate())
+		assert.Equal(t, big.Zero(), st.ThisEpochRewardSmoothed.VelocityEstimate)
 	})
 	t.Run("construct with some power", func(t *testing.T) {
 		rt := mock.NewBuilder(context.Background(), builtin.RewardActorAddr).
@@ -45,7 +48,7 @@ func TestConstructor(t *testing.T) {
 		// Note this check is sensative to the value of startRealizedPower and the minting function
 		// so it is somewhat brittle. Values of startRealizedPower below 1<<20 mint no coins
 		assert.NotEqual(t, big.Zero(), st.ThisEpochReward)
-		assert.Equal(t, big.MustFromString("50336408296765376121"), st.ThisEpochReward)
+		assert.Equal(t, big.MustFromString("50336385681480693788"), st.ThisEpochReward)
 	})
 }
 
@@ -85,7 +88,8 @@ func TestAwardBlockReward(t *testing.T) {
 		smallReward := abi.NewTokenAmount(300)
 		rt.SetBalance(smallReward)
 		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
-		rt.ExpectSend(miner, builtin.MethodsMiner.AddLockedFund, &smallReward, smallReward, nil, 0)
+		rt.ExpectSend(miner, builtin.MethodsMiner.ApplyRewards, &builtin.ApplyRewardParams{Reward: smallReward, Penalty: big.Zero()},
+			smallReward, &builtin.ApplyRewardsReturn{Locked: big.Zero(), DebtRepaid: big.Zero()}, 0)
 		rt.Call(actor.AwardBlockReward, &reward.AwardBlockRewardParams{
 			Miner:     miner,
 			Penalty:   big.Zero(),
@@ -93,6 +97,74 @@ func TestAwardBlockReward(t *testing.T) {
 			WinCount:  1,
 		})
 		rt.Verify()
+
+		stats := actor.mintingStats(rt)
+		assert.Equal(t, smallReward, stats.TotalMinted)
+		assert.Equal(t, big.Add(stats.TotalSimpleMinted, stats.TotalBaselineMinted), stats.TotalMinted)
+	})
+
+	t.Run("passes penalty to miner with reward", func(t *testing.T) {
+		rt := builder.Build(t)
+		startRealizedPower := abi.NewStoragePower(1)
+		actor.constructAndVerify(rt, &startRealizedPower)
+		miner := tutil.NewIDAddr(t, 1000)
+
+		// The penalty may exceed the reward; the miner pays it from its balance.
+		smallReward := abi.NewTokenAmount(300)
+		penalty := abi.NewTokenAmount(400)
+		rt.SetBalance(smallReward)
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectSend(miner, builtin.MethodsMiner.ApplyRewards, &builtin.ApplyRewardParams{Reward: smallReward, Penalty: penalty},
+			smallReward, &builtin.ApplyRewardsReturn{Locked: big.Zero(), DebtRepaid: smallReward}, 0)
+		rt.Call(actor.AwardBlockReward, &reward.AwardBlockRewardParams{
+			Miner:     miner,
+			Penalty:   penalty,
+			GasReward: big.Zero(),
+			WinCount:  1,
+		})
+		rt.Verify()
+
+		// Only the part of the penalty the miner could pay was burnt.
+		assert.Equal(t, smallReward, actor.mintingStats(rt).TotalPenalties)
+	})
+}
+
+func TestMintingStats(t *testing.T) {
+	actor := rewardHarness{reward.Actor{}, t}
+	builder := mock.NewBuilder(context.Background(), builtin.RewardActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	t.Run("records minting split and penalties", func(t *testing.T) {
+		rt := builder.Build(t)
+		startRealizedPower := big.Lsh(abi.NewStoragePower(1), 39)
+		actor.constructAndVerify(rt, &startRealizedPower)
+		rt.SetBalance(big.Mul(big.NewInt(1e9), big.NewInt(1e18)))
+		miner := tutil.NewIDAddr(t, 1000)
+
+		stats := actor.mintingStats(rt)
+		assert.Equal(t, big.Zero(), stats.TotalMinted)
+		assert.Equal(t, big.Zero(), stats.TotalPenalties)
+
+		st := getState(rt)
+		// Realized power advances effective network time, so both minting functions contribute.
+		assert.True(t, st.ThisEpochSimpleReward.GreaterThan(big.Zero()))
+		assert.True(t, st.ThisEpochSimpleReward.LessThan(st.ThisEpochReward))
+
+		gasReward := abi.NewTokenAmount(5)
+		penalty := abi.NewTokenAmount(10)
+		actor.awardBlockReward(rt, miner, penalty, gasReward, 2, penalty)
+		actor.awardBlockReward(rt, miner, penalty, gasReward, 1, penalty)
+
+		blockReward := big.Div(big.Mul(st.ThisEpochReward, big.NewInt(2)), big.NewInt(builtin.ExpectedLeadersPerEpoch))
+		blockReward = big.Add(blockReward, big.Div(st.ThisEpochReward, big.NewInt(builtin.ExpectedLeadersPerEpoch)))
+		simpleReward := big.Div(big.Mul(st.ThisEpochSimpleReward, big.NewInt(2)), big.NewInt(builtin.ExpectedLeadersPerEpoch))
+		simpleReward = big.Add(simpleReward, big.Div(st.ThisEpochSimpleReward, big.NewInt(builtin.ExpectedLeadersPerEpoch)))
+
+		stats = actor.mintingStats(rt)
+		assert.Equal(t, blockReward, stats.TotalMinted)
+		assert.Equal(t, simpleReward, stats.TotalSimpleMinted)
+		assert.Equal(t, big.Sub(blockReward, simpleReward), stats.TotalBaselineMinted)
+		assert.Equal(t, big.Mul(penalty, big.NewInt(2)), stats.TotalPenalties)
 	})
 }
 
@@ -109,6 +181,30 @@ func (h *rewardHarness) constructAndVerify(rt *mock.Runtime, currRawPower *abi.S
 
 }
 
+func (h *rewardHarness) awardBlockReward(rt *mock.Runtime, miner address.Address, penalty, gasReward abi.TokenAmount, winCount int64, burnt abi.TokenAmount) {
+	st := getState(rt)
+	blockReward := big.Div(big.Mul(st.ThisEpochReward, big.NewInt(winCount)), big.NewInt(builtin.ExpectedLeadersPerEpoch))
+	totalReward := big.Add(blockReward, gasReward)
+
+	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+	rt.ExpectSend(miner, builtin.MethodsMiner.ApplyRewards, &builtin.ApplyRewardParams{Reward: totalReward, Penalty: penalty},
+		totalReward, &builtin.ApplyRewardsReturn{Locked: big.Zero(), DebtRepaid: burnt}, 0)
+	rt.Call(h.AwardBlockReward, &reward.AwardBlockRewardParams{
+		Miner:     miner,
+		Penalty:   penalty,
+		GasReward: gasReward,
+		WinCount:  winCount,
+	})
+	rt.Verify()
+}
+
+func (h *rewardHarness) mintingStats(rt *mock.Runtime) *reward.MintingStatsReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.MintingStats, nil).(*reward.MintingStatsReturn)
+	rt.Verify()
+	return ret
+}
+
 func getState(rt *mock.Runtime) *reward.State {
 	var st reward.State
 	rt.GetState(&st)
@@ -50,6 +50,21 @@ type MinerAddrs struct {
 	Worker addr.Address
 }
 
+// Parameters for the miner actor's ApplyRewards method, defined here so that the reward actor may send them
+// without a circular dependency between actors.
+type ApplyRewardParams struct {
+	Reward  abi.TokenAmount
+	Penalty abi.TokenAmount
+}
+
+// Return value of the miner actor's ApplyRewards method, defined here for the same reason.
+type ApplyRewardsReturn struct {
+	// Amount of the reward locked in the vesting table.
+	Locked abi.TokenAmount
+	// Amount of fee debt repaid, which was burnt.
+	DebtRepaid abi.TokenAmount
+}
+
 type ConfirmSectorProofsParams struct {
 	Sectors []abi.SectorNumber
 }
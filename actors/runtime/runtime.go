@@ -98,12 +98,13 @@ type Runtime interface {
 
 	// Returns the total token supply in circulation at the beginning of the current epoch.
 	// The circulating supply is the sum of:
-	// - rewards emitted by the reward actor,
+	// - rewards emitted by the reward actor (recorded as its total minted),
 	// - funds vested from lock-ups in the genesis state,
 	// less the sum of:
 	// - funds burnt,
 	// - pledge collateral locked in storage miner actors (recorded in the storage power actor)
 	// - deal collateral locked by the storage market actor
+	// This is computed from a state tree by states.ComputeCirculatingSupply.
 	TotalFilCircSupply() abi.TokenAmount
 
 	// Provides a Go context for use by HAMT, etc.
@@ -158,6 +159,9 @@ type Syscalls interface {
 
 	BatchVerifySeals(vis map[address.Address][]abi.SealVerifyInfo) (map[address.Address][]bool, error)
 
+	// Verifies a proof that a sector's sealed replica has been updated with new data.
+	VerifyReplicaUpdate(update abi.ReplicaUpdateInfo) error
+
 	// Verifies a proof of spacetime.
 	VerifyPoSt(vi abi.WindowPoStVerifyInfo) error
 	// Verifies that two block headers provide proof of a consensus fault:
@@ -0,0 +1,80 @@
+package states
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	xerrors "golang.org/x/xerrors"
+
+	abi "github.com/filecoin-project/specs-actors/actors/abi"
+	big "github.com/filecoin-project/specs-actors/actors/abi/big"
+	builtin "github.com/filecoin-project/specs-actors/actors/builtin"
+	market "github.com/filecoin-project/specs-actors/actors/builtin/market"
+	multisig "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	power "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	reward "github.com/filecoin-project/specs-actors/actors/builtin/reward"
+)
+
+// The components of the token supply in circulation, as defined for Runtime.TotalFilCircSupply.
+type CirculatingSupply struct {
+	Minted       abi.TokenAmount // Block rewards emitted by the reward actor
+	Vested       abi.TokenAmount // Funds vested from lock-ups in genesis multisig actors
+	Burnt        abi.TokenAmount // Balance of the burnt funds actor
+	PledgeLocked abi.TokenAmount // Pledge collateral locked in miner actors, as recorded by the power actor
+	MarketLocked abi.TokenAmount // Deal collateral and storage fees locked by the market actor
+
+	// Minted + Vested - Burnt - PledgeLocked - MarketLocked, or zero if that would be negative.
+	Total abi.TokenAmount
+}
+
+// Computes the circulating supply at some epoch from a state tree.
+// The genesis multisigs are the actors holding funds that were locked, to vest linearly, in the genesis state.
+func ComputeCirculatingSupply(tree *Tree, genesisMultisigs []addr.Address, currEpoch abi.ChainEpoch) (*CirculatingSupply, error) {
+	var rewardSt reward.State
+	if _, err := tree.LoadActorState(builtin.RewardActorAddr, &rewardSt); err != nil {
+		return nil, err
+	}
+	var powerSt power.State
+	if _, err := tree.LoadActorState(builtin.StoragePowerActorAddr, &powerSt); err != nil {
+		return nil, err
+	}
+	var marketSt market.State
+	if _, err := tree.LoadActorState(builtin.StorageMarketActorAddr, &marketSt); err != nil {
+		return nil, err
+	}
+	burntActor, found, err := tree.GetActor(builtin.BurntFundsActorAddr)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, xerrors.Errorf("no burnt funds actor at %v", builtin.BurntFundsActorAddr)
+	}
+
+	vested := big.Zero()
+	for _, msigAddr := range genesisMultisigs {
+		var msigSt multisig.State
+		if _, err := tree.LoadActorState(msigAddr, &msigSt); err != nil {
+			return nil, err
+		}
+		vested = big.Add(vested, vestedFromGenesis(&msigSt, currEpoch))
+	}
+
+	supply := &CirculatingSupply{
+		Minted:       rewardSt.TotalMinted,
+		Vested:       vested,
+		Burnt:        burntActor.Balance,
+		PledgeLocked: powerSt.TotalPledgeCollateral,
+		MarketLocked: big.Sum(marketSt.TotalClientLockedCollateral, marketSt.TotalProviderLockedCollateral,
+			marketSt.TotalClientStorageFee),
+	}
+	supply.Total = big.Sub(big.Add(supply.Minted, supply.Vested),
+		big.Sum(supply.Burnt, supply.PledgeLocked, supply.MarketLocked))
+	supply.Total = big.Max(supply.Total, big.Zero())
+	return supply, nil
+}
+
+// Computes the amount vested as of some epoch from a multisig's initial locked balance.
+func vestedFromGenesis(st *multisig.State, currEpoch abi.ChainEpoch) abi.TokenAmount {
+	elapsed := currEpoch - st.StartEpoch
+	if elapsed < 0 {
+		return big.Zero()
+	}
+	return big.Sub(st.InitialBalance, st.AmountLocked(elapsed))
+}
@@ -0,0 +1,123 @@
+package states_test
+
+import (
+	"context"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	cid "github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/actors/states"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/support/testing"
+)
+
+func TestCirculatingSupply(t *testing.T) {
+	msig1 := tutil.NewIDAddr(t, 100)
+	msig2 := tutil.NewIDAddr(t, 101)
+
+	// Builds a state tree with the given reward minted, power pledge, market collateral and burnt funds,
+	// and two genesis multisigs each locking 1000 to vest over 100 epochs, from epochs 10 and 100 respectively.
+	setup := func(t *testing.T, minted, pledge, marketLocked, burnt abi.TokenAmount) *states.Tree {
+		store := ipld.NewADTStore(context.Background())
+		tree := states.NewTree(store)
+		emptyMap, err := adt.MakeEmptyMap(store).Root()
+		require.NoError(t, err)
+		emptyArray, err := adt.MakeEmptyArray(store).Root()
+		require.NoError(t, err)
+
+		putActor := func(a addr.Address, code cid.Cid, state interface{}, balance abi.TokenAmount) {
+			head, err := store.Put(context.Background(), state)
+			require.NoError(t, err)
+			require.NoError(t, tree.SetActor(a, &states.Actor{
+				Code:    code,
+				Head:    head,
+				Balance: balance,
+			}))
+		}
+
+		rewardSt := reward.ConstructState(big.Zero())
+		rewardSt.TotalMinted = minted
+		putActor(builtin.RewardActorAddr, builtin.RewardActorCodeID, rewardSt, big.Zero())
+
+		powerSt := power.ConstructState(emptyMap, emptyMap, power.DefaultSealProofPolicies())
+		powerSt.TotalPledgeCollateral = pledge
+		putActor(builtin.StoragePowerActorAddr, builtin.StoragePowerActorCodeID, powerSt, big.Zero())
+
+		marketSt := market.ConstructState(emptyArray, emptyMap, emptyMap)
+		marketSt.TotalClientLockedCollateral = marketLocked
+		marketSt.TotalProviderLockedCollateral = marketLocked
+		marketSt.TotalClientStorageFee = marketLocked
+		putActor(builtin.StorageMarketActorAddr, builtin.StorageMarketActorCodeID, marketSt, big.Zero())
+
+		putActor(builtin.BurntFundsActorAddr, builtin.AccountActorCodeID, &account.State{Address: builtin.BurntFundsActorAddr}, burnt)
+
+		for i, a := range []addr.Address{msig1, msig2} {
+			putActor(a, builtin.MultisigActorCodeID, &multisig.State{
+				Signers:        []addr.Address{},
+				InitialBalance: abi.NewTokenAmount(1000),
+				StartEpoch:     abi.ChainEpoch(10 + 90*i),
+				UnlockDuration: 100,
+				PendingTxns:    emptyMap,
+			}, abi.NewTokenAmount(1000))
+		}
+
+		// Reload the tree from its root, as a VM would provide it.
+		root, err := tree.Flush()
+		require.NoError(t, err)
+		tree, err = states.LoadTree(store, root)
+		require.NoError(t, err)
+		return tree
+	}
+
+	t.Run("computes supply components", func(t *testing.T) {
+		tree := setup(t, abi.NewTokenAmount(10_000), abi.NewTokenAmount(2000), abi.NewTokenAmount(100), abi.NewTokenAmount(50))
+
+		// At epoch 60, half the first multisig has vested and none of the second.
+		supply, err := states.ComputeCirculatingSupply(tree, []addr.Address{msig1, msig2}, 60)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(10_000), supply.Minted)
+		assert.Equal(t, abi.NewTokenAmount(500), supply.Vested)
+		assert.Equal(t, abi.NewTokenAmount(50), supply.Burnt)
+		assert.Equal(t, abi.NewTokenAmount(2000), supply.PledgeLocked)
+		assert.Equal(t, abi.NewTokenAmount(300), supply.MarketLocked)
+		assert.Equal(t, abi.NewTokenAmount(10_000+500-50-2000-300), supply.Total)
+
+		// Later, both have fully vested.
+		supply, err = states.ComputeCirculatingSupply(tree, []addr.Address{msig1, msig2}, 500)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(2000), supply.Vested)
+		assert.Equal(t, abi.NewTokenAmount(10_000+2000-50-2000-300), supply.Total)
+
+		// Only the given multisigs are counted as vesting from genesis.
+		supply, err = states.ComputeCirculatingSupply(tree, nil, 500)
+		require.NoError(t, err)
+		assert.Equal(t, big.Zero(), supply.Vested)
+	})
+
+	t.Run("total is not negative", func(t *testing.T) {
+		tree := setup(t, abi.NewTokenAmount(100), abi.NewTokenAmount(2000), big.Zero(), big.Zero())
+
+		supply, err := states.ComputeCirculatingSupply(tree, nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, big.Zero(), supply.Total)
+	})
+
+	t.Run("fails for missing actor", func(t *testing.T) {
+		tree := setup(t, big.Zero(), big.Zero(), big.Zero(), big.Zero())
+
+		_, err := states.ComputeCirculatingSupply(tree, []addr.Address{tutil.NewIDAddr(t, 102)}, 0)
+		assert.Error(t, err)
+	})
+}
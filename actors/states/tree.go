@@ -0,0 +1,83 @@
+package states
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	cid "github.com/ipfs/go-cid"
+	xerrors "golang.org/x/xerrors"
+
+	abi "github.com/filecoin-project/specs-actors/actors/abi"
+	adt "github.com/filecoin-project/specs-actors/actors/util/adt"
+)
+
+// The on-chain state of an actor, as recorded in the state tree.
+type Actor struct {
+	Code       cid.Cid // CID representing the code associated with the actor
+	Head       cid.Cid // CID of the head state object for the actor
+	CallSeqNum uint64  // CallSeqNum for the next message to be received by the actor (non-zero for accounts only)
+	Balance    abi.TokenAmount
+}
+
+// A specialization of a map of ID-addresses to actor heads.
+// The state tree is maintained by a VM; this type provides read and write access to it outside of actor execution.
+type Tree struct {
+	Map   *adt.Map
+	Store adt.Store
+}
+
+// Initializes a new, empty state tree backed by a store.
+func NewTree(store adt.Store) *Tree {
+	return &Tree{
+		Map:   adt.MakeEmptyMap(store),
+		Store: store,
+	}
+}
+
+// Loads a tree from a root CID and store.
+func LoadTree(s adt.Store, r cid.Cid) (*Tree, error) {
+	m, err := adt.AsMap(s, r)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load state tree %v: %w", r, err)
+	}
+	return &Tree{
+		Map:   m,
+		Store: s,
+	}, nil
+}
+
+// Writes the tree root node to the store, and returns its CID.
+func (t *Tree) Flush() (cid.Cid, error) {
+	return t.Map.Root()
+}
+
+// Loads the state associated with an address.
+func (t *Tree) GetActor(addr addr.Address) (*Actor, bool, error) {
+	var actor Actor
+	found, err := t.Map.Get(adt.AddrKey(addr), &actor)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load actor %v: %w", addr, err)
+	}
+	return &actor, found, nil
+}
+
+// Sets the state associated with an address, overwriting any existing state.
+func (t *Tree) SetActor(addr addr.Address, actor *Actor) error {
+	if err := t.Map.Put(adt.AddrKey(addr), actor); err != nil {
+		return xerrors.Errorf("failed to set actor %v: %w", addr, err)
+	}
+	return nil
+}
+
+// Loads the head state of the actor at an address into out.
+// Returns an error if the actor is not found.
+func (t *Tree) LoadActorState(addr addr.Address, out interface{}) (*Actor, error) {
+	actor, found, err := t.GetActor(addr)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, xerrors.Errorf("no actor at address %v", addr)
+	}
+	if err := t.Store.Get(t.Store.Context(), actor.Head, out); err != nil {
+		return nil, xerrors.Errorf("failed to load state of actor %v: %w", addr, err)
+	}
+	return actor, nil
+}
@@ -90,6 +90,19 @@ func (a *Array) ForEach(out runtime.CBORUnmarshaler, fn func(i int64) error) err
 	})
 }
 
+// Iterates entries in the array with index at least `start`, in increasing order, as for ForEach.
+// Nodes holding only lower indices are not loaded.
+func (a *Array) ForEachFrom(out runtime.CBORUnmarshaler, start uint64, fn func(i int64) error) error {
+	return a.root.ForEachAt(a.store.Context(), start, func(k uint64, val *cbg.Deferred) error {
+		if out != nil {
+			if err := out.UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+				return err
+			}
+		}
+		return fn(int64(k))
+	})
+}
+
 func (a *Array) Length() uint64 {
 	return a.root.Count
 }
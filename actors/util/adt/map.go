@@ -2,10 +2,12 @@ package adt
 
 import (
 	"bytes"
+	"sort"
 
 	cid "github.com/ipfs/go-cid"
 	hamt "github.com/ipfs/go-hamt-ipld"
 	errors "github.com/pkg/errors"
+	"github.com/spaolacci/murmur3"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/xerrors"
 
@@ -108,6 +110,123 @@ func (m *Map) ForEach(out runtime.CBORUnmarshaler, fn func(key string) error) er
 	})
 }
 
+// Iterates the entries of the map whose keys follow some key, in order of the HAMT's hash of each key and then of
+// the key itself, deserializing each value in turn into `out` and then calling a function with the corresponding key.
+// The key after which to start need not be present in the map. If it is nil, iteration starts from the first entry.
+// The order is independent of the HAMT's shape, so iteration may be resumed after the last key visited even if the
+// map has been modified in between. Only the nodes following the starting key, and those on the path to it, are loaded.
+// Modifications to the map since it was last flushed are not visited.
+// Iteration halts if the function returns an error.
+// If the output parameter is nil, deserialization is skipped.
+func (m *Map) ForEachAfter(after Keyer, out runtime.CBORUnmarshaler, fn func(key string) error) error {
+	var start *hashedKey
+	if after != nil {
+		k := hashKey(after.Key())
+		start = &k
+	}
+	return m.forEachAfter(m.root, 0, start, out, fn)
+}
+
+func (m *Map) forEachAfter(nd *hamt.Node, depth int, after *hashedKey, out runtime.CBORUnmarshaler, fn func(key string) error) error {
+	// A node's pointers are held in order of the child index, taken from the next bits of their keys' hashes.
+	var afterIdx int
+	if after != nil {
+		afterIdx = hashIndex(after.hash, depth)
+	}
+	ptrIdx := 0
+	for idx := 0; idx < 1<<hamtBitwidth; idx++ {
+		if nd.Bitfield.Bit(idx) == 0 {
+			continue
+		}
+		p := nd.Pointers[ptrIdx]
+		ptrIdx++
+		if after != nil && idx < afterIdx {
+			continue
+		}
+		childAfter := after
+		if idx > afterIdx {
+			childAfter = nil
+		}
+
+		if p.Link.Defined() {
+			child, err := hamt.LoadNode(m.store.Context(), m.store, p.Link, hamt.UseTreeBitWidth(hamtBitwidth))
+			if err != nil {
+				return xerrors.Errorf("failed to load hamt node: %w", err)
+			}
+			if err := m.forEachAfter(child, depth+1, childAfter, out, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The entries of a bucket are held in order of key, which may differ from the order of their hashes.
+		kvs := make([]*hamt.KV, len(p.KVs))
+		keys := make([]hashedKey, len(p.KVs))
+		copy(kvs, p.KVs)
+		for i, kv := range kvs {
+			keys[i] = hashKey(kv.Key)
+		}
+		sort.Sort(bucketByHash{kvs, keys})
+		for i, kv := range kvs {
+			if childAfter != nil && !childAfter.less(keys[i]) {
+				continue
+			}
+			if out != nil {
+				if err := out.UnmarshalCBOR(bytes.NewReader(kv.Value.Raw)); err != nil {
+					return err
+				}
+			}
+			if err := fn(kv.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// A map key along with the HAMT's hash of it.
+type hashedKey struct {
+	key  string
+	hash []byte
+}
+
+func hashKey(key string) hashedKey {
+	h := murmur3.New64()
+	_, _ = h.Write([]byte(key))
+	return hashedKey{key: key, hash: h.Sum(nil)}
+}
+
+// Orders keys by hash, then by key.
+func (k hashedKey) less(o hashedKey) bool {
+	if c := bytes.Compare(k.hash, o.hash); c != 0 {
+		return c < 0
+	}
+	return k.key < o.key
+}
+
+// Sorts the entries of a HAMT bucket, along with their hashed keys, by hash.
+type bucketByHash struct {
+	kvs  []*hamt.KV
+	keys []hashedKey
+}
+
+func (b bucketByHash) Len() int           { return len(b.kvs) }
+func (b bucketByHash) Less(i, j int) bool { return b.keys[i].less(b.keys[j]) }
+func (b bucketByHash) Swap(i, j int) {
+	b.kvs[i], b.kvs[j] = b.kvs[j], b.kvs[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+// Returns the index of the child of a HAMT node at some depth under which a hash falls, being the hash's next
+// hamtBitwidth bits, most significant first.
+func hashIndex(hash []byte, depth int) int {
+	idx := 0
+	for i := depth * hamtBitwidth; i < (depth+1)*hamtBitwidth; i++ {
+		idx = idx<<1 | int(hash[i/8]>>(7-uint(i%8))&1)
+	}
+	return idx
+}
+
 // Collects all the keys from the map into a slice of strings.
 func (m *Map) CollectKeys() (out []string, err error) {
 	err = m.ForEach(nil, func(key string) error {
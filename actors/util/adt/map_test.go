@@ -0,0 +1,97 @@
+package adt_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/support/testing"
+)
+
+var errPageFull = errors.New("page full")
+
+func TestMapForEachAfter(t *testing.T) {
+	const count = 300
+
+	buildMap := func(t *testing.T) (adt.Store, *adt.Map) {
+		rt := mock.NewBuilder(context.Background(), address.Undef).Build(t)
+		store := adt.AsStore(rt)
+		m := adt.MakeEmptyMap(store)
+		for i := int64(0); i < count; i++ {
+			value := big.NewInt(i)
+			require.NoError(t, m.Put(adt.IntKey(i), &value))
+		}
+		m, err := adt.AsMap(store, tutil.MustRoot(t, m))
+		require.NoError(t, err)
+		return store, m
+	}
+
+	// Lists up to limit keys following some key.
+	page := func(t *testing.T, m *adt.Map, after adt.Keyer, limit int) []int64 {
+		var keys []int64
+		var value big.Int
+		err := m.ForEachAfter(after, &value, func(k string) error {
+			if len(keys) == limit {
+				return errPageFull
+			}
+			key, err := adt.ParseIntKey(k)
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(key), value)
+			keys = append(keys, key)
+			return nil
+		})
+		if err != errPageFull {
+			require.NoError(t, err)
+		}
+		return keys
+	}
+
+	t.Run("pages visit each entry once in a fixed order", func(t *testing.T) {
+		_, m := buildMap(t)
+		all := page(t, m, nil, count+1)
+		require.Len(t, all, count)
+		seen := make([]bool, count)
+		for _, k := range all {
+			assert.False(t, seen[k])
+			seen[k] = true
+		}
+
+		for _, limit := range []int{1, 7, 64} {
+			var paged []int64
+			var after adt.Keyer
+			for {
+				keys := page(t, m, after, limit)
+				if len(keys) == 0 {
+					break
+				}
+				paged = append(paged, keys...)
+				after = adt.IntKey(keys[len(keys)-1])
+			}
+			assert.Equal(t, all, paged, "limit %d", limit)
+		}
+	})
+
+	t.Run("resumes after a key since deleted", func(t *testing.T) {
+		store, m := buildMap(t)
+		all := page(t, m, nil, count+1)
+
+		first := page(t, m, nil, 10)
+		require.Equal(t, all[:10], first)
+
+		// Delete the cursor key and everything before it, then reload the map.
+		for _, k := range first {
+			require.NoError(t, m.Delete(adt.IntKey(k)))
+		}
+		m, err := adt.AsMap(store, tutil.MustRoot(t, m))
+		require.NoError(t, err)
+
+		assert.Equal(t, all[10:20], page(t, m, adt.IntKey(first[9]), 10))
+	})
+}
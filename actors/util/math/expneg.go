@@ -1,10 +1,11 @@
-package reward
+package math
 
 import (
 	"math/big"
 )
 
-const precision = 128
+// Precision128 is the number of fractional bits of the Q.128 fixed point format used by actor computations.
+const Precision128 = 128
 
 var (
 	// Coefficents in Q.128 format
@@ -60,30 +61,35 @@ func init() {
 	expDenoCoef = parse(deno)
 }
 
-// expneg accepts x in Q.128 format and computes e^-x.
+// ExpNeg accepts x in Q.128 format and computes e^-x.
 // It is most precise within [0, 1.725) range, where error is less than 3.4e-30.
 // Over the [0, 5) range its error is less than 4.6e-15.
 // Output is in Q.128 format.
-func expneg(x *big.Int) *big.Int {
+func ExpNeg(x *big.Int) *big.Int {
 	// exp is approximated by rational function
 	// polynomials of the rational function are evaluated using Horner's method
-	num := polyval(expNumCoef, x)   // Q.128
-	deno := polyval(expDenoCoef, x) // Q.128
+	num := Polyval(expNumCoef, x)   // Q.128
+	deno := Polyval(expDenoCoef, x) // Q.128
 
-	num = num.Lsh(num, precision) // Q.256
-	return num.Div(num, deno)     // Q.256 / Q.128 => Q.128
+	num = num.Lsh(num, Precision128) // Q.256
+	return num.Div(num, deno)        // Q.256 / Q.128 => Q.128
 }
 
-// polyval evaluates a polynomial given by coefficients `p` in Q.128 format
+// Polyval evaluates a polynomial given by coefficients `p` in Q.128 format
 // at point `x` in Q.128 format. Output is in Q.128.
 // Coefficients should be ordered from the highest order coefficient to the lowest.
-func polyval(p []*big.Int, x *big.Int) *big.Int {
+func Polyval(p []*big.Int, x *big.Int) *big.Int {
+	return polyval(p, x, Precision128)
+}
+
+// Evaluates a polynomial with coefficients and point in a fixed point format with some number of fractional bits.
+func polyval(p []*big.Int, x *big.Int, precision uint) *big.Int {
 	// evaluation using Horner's method
-	res := new(big.Int).Set(p[0]) // Q.128
+	res := new(big.Int).Set(p[0]) // Q.precision
 	tmp := new(big.Int)           // big.Int.Mul doesn't like when input is reused as output
 	for _, c := range p[1:] {
-		tmp = tmp.Mul(res, x)         // Q.128 * Q.128 => Q.256
-		res = res.Rsh(tmp, precision) // Q.256 >> 128 => Q.128
+		tmp = tmp.Mul(res, x)         // Q.precision * Q.precision => Q.2*precision
+		res = res.Rsh(tmp, precision) // Q.2*precision >> precision => Q.precision
 		res = res.Add(res, c)
 	}
 
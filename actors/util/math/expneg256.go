@@ -0,0 +1,54 @@
+package math
+
+import (
+	"math/big"
+)
+
+// Precision256 is the number of fractional bits of the Q.256 fixed point format, used where Q.128 is too coarse,
+// such as for probabilities compared with 256-bit uniform samples.
+const Precision256 = 256
+
+// Number of terms of the Taylor series of e^-x evaluated by ExpNeg256.
+// Over [0, ln(2)) the first omitted term, x^60 / 60!, is below 2^-300.
+const expNeg256Terms = 60
+
+var (
+	// ln(2) in Q.256 format: floor(ln(2) * 2^256)
+	ln2Q256 *big.Int
+
+	// Coefficients of the Taylor series of e^-x about zero, (-1)^k * floor(2^256 / k!), in Q.256 format.
+	// They are ordered from the highest order coefficient to the lowest.
+	expNeg256Coef []*big.Int
+)
+
+func init() {
+	var ok bool
+	ln2Q256, ok = new(big.Int).SetString("80260960185991308862233904206310070533990667611589946606122867505419956976171", 10)
+	if !ok {
+		panic("could not parse ln(2)")
+	}
+
+	expNeg256Coef = make([]*big.Int, expNeg256Terms)
+	factorial := big.NewInt(1)
+	for k := int64(0); k < expNeg256Terms; k++ {
+		if k > 0 {
+			factorial = factorial.Mul(factorial, big.NewInt(k))
+		}
+		c := new(big.Int).Lsh(big.NewInt(1), Precision256) // Q.256
+		c = c.Div(c, factorial)                            // Q.256 / Q.0 => Q.256
+		if k%2 == 1 {
+			c = c.Neg(c)
+		}
+		expNeg256Coef[expNeg256Terms-1-k] = c
+	}
+}
+
+// ExpNeg256 accepts a non-negative x in Q.256 format and computes e^-x.
+// The argument is reduced to x = k*ln(2) + r with r in [0, ln(2)), so that e^-x = 2^-k * e^-r, and e^-r
+// is evaluated from its Taylor series. The error is a few units in the last place of e^-r.
+// Output is in Q.256 format.
+func ExpNeg256(x *big.Int) *big.Int {
+	k, r := new(big.Int).QuoRem(x, ln2Q256, new(big.Int)) // Q.256 / Q.256 => Q.0, remainder Q.256
+	res := polyval(expNeg256Coef, r, Precision256)        // Q.256
+	return res.Rsh(res, uint(k.Uint64()))                 // Q.256
+}
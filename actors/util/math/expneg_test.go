@@ -0,0 +1,78 @@
+package math_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xorcare/golden"
+
+	math "github.com/filecoin-project/specs-actors/actors/util/math"
+)
+
+var Res big.Word
+
+func BenchmarkExpneg(b *testing.B) {
+	x := new(big.Int).SetUint64(14)
+	x = x.Lsh(x, math.Precision128-3) // set x to 1.75
+	dec := new(big.Int)
+	dec = dec.Div(x, big.NewInt(int64(b.N)))
+	b.ResetTimer()
+	b.ReportAllocs()
+	var res big.Word
+
+	for i := 0; i < b.N; i++ {
+		r := math.ExpNeg(x)
+		res += r.Bits()[0]
+		x.Sub(x, dec)
+	}
+	Res += res
+}
+
+func TestExpFunction(t *testing.T) {
+	const N = 256
+
+	step := big.NewInt(5)
+	step = step.Lsh(step, math.Precision128) // Q.128
+	step = step.Div(step, big.NewInt(N-1))
+
+	x := big.NewInt(0)
+	b := &bytes.Buffer{}
+
+	b.WriteString("x, y\n")
+	for i := 0; i < N; i++ {
+		y := math.ExpNeg(x)
+		fmt.Fprintf(b, "%s,%s\n", x, y)
+		x = x.Add(x, step)
+	}
+
+	golden.Assert(t, b.Bytes())
+}
+
+func TestExpNeg256(t *testing.T) {
+	// Reference values floor(e^-x * 2^256) were computed independently with 700-bit arithmetic.
+	for _, tc := range []struct {
+		num, denom int64
+		expected   string
+	}{
+		{0, 1, "115792089237316195423570985008687907853269984665640564039457584007913129639936"},
+		{1, 2, "70231452274613512381056602985783713128433276244614338325793586768344059560728"},
+		{1, 1, "42597529080697662913911602080600932014987715856510989744817822076425378192109"},
+		{5, 2, "9504793485703466392035556218231925128768659254922458042699240625396504219921"},
+		{5, 1, "780200960194411271448883298131840372405376032958648359718723605233746333234"},
+	} {
+		t.Run(fmt.Sprintf("%d/%d", tc.num, tc.denom), func(t *testing.T) {
+			x := new(big.Int).Lsh(big.NewInt(tc.num), math.Precision256)
+			x = x.Div(x, big.NewInt(tc.denom))
+			expected, ok := new(big.Int).SetString(tc.expected, 10)
+			require.True(t, ok)
+
+			// The result is within one unit in the last place.
+			diff := new(big.Int).Sub(math.ExpNeg256(x), expected)
+			assert.True(t, diff.CmpAbs(big.NewInt(1)) <= 0, "error %v", diff)
+		})
+	}
+}
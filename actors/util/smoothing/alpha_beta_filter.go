@@ -0,0 +1,71 @@
+package smoothing
+
+import (
+	abi "github.com/filecoin-project/specs-actors/actors/abi"
+	big "github.com/filecoin-project/specs-actors/actors/abi/big"
+)
+
+// Estimates are held in Q.128 fixed point format, as are the reward actor's computations.
+const precision = 128
+
+var (
+	// Filter gains in Q.128 format, tuned to smooth per-epoch noise while tracking trends over days.
+	// DefaultAlpha = floor(0.000925 * 2^128)
+	DefaultAlpha = big.MustFromString("314761189401868078703621511874385595")
+	// DefaultBeta = floor(0.000000284 * 2^128)
+	DefaultBeta = big.MustFromString("96640192205546523623598388510622")
+)
+
+// An alpha-beta filter estimate of a quantity's value (position) and rate of change per epoch (velocity).
+// Both values are in Q.128 format.
+type FilterEstimate struct {
+	PositionEstimate big.Int
+	VelocityEstimate big.Int
+}
+
+// Returns an estimate with some initial position and velocity, given as integers.
+func NewEstimate(position, velocity big.Int) FilterEstimate {
+	return FilterEstimate{
+		PositionEstimate: big.Lsh(position, precision), // Q.0 => Q.128
+		VelocityEstimate: big.Lsh(velocity, precision), // Q.0 => Q.128
+	}
+}
+
+// Returns the integer part of the position estimate.
+func (fe *FilterEstimate) Estimate() big.Int {
+	return big.Rsh(fe.PositionEstimate, precision) // Q.128 => Q.0
+}
+
+// Returns the estimate following an observation made some number of epochs after the previous estimate,
+// using the default filter gains.
+func NextEstimate(prev FilterEstimate, observation big.Int, epochDelta abi.ChainEpoch) FilterEstimate {
+	return NextEstimateWithGains(prev, observation, epochDelta, DefaultAlpha, DefaultBeta)
+}
+
+// Returns the estimate following an observation made some number of epochs after the previous estimate.
+// The position is first projected forward by the velocity, then both are corrected towards the observation
+// in proportion to the residual, with gains alpha and beta (in Q.128 format).
+func NextEstimateWithGains(prev FilterEstimate, observation big.Int, epochDelta abi.ChainEpoch, alpha, beta big.Int) FilterEstimate {
+	if epochDelta <= 0 {
+		epochDelta = 1
+	}
+	deltaT := big.Lsh(big.NewInt(int64(epochDelta)), precision) // Q.0 => Q.128
+
+	deltaX := big.Mul(deltaT, prev.VelocityEstimate) // Q.128 * Q.128 => Q.256
+	deltaX = big.Rsh(deltaX, precision)              // Q.256 => Q.128
+	position := big.Add(prev.PositionEstimate, deltaX)
+
+	residual := big.Sub(big.Lsh(observation, precision), position) // Q.128
+	revisionX := big.Mul(alpha, residual)                          // Q.128 * Q.128 => Q.256
+	revisionX = big.Rsh(revisionX, precision)                      // Q.256 => Q.128
+	position = big.Add(position, revisionX)
+
+	revisionV := big.Mul(beta, residual)   // Q.128 * Q.128 => Q.256
+	revisionV = big.Div(revisionV, deltaT) // Q.256 / Q.128 => Q.128
+	velocity := big.Add(prev.VelocityEstimate, revisionV)
+
+	return FilterEstimate{
+		PositionEstimate: position,
+		VelocityEstimate: velocity,
+	}
+}
@@ -0,0 +1,63 @@
+package smoothing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/util/smoothing"
+)
+
+func TestFilterEstimate(t *testing.T) {
+	// Returns a gain in Q.128 format equal to num/denom.
+	gain := func(num, denom int64) big.Int {
+		return big.Div(big.Lsh(big.NewInt(num), 128), big.NewInt(denom))
+	}
+
+	t.Run("round trips initial estimate", func(t *testing.T) {
+		est := smoothing.NewEstimate(big.NewInt(1_000_000), big.NewInt(5))
+		assert.Equal(t, big.NewInt(1_000_000), est.Estimate())
+	})
+
+	t.Run("steady observations leave estimate unchanged", func(t *testing.T) {
+		est := smoothing.NewEstimate(big.NewInt(1<<40), big.Zero())
+		for i := 0; i < 100; i++ {
+			est = smoothing.NextEstimate(est, big.NewInt(1<<40), 1)
+		}
+		assert.Equal(t, big.NewInt(1<<40), est.Estimate())
+		assert.Equal(t, big.Zero(), est.VelocityEstimate)
+	})
+
+	t.Run("projects position by velocity over epoch delta", func(t *testing.T) {
+		est := smoothing.NewEstimate(big.NewInt(1000), big.NewInt(10))
+		// An observation matching the projection leaves nothing to correct.
+		est = smoothing.NextEstimate(est, big.NewInt(1050), 5)
+		assert.Equal(t, big.NewInt(1050), est.Estimate())
+		assert.Equal(t, big.Lsh(big.NewInt(10), 128), est.VelocityEstimate)
+	})
+
+	t.Run("moves part way towards an outlier", func(t *testing.T) {
+		est := smoothing.NewEstimate(big.NewInt(1_000_000), big.Zero())
+		est = smoothing.NextEstimate(est, big.NewInt(2_000_000), 1)
+		assert.True(t, est.Estimate().GreaterThan(big.NewInt(1_000_000)))
+		assert.True(t, est.Estimate().LessThan(big.NewInt(1_001_000)))
+		assert.True(t, est.VelocityEstimate.GreaterThan(big.Zero()))
+	})
+
+	t.Run("converges on a steady trend", func(t *testing.T) {
+		alpha, beta := gain(1, 2), gain(1, 10)
+		est := smoothing.NewEstimate(big.Zero(), big.Zero())
+		for epoch := int64(1); epoch <= 200; epoch++ {
+			est = smoothing.NextEstimateWithGains(est, big.NewInt(100*epoch), 1, alpha, beta)
+		}
+		assert.Equal(t, big.NewInt(20_000), est.Estimate())
+		assert.Equal(t, big.NewInt(100), big.Rsh(est.VelocityEstimate, 128))
+	})
+
+	t.Run("treats a non-positive epoch delta as one epoch", func(t *testing.T) {
+		est := smoothing.NewEstimate(big.NewInt(1000), big.NewInt(10))
+		assert.Equal(t, smoothing.NextEstimate(est, big.NewInt(1500), 1), smoothing.NextEstimate(est, big.NewInt(1500), abi.ChainEpoch(0)))
+	})
+}
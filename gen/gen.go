@@ -17,6 +17,8 @@ import (
 	system "github.com/filecoin-project/specs-actors/actors/builtin/system"
 	verifreg "github.com/filecoin-project/specs-actors/actors/builtin/verifreg"
 	puppet "github.com/filecoin-project/specs-actors/actors/puppet"
+	states "github.com/filecoin-project/specs-actors/actors/states"
+	smoothing "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 )
 
 func main() {
@@ -26,6 +28,7 @@ func main() {
 		abi.SectorID{},
 		abi.SectorInfo{},
 		abi.SealVerifyInfo{},
+		abi.ReplicaUpdateInfo{},
 		abi.PoStProof{},
 		abi.WindowPoStVerifyInfo{},
 		abi.WinningPoStVerifyInfo{},
@@ -36,6 +39,20 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./actors/builtin/cbor_gen.go", "builtin",
 		builtin.MinerAddrs{},
 		builtin.ConfirmSectorProofsParams{},
+		builtin.ApplyRewardParams{},
+		builtin.ApplyRewardsReturn{},
+	); err != nil {
+		panic(err)
+	}
+
+	if err := gen.WriteTupleEncodersToFile("./actors/util/smoothing/cbor_gen.go", "smoothing",
+		smoothing.FilterEstimate{},
+	); err != nil {
+		panic(err)
+	}
+
+	if err := gen.WriteTupleEncodersToFile("./actors/states/cbor_gen.go", "states",
+		states.Actor{},
 	); err != nil {
 		panic(err)
 	}
@@ -83,6 +100,7 @@ func main() {
 		reward.AwardBlockRewardParams{},
 		// method returns
 		reward.ThisEpochRewardReturn{},
+		reward.MintingStatsReturn{},
 	); err != nil {
 		panic(err)
 	}
@@ -127,13 +145,18 @@ func main() {
 		power.State{},
 		power.Claim{},
 		power.CronEvent{},
+		power.DeferredProof{},
+		power.SealProofPolicy{},
 		// method params
+		power.ConstructorParams{},
 		power.CreateMinerParams{},
 		power.EnrollCronEventParams{},
+		power.DeleteMinerParams{},
 		power.UpdateClaimedPowerParams{},
 		// method returns
 		power.CreateMinerReturn{},
 		power.CurrentTotalPowerReturn{},
+		power.MinerPowerReturn{},
 		// other types
 		power.MinerConstructorParams{},
 		power.SectorStorageWeightDesc{},
@@ -176,11 +199,16 @@ func main() {
 		miner.SectorPreCommitInfo{},
 		miner.SectorOnChainInfo{},
 		miner.WorkerKeyChange{},
+		miner.BeneficiaryTerm{},
+		miner.PendingBeneficiaryChange{},
+		miner.WindowedPoSt{},
 		// method params
 		// miner.ConstructorParams{},
 		miner.SubmitWindowedPoStParams{},
 		miner.TerminateSectorsParams{},
 		miner.TerminateSectorsReturn{},
+		miner.TerminationReport{},
+		miner.SectorTerminationReport{},
 		miner.ChangePeerIDParams{},
 		miner.ChangeMultiaddrsParams{},
 		miner.ProveCommitSectorParams{},
@@ -193,6 +221,13 @@ func main() {
 		miner.CheckSectorProvenParams{},
 		miner.WithdrawBalanceParams{},
 		miner.CompactPartitionsParams{},
+		miner.DisputeWindowedPoStParams{},
+		miner.ProveReplicaUpdatesParams{},
+		miner.MaskSectorNumbersParams{},
+		miner.MovePartitionsParams{},
+		miner.CompactSectorNumbersParams{},
+		miner.ChangeBeneficiaryParams{},
+		miner.GetBeneficiaryReturn{},
 		// other types
 		miner.CronEventPayload{},
 		miner.FaultDeclaration{},
@@ -200,6 +235,7 @@ func main() {
 		miner.ExpirationExtension{},
 		miner.TerminationDeclaration{},
 		miner.PoStPartition{},
+		miner.ReplicaUpdate{},
 	); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,135 @@
+// Package calculator exposes the miner actor's pledge, penalty and reward policy for use outside actor code,
+// such as estimating the cost of committing a sector under some network conditions.
+package calculator
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/actors/util/smoothing"
+)
+
+// The network-wide values that the miner actor consults when a sector is committed.
+// These are the values reported by the reward and power actors, and the runtime's circulating supply.
+type NetworkConditions struct {
+	QAPower           abi.StoragePower // Total quality-adjusted power
+	BaselinePower     abi.StoragePower // Baseline power for the current epoch
+	TotalPledge       abi.TokenAmount  // Total pledge collateral locked by all miners
+	EpochReward       abi.TokenAmount  // Block reward for the current epoch
+	CirculatingSupply abi.TokenAmount
+}
+
+// Describes a sector to be committed.
+type SectorProfile struct {
+	Size               abi.SectorSize
+	Duration           abi.ChainEpoch // Epochs between activation and expiration
+	DealWeight         abi.DealWeight // Deal space-time, in byte-epochs
+	VerifiedDealWeight abi.DealWeight // Verified deal space-time, in byte-epochs
+}
+
+// The costs and rewards for a sector, holding network conditions constant over its life.
+type SectorEconomics struct {
+	QAPower abi.StoragePower
+	// Deposit required at pre-commitment, refunded when the sector is proven.
+	PreCommitDeposit abi.TokenAmount
+	// Pledge locked while the sector is active.
+	InitialPledge abi.TokenAmount
+	// Block reward expected to be earned by the sector per day.
+	ExpectedDayReward abi.TokenAmount
+	// Block reward expected to be earned by the sector over its whole life.
+	ExpectedLifetimeReward abi.TokenAmount
+	// Penalty charged per proving period for a declared fault.
+	DeclaredFaultCost abi.TokenAmount
+	// Penalty charged for a fault detected by a missed Window PoSt.
+	UndeclaredFaultCost abi.TokenAmount
+	// Penalty charged for terminating the sector immediately after activation, and the largest penalty
+	// charged for terminating it at any age.
+	MinTerminationCost abi.TokenAmount
+	MaxTerminationCost abi.TokenAmount
+}
+
+// Penalty for terminating a sector at some age.
+type TerminationCost struct {
+	Age     abi.ChainEpoch
+	Penalty abi.TokenAmount
+}
+
+// Computes the economics of a sector under some network conditions.
+func Compute(network NetworkConditions, sector SectorProfile) (*SectorEconomics, error) {
+	if err := sector.validate(); err != nil {
+		return nil, err
+	}
+	qaPower := miner.QAPowerForWeight(sector.Size, sector.Duration, sector.DealWeight, sector.VerifiedDealWeight)
+	rewardEstimate, powerEstimate := network.estimates()
+	pledge := miner.InitialPledgeForPower(qaPower, powerEstimate, network.BaselinePower, network.TotalPledge,
+		rewardEstimate, network.CirculatingSupply)
+	dayReward := miner.ExpectedDayRewardForPower(rewardEstimate, powerEstimate, qaPower)
+
+	return &SectorEconomics{
+		QAPower: qaPower,
+		PreCommitDeposit: miner.PreCommitDepositForPower(qaPower, powerEstimate, network.BaselinePower, network.TotalPledge,
+			rewardEstimate, network.CirculatingSupply),
+		InitialPledge:          pledge,
+		ExpectedDayReward:      dayReward,
+		ExpectedLifetimeReward: big.Div(big.Mul(dayReward, big.NewInt(int64(sector.Duration))), big.NewInt(builtin.EpochsInDay)),
+		DeclaredFaultCost:      miner.PledgePenaltyForDeclaredFault(rewardEstimate, powerEstimate, qaPower),
+		UndeclaredFaultCost:    miner.PledgePenaltyForUndeclaredFault(rewardEstimate, powerEstimate, qaPower),
+		MinTerminationCost:     miner.PledgePenaltyForTermination(pledge, 0, rewardEstimate, powerEstimate, qaPower),
+		MaxTerminationCost:     miner.PledgePenaltyForTermination(pledge, sector.Duration, rewardEstimate, powerEstimate, qaPower),
+	}, nil
+}
+
+// Computes the termination penalty for a sector at intervals of step epochs over its life, including at its expiration.
+func TerminationSchedule(network NetworkConditions, sector SectorProfile, step abi.ChainEpoch) ([]TerminationCost, error) {
+	if err := sector.validate(); err != nil {
+		return nil, err
+	}
+	if step <= 0 {
+		return nil, xerrors.Errorf("step must be positive, was %d", step)
+	}
+	qaPower := miner.QAPowerForWeight(sector.Size, sector.Duration, sector.DealWeight, sector.VerifiedDealWeight)
+	rewardEstimate, powerEstimate := network.estimates()
+	pledge := miner.InitialPledgeForPower(qaPower, powerEstimate, network.BaselinePower, network.TotalPledge,
+		rewardEstimate, network.CirculatingSupply)
+
+	var schedule []TerminationCost
+	for age := abi.ChainEpoch(0); ; age += step {
+		if age > sector.Duration {
+			age = sector.Duration
+		}
+		schedule = append(schedule, TerminationCost{
+			Age:     age,
+			Penalty: miner.PledgePenaltyForTermination(pledge, age, rewardEstimate, powerEstimate, qaPower),
+		})
+		if age == sector.Duration {
+			return schedule, nil
+		}
+	}
+}
+
+// Returns steady estimates of the epoch reward and network power, as the actors' smoothed values would
+// converge to if network conditions were held constant.
+func (n *NetworkConditions) estimates() (reward, power smoothing.FilterEstimate) {
+	return smoothing.NewEstimate(n.EpochReward, big.Zero()), smoothing.NewEstimate(n.QAPower, big.Zero())
+}
+
+func (s *SectorProfile) validate() error {
+	if s.Size == 0 {
+		return xerrors.Errorf("sector size must be positive")
+	}
+	if s.Duration <= 0 {
+		return xerrors.Errorf("sector duration must be positive, was %d", s.Duration)
+	}
+	if s.DealWeight.Nil() || s.DealWeight.LessThan(big.Zero()) ||
+		s.VerifiedDealWeight.Nil() || s.VerifiedDealWeight.LessThan(big.Zero()) {
+		return xerrors.Errorf("deal weights must be non-negative")
+	}
+	spaceTime := big.Mul(big.NewIntUnsigned(uint64(s.Size)), big.NewInt(int64(s.Duration)))
+	if big.Add(s.DealWeight, s.VerifiedDealWeight).GreaterThan(spaceTime) {
+		return xerrors.Errorf("deal weights %v and %v exceed sector space-time %v", s.DealWeight, s.VerifiedDealWeight, spaceTime)
+	}
+	return nil
+}
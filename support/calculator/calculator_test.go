@@ -0,0 +1,86 @@
+package calculator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/actors/util/smoothing"
+	"github.com/filecoin-project/specs-actors/support/calculator"
+)
+
+var network = calculator.NetworkConditions{
+	QAPower:           big.NewInt(1 << 50),
+	BaselinePower:     big.NewInt(1 << 50),
+	TotalPledge:       big.Zero(),
+	EpochReward:       big.Mul(big.NewInt(100), abi.TokenPrecision),
+	CirculatingSupply: big.Mul(big.NewInt(1_000_000), abi.TokenPrecision),
+}
+
+func ccSector(duration abi.ChainEpoch) calculator.SectorProfile {
+	return calculator.SectorProfile{
+		Size:               abi.SectorSize(32 << 30),
+		Duration:           duration,
+		DealWeight:         big.Zero(),
+		VerifiedDealWeight: big.Zero(),
+	}
+}
+
+func TestCompute(t *testing.T) {
+	t.Run("matches miner policy", func(t *testing.T) {
+		sector := ccSector(180 * builtin.EpochsInDay)
+		sector.VerifiedDealWeight = big.Mul(big.NewIntUnsigned(uint64(sector.Size)), big.NewInt(int64(sector.Duration/2)))
+
+		econ, err := calculator.Compute(network, sector)
+		require.NoError(t, err)
+
+		qaPower := miner.QAPowerForWeight(sector.Size, sector.Duration, sector.DealWeight, sector.VerifiedDealWeight)
+		rewardEstimate := smoothing.NewEstimate(network.EpochReward, big.Zero())
+		powerEstimate := smoothing.NewEstimate(network.QAPower, big.Zero())
+		pledge := miner.InitialPledgeForPower(qaPower, powerEstimate, network.BaselinePower, network.TotalPledge,
+			rewardEstimate, network.CirculatingSupply)
+		dayReward := miner.ExpectedDayRewardForPower(rewardEstimate, powerEstimate, qaPower)
+
+		assert.Equal(t, qaPower, econ.QAPower)
+		assert.Equal(t, pledge, econ.InitialPledge)
+		assert.Equal(t, pledge, econ.PreCommitDeposit)
+		assert.Equal(t, dayReward, econ.ExpectedDayReward)
+		assert.Equal(t, big.Mul(dayReward, big.NewInt(180)), econ.ExpectedLifetimeReward)
+		assert.Equal(t, miner.PledgePenaltyForDeclaredFault(rewardEstimate, powerEstimate, qaPower), econ.DeclaredFaultCost)
+		assert.Equal(t, miner.PledgePenaltyForUndeclaredFault(rewardEstimate, powerEstimate, qaPower), econ.UndeclaredFaultCost)
+		assert.Equal(t, miner.PledgePenaltyForTermination(pledge, 0, rewardEstimate, powerEstimate, qaPower), econ.MinTerminationCost)
+		assert.Equal(t, miner.PledgePenaltyForTermination(pledge, sector.Duration, rewardEstimate, powerEstimate, qaPower), econ.MaxTerminationCost)
+	})
+
+	t.Run("rejects invalid sectors", func(t *testing.T) {
+		_, err := calculator.Compute(network, ccSector(0))
+		assert.Error(t, err)
+
+		sector := ccSector(builtin.EpochsInDay)
+		sector.DealWeight = big.Mul(big.NewIntUnsigned(uint64(sector.Size)), big.NewInt(int64(sector.Duration+1)))
+		_, err = calculator.Compute(network, sector)
+		assert.Error(t, err)
+	})
+}
+
+func TestTerminationSchedule(t *testing.T) {
+	sector := ccSector(100 * builtin.EpochsInDay)
+	schedule, err := calculator.TerminationSchedule(network, sector, 30*builtin.EpochsInDay)
+	require.NoError(t, err)
+
+	// Ages 0, 30, 60 and 90 days, then the sector's expiration.
+	require.Len(t, schedule, 5)
+	assert.Equal(t, abi.ChainEpoch(0), schedule[0].Age)
+	assert.Equal(t, sector.Duration, schedule[4].Age)
+	for i := 1; i < len(schedule); i++ {
+		assert.True(t, schedule[i].Penalty.GreaterThan(schedule[i-1].Penalty))
+	}
+
+	_, err = calculator.TerminationSchedule(network, sector, 0)
+	assert.Error(t, err)
+}
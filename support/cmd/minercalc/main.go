@@ -0,0 +1,109 @@
+// Command minercalc prints the deposit, pledge, expected reward and penalties for committing a sector
+// under some network conditions.
+//
+// Usage:
+//
+//	go run ./support/cmd/minercalc -qa-power=<bytes> -epoch-reward=<attoFIL> -circulating-supply=<attoFIL> [flags]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/support/calculator"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("minercalc", flag.ContinueOnError)
+	qaPower := flags.String("qa-power", "0", "network quality-adjusted power, in bytes")
+	baselinePower := flags.String("baseline-power", "0", "network baseline power, in bytes")
+	totalPledge := flags.String("total-pledge", "0", "network total pledge collateral, in attoFIL")
+	epochReward := flags.String("epoch-reward", "0", "block reward for the current epoch, in attoFIL")
+	circulatingSupply := flags.String("circulating-supply", "0", "circulating supply, in attoFIL")
+	sectorSize := flags.Uint64("sector-size", uint64(32<<30), "sector size, in bytes")
+	durationDays := flags.Int64("duration-days", 180, "sector lifetime, in days")
+	dealPercent := flags.Int64("deal-percent", 0, "percentage of the sector's space-time filled with deals")
+	verifiedPercent := flags.Int64("verified-percent", 0, "percentage of the sector's space-time filled with verified deals")
+	stepDays := flags.Int64("step-days", 30, "interval between termination penalties reported, in days")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var network calculator.NetworkConditions
+	for _, v := range []struct {
+		name  string
+		value string
+		out   *big.Int
+	}{
+		{"qa-power", *qaPower, &network.QAPower},
+		{"baseline-power", *baselinePower, &network.BaselinePower},
+		{"total-pledge", *totalPledge, &network.TotalPledge},
+		{"epoch-reward", *epochReward, &network.EpochReward},
+		{"circulating-supply", *circulatingSupply, &network.CirculatingSupply},
+	} {
+		parsed, err := big.FromString(v.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", v.name, v.value, err)
+		}
+		*v.out = parsed
+	}
+
+	if *dealPercent < 0 || *verifiedPercent < 0 || *dealPercent+*verifiedPercent > 100 {
+		return fmt.Errorf("deal percentages %d and %d must be non-negative and sum to at most 100", *dealPercent, *verifiedPercent)
+	}
+	duration := abi.ChainEpoch(*durationDays * builtin.EpochsInDay)
+	spaceTime := big.Mul(big.NewIntUnsigned(*sectorSize), big.NewInt(int64(duration)))
+	sector := calculator.SectorProfile{
+		Size:               abi.SectorSize(*sectorSize),
+		Duration:           duration,
+		DealWeight:         big.Div(big.Mul(spaceTime, big.NewInt(*dealPercent)), big.NewInt(100)),
+		VerifiedDealWeight: big.Div(big.Mul(spaceTime, big.NewInt(*verifiedPercent)), big.NewInt(100)),
+	}
+
+	econ, err := calculator.Compute(network, sector)
+	if err != nil {
+		return err
+	}
+	schedule, err := calculator.TerminationSchedule(network, sector, abi.ChainEpoch(*stepDays*builtin.EpochsInDay))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("QA power:                 %s bytes\n", econ.QAPower)
+	fmt.Printf("Pre-commit deposit:       %s FIL\n", formatFIL(econ.PreCommitDeposit))
+	fmt.Printf("Initial pledge:           %s FIL\n", formatFIL(econ.InitialPledge))
+	fmt.Printf("Expected daily reward:    %s FIL\n", formatFIL(econ.ExpectedDayReward))
+	fmt.Printf("Expected lifetime reward: %s FIL\n", formatFIL(econ.ExpectedLifetimeReward))
+	fmt.Printf("Declared fault (per day): %s FIL\n", formatFIL(econ.DeclaredFaultCost))
+	fmt.Printf("Undeclared fault:         %s FIL\n", formatFIL(econ.UndeclaredFaultCost))
+	fmt.Println("Termination penalty by sector age:")
+	for _, cost := range schedule {
+		fmt.Printf("  day %4d: %s FIL\n", cost.Age/builtin.EpochsInDay, formatFIL(cost.Penalty))
+	}
+	return nil
+}
+
+// Formats an attoFIL amount as a decimal number of FIL.
+func formatFIL(amount abi.TokenAmount) string {
+	sign := ""
+	if amount.LessThan(big.Zero()) {
+		sign = "-"
+		amount = amount.Neg()
+	}
+	whole := big.Div(amount, abi.TokenPrecision)
+	frac := big.Mod(amount, abi.TokenPrecision)
+	return fmt.Sprintf("%s%s.%018d", sign, whole, frac.Int)
+}
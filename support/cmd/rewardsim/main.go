@@ -0,0 +1,158 @@
+// Command rewardsim projects block reward emissions for a trajectory of network realized power.
+// It drives the reward actor's state through the same per-epoch update the actor performs, printing
+// CSV rows of the epoch reward, effective network time and cumulative amount minted.
+//
+// The realized power trajectory is either read from a CSV file of "epoch,power" rows, each power holding
+// from its epoch until the next row, or grows exponentially from an initial value at some annual rate.
+//
+// Usage:
+//
+//	go run ./support/cmd/rewardsim -power-csv=<file> [flags]
+//	go run ./support/cmd/rewardsim -initial-power=<bytes> -annual-growth=<percent> [flags]
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	gbig "math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/builtin/reward"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("rewardsim", flag.ContinueOnError)
+	powerCSV := flags.String("power-csv", "", "file of \"epoch,power\" rows giving realized power, in bytes, from each epoch")
+	initialPower := flags.String("initial-power", "0", "realized power at genesis, in bytes, if no CSV is given")
+	annualGrowth := flags.Float64("annual-growth", 0, "annual growth of realized power, in percent, if no CSV is given")
+	days := flags.Int64("days", 365, "number of days to simulate")
+	stepEpochs := flags.Int64("step-epochs", builtin.EpochsInDay, "interval between rows printed, in epochs")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if *days <= 0 || *stepEpochs <= 0 {
+		return fmt.Errorf("days %d and step %d must be positive", *days, *stepEpochs)
+	}
+
+	var powerAt func(abi.ChainEpoch) abi.StoragePower
+	if *powerCSV != "" {
+		f, err := os.Open(*powerCSV)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		if powerAt, err = readTrajectory(f); err != nil {
+			return fmt.Errorf("invalid power trajectory %s: %w", *powerCSV, err)
+		}
+	} else {
+		initial, err := big.FromString(*initialPower)
+		if err != nil {
+			return fmt.Errorf("invalid initial-power %q: %w", *initialPower, err)
+		}
+		if initial.LessThan(big.Zero()) || *annualGrowth <= -100 {
+			return fmt.Errorf("initial power %s must be non-negative and growth %v%% greater than -100%%", initial, *annualGrowth)
+		}
+		powerAt = exponentialTrajectory(initial, *annualGrowth)
+	}
+
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"epoch", "realized_power", "baseline_power", "epoch_reward", "effective_network_time", "cumulative_minted"}); err != nil {
+		return err
+	}
+
+	// The reward computed at the end of each epoch is paid in the next. The genesis state holds the reward for epoch 0.
+	// Each epoch is assumed to produce the expected number of winning blocks, so pays out the whole epoch reward.
+	st := reward.ConstructState(powerAt(0))
+	end := abi.ChainEpoch(*days * builtin.EpochsInDay)
+	for epoch := abi.ChainEpoch(0); epoch <= end; epoch++ {
+		st.RecordBlockReward(st.ThisEpochReward, builtin.ExpectedLeadersPerEpoch)
+		realized := powerAt(epoch)
+		if int64(epoch)%*stepEpochs == 0 {
+			if err := w.Write([]string{
+				strconv.FormatInt(int64(epoch), 10),
+				realized.String(),
+				st.ThisEpochBaselinePower.String(),
+				st.ThisEpochReward.String(),
+				strconv.FormatInt(int64(st.EffectiveNetworkTime), 10),
+				st.TotalMinted.String(),
+			}); err != nil {
+				return err
+			}
+		}
+		st.UpdateNetworkKPI(epoch, realized)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// Reads a realized power trajectory from "epoch,power" rows, with an optional header.
+// Power is zero before the first row's epoch.
+func readTrajectory(r io.Reader) (func(abi.ChainEpoch) abi.StoragePower, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	var epochs []abi.ChainEpoch
+	var powers []abi.StoragePower
+	for i, row := range rows {
+		if len(row) != 2 {
+			return nil, fmt.Errorf("row %d has %d fields, expected 2", i+1, len(row))
+		}
+		epoch, err := strconv.ParseInt(strings.TrimSpace(row[0]), 10, 64)
+		if err != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, fmt.Errorf("row %d: invalid epoch %q", i+1, row[0])
+		}
+		power, err := big.FromString(strings.TrimSpace(row[1]))
+		if err != nil || power.LessThan(big.Zero()) {
+			return nil, fmt.Errorf("row %d: invalid power %q", i+1, row[1])
+		}
+		if len(epochs) > 0 && abi.ChainEpoch(epoch) <= epochs[len(epochs)-1] {
+			return nil, fmt.Errorf("row %d: epoch %d not after previous row", i+1, epoch)
+		}
+		epochs = append(epochs, abi.ChainEpoch(epoch))
+		powers = append(powers, power)
+	}
+	if len(epochs) == 0 {
+		return nil, fmt.Errorf("no rows")
+	}
+
+	return func(epoch abi.ChainEpoch) abi.StoragePower {
+		// Index of the first row after epoch.
+		i := sort.Search(len(epochs), func(i int) bool { return epochs[i] > epoch })
+		if i == 0 {
+			return big.Zero()
+		}
+		return powers[i-1]
+	}, nil
+}
+
+// Returns a trajectory growing from an initial power by some percentage each year.
+// The trajectory is an input to the simulation, so is computed in floating point.
+func exponentialTrajectory(initial abi.StoragePower, annualGrowthPercent float64) func(abi.ChainEpoch) abi.StoragePower {
+	return func(epoch abi.ChainEpoch) abi.StoragePower {
+		growth := math.Pow(1+annualGrowthPercent/100, float64(epoch)/float64(builtin.EpochsInYear))
+		power, _ := new(gbig.Float).Mul(new(gbig.Float).SetInt(initial.Int), gbig.NewFloat(growth)).Int(nil)
+		return big.Int{Int: power}
+	}
+}
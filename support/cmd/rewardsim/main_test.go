@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/builtin/reward"
+)
+
+func TestReadTrajectory(t *testing.T) {
+	t.Run("skips header and holds power between rows", func(t *testing.T) {
+		powerAt, err := readTrajectory(strings.NewReader("epoch,power\n10,100\n20, 300\n"))
+		require.NoError(t, err)
+		assert.Equal(t, big.Zero(), powerAt(0))
+		assert.Equal(t, big.Zero(), powerAt(9))
+		assert.Equal(t, big.NewInt(100), powerAt(10))
+		assert.Equal(t, big.NewInt(100), powerAt(19))
+		assert.Equal(t, big.NewInt(300), powerAt(20))
+		assert.Equal(t, big.NewInt(300), powerAt(1000))
+	})
+
+	t.Run("header is optional", func(t *testing.T) {
+		powerAt, err := readTrajectory(strings.NewReader("0,5\n"))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(5), powerAt(0))
+	})
+
+	t.Run("rejects invalid epoch after header", func(t *testing.T) {
+		_, err := readTrajectory(strings.NewReader("epoch,power\nten,100\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects epochs out of order", func(t *testing.T) {
+		_, err := readTrajectory(strings.NewReader("20,100\n10,100\n"))
+		assert.Error(t, err)
+		_, err = readTrajectory(strings.NewReader("10,100\n10,200\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects negative power", func(t *testing.T) {
+		_, err := readTrajectory(strings.NewReader("0,-1\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects empty trajectory", func(t *testing.T) {
+		_, err := readTrajectory(strings.NewReader("epoch,power\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("matches reward state updated as by the actor", func(t *testing.T) {
+		initial := big.Lsh(big.NewInt(1), 40)
+		step := builtin.EpochsInDay / 4
+		var out bytes.Buffer
+		require.NoError(t, run([]string{"-initial-power=" + initial.String(), "-annual-growth=0", "-days=1",
+			"-step-epochs=" + strconv.Itoa(step)}, &out))
+
+		rows, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 1+builtin.EpochsInDay/step+1)
+		assert.Equal(t, []string{"epoch", "realized_power", "baseline_power", "epoch_reward", "effective_network_time", "cumulative_minted"}, rows[0])
+
+		// Replay the simulation against the reward state directly, as the reward actor updates it.
+		st := reward.ConstructState(initial)
+		minted := big.Zero()
+		row := 1
+		for epoch := abi.ChainEpoch(0); epoch <= builtin.EpochsInDay; epoch++ {
+			minted = big.Add(minted, st.ThisEpochReward)
+			if int64(epoch)%int64(step) == 0 {
+				assert.Equal(t, []string{
+					strconv.FormatInt(int64(epoch), 10),
+					initial.String(),
+					st.ThisEpochBaselinePower.String(),
+					st.ThisEpochReward.String(),
+					strconv.FormatInt(int64(st.EffectiveNetworkTime), 10),
+					minted.String(),
+				}, rows[row])
+				row++
+			}
+			st.UpdateNetworkKPI(epoch, initial)
+		}
+	})
+
+	t.Run("rejects invalid flags", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.Error(t, run([]string{"-days=0"}, &out))
+		assert.Error(t, run([]string{"-initial-power=-1"}, &out))
+		assert.Error(t, run([]string{"-annual-growth=-100"}, &out))
+	})
+}
@@ -60,6 +60,7 @@ type Runtime struct {
 	expectVerifySigs               []*expectVerifySig
 	expectCreateActor              *expectCreateActor
 	expectVerifySeal               *expectVerifySeal
+	expectVerifyReplicaUpdate      *expectVerifyReplicaUpdate
 	expectComputeUnsealedSectorCID *expectComputeUnsealedSectorCID
 	expectVerifyPoSt               *expectVerifyPoSt
 	expectVerifyConsensusFault     *expectVerifyConsensusFault
@@ -105,6 +106,11 @@ type expectVerifySeal struct {
 	result error
 }
 
+type expectVerifyReplicaUpdate struct {
+	update abi.ReplicaUpdateInfo
+	result error
+}
+
 type expectComputeUnsealedSectorCID struct {
 	reg       abi.RegisteredSealProof
 	pieces    []abi.PieceInfo
@@ -524,6 +530,24 @@ func (rt *Runtime) VerifySeal(seal abi.SealVerifyInfo) error {
 	return nil
 }
 
+func (rt *Runtime) VerifyReplicaUpdate(update abi.ReplicaUpdateInfo) error {
+	exp := rt.expectVerifyReplicaUpdate
+	if exp != nil {
+		if !reflect.DeepEqual(exp.update, update) {
+			rt.failTest("unexpected replica update verification\n"+
+				"        : %v\n"+
+				"expected: %v",
+				update, exp.update)
+		}
+		defer func() {
+			rt.expectVerifyReplicaUpdate = nil
+		}()
+		return exp.result
+	}
+	rt.failTestNow("unexpected syscall to verify replica update %v", update)
+	return nil
+}
+
 func (rt *Runtime) BatchVerifySeals(vis map[address.Address][]abi.SealVerifyInfo) (map[address.Address][]bool, error) {
 	out := make(map[address.Address][]bool)
 	for k, v := range vis { //nolint:nomaprange
@@ -740,6 +764,13 @@ func (rt *Runtime) ExpectVerifySeal(seal abi.SealVerifyInfo, result error) {
 	}
 }
 
+func (rt *Runtime) ExpectVerifyReplicaUpdate(update abi.ReplicaUpdateInfo, result error) {
+	rt.expectVerifyReplicaUpdate = &expectVerifyReplicaUpdate{
+		update: update,
+		result: result,
+	}
+}
+
 func (rt *Runtime) ExpectComputeUnsealedSectorCID(reg abi.RegisteredSealProof, pieces []abi.PieceInfo, cid cid.Cid, err error) {
 	rt.expectComputeUnsealedSectorCID = &expectComputeUnsealedSectorCID{
 		reg, pieces, cid, err,
@@ -794,6 +825,10 @@ func (rt *Runtime) Verify() {
 		rt.failTest("missing expected verify seal with %v", rt.expectVerifySeal.seal)
 	}
 
+	if rt.expectVerifyReplicaUpdate != nil {
+		rt.failTest("missing expected verify replica update with %v", rt.expectVerifyReplicaUpdate.update)
+	}
+
 	if rt.expectComputeUnsealedSectorCID != nil {
 		rt.failTest("missing expected ComputeUnsealedSectorCID with %v", rt.expectComputeUnsealedSectorCID)
 	}
@@ -818,6 +853,7 @@ func (rt *Runtime) Reset() {
 	rt.expectCreateActor = nil
 	rt.expectVerifySigs = nil
 	rt.expectVerifySeal = nil
+	rt.expectVerifyReplicaUpdate = nil
 	rt.expectComputeUnsealedSectorCID = nil
 }
 